@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,6 +26,8 @@ const (
 type ShortenURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ActiveFrom    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +69,20 @@ func (x *ShortenURLRequest) GetOriginalUrl() string {
 	return ""
 }
 
+func (x *ShortenURLRequest) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 type ShortenURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
@@ -305,6 +322,7 @@ func (x *BatchResultItem) GetShortUrl() string {
 type RetrieveURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -346,6 +364,13 @@ func (x *RetrieveURLRequest) GetShortUrl() string {
 	return ""
 }
 
+func (x *RetrieveURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type RetrieveURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
@@ -390,27 +415,27 @@ func (x *RetrieveURLResponse) GetOriginalUrl() string {
 	return ""
 }
 
-type GetUserURLsResponse struct {
+type DescribeURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Urls          []*UserURLItem         `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserURLsResponse) Reset() {
-	*x = GetUserURLsResponse{}
+func (x *DescribeURLRequest) Reset() {
+	*x = DescribeURLRequest{}
 	mi := &file_shortener_shortener_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserURLsResponse) String() string {
+func (x *DescribeURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserURLsResponse) ProtoMessage() {}
+func (*DescribeURLRequest) ProtoMessage() {}
 
-func (x *GetUserURLsResponse) ProtoReflect() protoreflect.Message {
+func (x *DescribeURLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_shortener_shortener_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -422,40 +447,46 @@ func (x *GetUserURLsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserURLsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserURLsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeURLRequest.ProtoReflect.Descriptor instead.
+func (*DescribeURLRequest) Descriptor() ([]byte, []int) {
 	return file_shortener_shortener_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetUserURLsResponse) GetUrls() []*UserURLItem {
+func (x *DescribeURLRequest) GetShortUrl() string {
 	if x != nil {
-		return x.Urls
+		return x.ShortUrl
 	}
-	return nil
+	return ""
 }
 
-type UserURLItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
-	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DescribeURLResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl          string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Status            string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ActiveFrom        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,7,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	ClicksLeft        *int64                 `protobuf:"varint,8,opt,name=clicks_left,json=clicksLeft,proto3,oneof" json:"clicks_left,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *UserURLItem) Reset() {
-	*x = UserURLItem{}
+func (x *DescribeURLResponse) Reset() {
+	*x = DescribeURLResponse{}
 	mi := &file_shortener_shortener_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserURLItem) String() string {
+func (x *DescribeURLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserURLItem) ProtoMessage() {}
+func (*DescribeURLResponse) ProtoMessage() {}
 
-func (x *UserURLItem) ProtoReflect() protoreflect.Message {
+func (x *DescribeURLResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_shortener_shortener_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,46 +498,88 @@ func (x *UserURLItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserURLItem.ProtoReflect.Descriptor instead.
-func (*UserURLItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use DescribeURLResponse.ProtoReflect.Descriptor instead.
+func (*DescribeURLResponse) Descriptor() ([]byte, []int) {
 	return file_shortener_shortener_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UserURLItem) GetShortUrl() string {
+func (x *DescribeURLResponse) GetShortUrl() string {
 	if x != nil {
 		return x.ShortUrl
 	}
 	return ""
 }
 
-func (x *UserURLItem) GetOriginalUrl() string {
+func (x *DescribeURLResponse) GetOriginalUrl() string {
 	if x != nil {
 		return x.OriginalUrl
 	}
 	return ""
 }
 
-type DeleteUserURLsRequest struct {
+func (x *DescribeURLResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DescribeURLResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DescribeURLResponse) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *DescribeURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *DescribeURLResponse) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *DescribeURLResponse) GetClicksLeft() int64 {
+	if x != nil && x.ClicksLeft != nil {
+		return *x.ClicksLeft
+	}
+	return 0
+}
+
+type BatchRetrieveURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortUrls     []string               `protobuf:"bytes,1,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserURLsRequest) Reset() {
-	*x = DeleteUserURLsRequest{}
+func (x *BatchRetrieveURLRequest) Reset() {
+	*x = BatchRetrieveURLRequest{}
 	mi := &file_shortener_shortener_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserURLsRequest) String() string {
+func (x *BatchRetrieveURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserURLsRequest) ProtoMessage() {}
+func (*BatchRetrieveURLRequest) ProtoMessage() {}
 
-func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchRetrieveURLRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_shortener_shortener_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -518,40 +591,39 @@ func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserURLsRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserURLsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchRetrieveURLRequest.ProtoReflect.Descriptor instead.
+func (*BatchRetrieveURLRequest) Descriptor() ([]byte, []int) {
 	return file_shortener_shortener_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DeleteUserURLsRequest) GetShortUrls() []string {
+func (x *BatchRetrieveURLRequest) GetShortUrls() []string {
 	if x != nil {
 		return x.ShortUrls
 	}
 	return nil
 }
 
-type GetStatsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Urls          uint64                 `protobuf:"varint,1,opt,name=urls,proto3" json:"urls,omitempty"`
-	Users         uint64                 `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+type BatchRetrieveURLResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Items         []*BatchRetrieveURLItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStatsResponse) Reset() {
-	*x = GetStatsResponse{}
+func (x *BatchRetrieveURLResponse) Reset() {
+	*x = BatchRetrieveURLResponse{}
 	mi := &file_shortener_shortener_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStatsResponse) String() string {
+func (x *BatchRetrieveURLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatsResponse) ProtoMessage() {}
+func (*BatchRetrieveURLResponse) ProtoMessage() {}
 
-func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchRetrieveURLResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_shortener_shortener_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -563,68 +635,1007 @@ func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchRetrieveURLResponse.ProtoReflect.Descriptor instead.
+func (*BatchRetrieveURLResponse) Descriptor() ([]byte, []int) {
 	return file_shortener_shortener_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetStatsResponse) GetUrls() uint64 {
+func (x *BatchRetrieveURLResponse) GetItems() []*BatchRetrieveURLItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchRetrieveURLItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl          string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Status            string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,4,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BatchRetrieveURLItem) Reset() {
+	*x = BatchRetrieveURLItem{}
+	mi := &file_shortener_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRetrieveURLItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRetrieveURLItem) ProtoMessage() {}
+
+func (x *BatchRetrieveURLItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRetrieveURLItem.ProtoReflect.Descriptor instead.
+func (*BatchRetrieveURLItem) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchRetrieveURLItem) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *BatchRetrieveURLItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *BatchRetrieveURLItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchRetrieveURLItem) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+type GetUserURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*UserURLItem         `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserURLsResponse) Reset() {
+	*x = GetUserURLsResponse{}
+	mi := &file_shortener_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserURLsResponse) ProtoMessage() {}
+
+func (x *GetUserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserURLsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUserURLsResponse) GetUrls() []*UserURLItem {
 	if x != nil {
 		return x.Urls
 	}
-	return 0
+	return nil
 }
 
-func (x *GetStatsResponse) GetUsers() uint64 {
+type UserURLItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ActiveFrom    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserURLItem) Reset() {
+	*x = UserURLItem{}
+	mi := &file_shortener_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLItem) ProtoMessage() {}
+
+func (x *UserURLItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[14]
 	if x != nil {
-		return x.Users
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-var File_shortener_shortener_proto protoreflect.FileDescriptor
+// Deprecated: Use UserURLItem.ProtoReflect.Descriptor instead.
+func (*UserURLItem) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{14}
+}
 
-const file_shortener_shortener_proto_rawDesc = "" +
-	"\n" +
-	"\x19shortener/shortener.proto\x12\tshortener\x1a\x1bgoogle/protobuf/empty.proto\"6\n" +
-	"\x11ShortenURLRequest\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"1\n" +
-	"\x12ShortenURLResponse\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"G\n" +
-	"\x16BatchShortenURLRequest\x12-\n" +
-	"\x05items\x18\x01 \x03(\v2\x17.shortener.BatchURLItemR\x05items\"X\n" +
-	"\fBatchURLItem\x12%\n" +
-	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"K\n" +
-	"\x17BatchShortenURLResponse\x120\n" +
-	"\x05items\x18\x01 \x03(\v2\x1a.shortener.BatchResultItemR\x05items\"U\n" +
-	"\x0fBatchResultItem\x12%\n" +
-	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
-	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"1\n" +
-	"\x12RetrieveURLRequest\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"8\n" +
-	"\x13RetrieveURLResponse\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"A\n" +
-	"\x13GetUserURLsResponse\x12*\n" +
-	"\x04urls\x18\x01 \x03(\v2\x16.shortener.UserURLItemR\x04urls\"M\n" +
-	"\vUserURLItem\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"6\n" +
-	"\x15DeleteUserURLsRequest\x12\x1d\n" +
-	"\n" +
-	"short_urls\x18\x01 \x03(\tR\tshortUrls\"<\n" +
-	"\x10GetStatsResponse\x12\x12\n" +
-	"\x04urls\x18\x01 \x01(\x04R\x04urls\x12\x14\n" +
-	"\x05users\x18\x02 \x01(\x04R\x05users2\x9f\x04\n" +
-	"\x10ShortenerService\x12K\n" +
-	"\n" +
-	"ShortenURL\x12\x1c.shortener.ShortenURLRequest\x1a\x1d.shortener.ShortenURLResponse\"\x00\x12Z\n" +
-	"\x0fBatchShortenURL\x12!.shortener.BatchShortenURLRequest\x1a\".shortener.BatchShortenURLResponse\"\x00\x12N\n" +
-	"\vRetrieveURL\x12\x1d.shortener.RetrieveURLRequest\x1a\x1e.shortener.RetrieveURLResponse\"\x00\x12G\n" +
-	"\vGetUserURLs\x12\x16.google.protobuf.Empty\x1a\x1e.shortener.GetUserURLsResponse\"\x00\x12L\n" +
-	"\x0eDeleteUserURLs\x12 .shortener.DeleteUserURLsRequest\x1a\x16.google.protobuf.Empty\"\x00\x128\n" +
+func (x *UserURLItem) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UserURLItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UserURLItem) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *UserURLItem) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ExportURLItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl          string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Status            string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ActiveFrom        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ClicksLeft        *int64                 `protobuf:"varint,7,opt,name=clicks_left,json=clicksLeft,proto3,oneof" json:"clicks_left,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,8,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	Tags              []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportURLItem) Reset() {
+	*x = ExportURLItem{}
+	mi := &file_shortener_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportURLItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportURLItem) ProtoMessage() {}
+
+func (x *ExportURLItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportURLItem.ProtoReflect.Descriptor instead.
+func (*ExportURLItem) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExportURLItem) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ExportURLItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ExportURLItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExportURLItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ExportURLItem) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *ExportURLItem) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ExportURLItem) GetClicksLeft() int64 {
+	if x != nil && x.ClicksLeft != nil {
+		return *x.ClicksLeft
+	}
+	return 0
+}
+
+func (x *ExportURLItem) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *ExportURLItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type DeleteUserURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrls     []string               `protobuf:"bytes,1,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserURLsRequest) Reset() {
+	*x = DeleteUserURLsRequest{}
+	mi := &file_shortener_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserURLsRequest) ProtoMessage() {}
+
+func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserURLsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserURLsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteUserURLsRequest) GetShortUrls() []string {
+	if x != nil {
+		return x.ShortUrls
+	}
+	return nil
+}
+
+type ImportURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportURLRequest) Reset() {
+	*x = ImportURLRequest{}
+	mi := &file_shortener_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportURLRequest) ProtoMessage() {}
+
+func (x *ImportURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportURLRequest.ProtoReflect.Descriptor instead.
+func (*ImportURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ImportURLRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ImportURLRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ImportURLRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ImportURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ImportURLResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           uint64                 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,4,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportURLResult) Reset() {
+	*x = ImportURLResult{}
+	mi := &file_shortener_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportURLResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportURLResult) ProtoMessage() {}
+
+func (x *ImportURLResult) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportURLResult.ProtoReflect.Descriptor instead.
+func (*ImportURLResult) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportURLResult) GetRow() uint64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportURLResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportURLResult) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ImportURLResult) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ImportURLResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportURLsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Results          []*ImportURLResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created          uint64                 `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Conflicts        uint64                 `protobuf:"varint,3,opt,name=conflicts,proto3" json:"conflicts,omitempty"`
+	Invalid          uint64                 `protobuf:"varint,4,opt,name=invalid,proto3" json:"invalid,omitempty"`
+	ResultsTruncated bool                   `protobuf:"varint,5,opt,name=results_truncated,json=resultsTruncated,proto3" json:"results_truncated,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportURLsResponse) Reset() {
+	*x = ImportURLsResponse{}
+	mi := &file_shortener_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportURLsResponse) ProtoMessage() {}
+
+func (x *ImportURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportURLsResponse.ProtoReflect.Descriptor instead.
+func (*ImportURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportURLsResponse) GetResults() []*ImportURLResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportURLsResponse) GetCreated() uint64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportURLsResponse) GetConflicts() uint64 {
+	if x != nil {
+		return x.Conflicts
+	}
+	return 0
+}
+
+func (x *ImportURLsResponse) GetInvalid() uint64 {
+	if x != nil {
+		return x.Invalid
+	}
+	return 0
+}
+
+func (x *ImportURLsResponse) GetResultsTruncated() bool {
+	if x != nil {
+		return x.ResultsTruncated
+	}
+	return false
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          uint64                 `protobuf:"varint,1,opt,name=urls,proto3" json:"urls,omitempty"`
+	Users         uint64                 `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_shortener_shortener_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetStatsResponse) GetUrls() uint64 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetUsers() uint64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+type Destination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variant       string                 `protobuf:"bytes,1,opt,name=variant,proto3" json:"variant,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Weight        uint32                 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Destination) Reset() {
+	*x = Destination{}
+	mi := &file_shortener_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Destination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Destination) ProtoMessage() {}
+
+func (x *Destination) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Destination.ProtoReflect.Descriptor instead.
+func (*Destination) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Destination) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Destination) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Destination) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type SetDestinationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Destinations  []*Destination         `protobuf:"bytes,2,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDestinationsRequest) Reset() {
+	*x = SetDestinationsRequest{}
+	mi := &file_shortener_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDestinationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDestinationsRequest) ProtoMessage() {}
+
+func (x *SetDestinationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDestinationsRequest.ProtoReflect.Descriptor instead.
+func (*SetDestinationsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetDestinationsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *SetDestinationsRequest) GetDestinations() []*Destination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+type GetDestinationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDestinationsRequest) Reset() {
+	*x = GetDestinationsRequest{}
+	mi := &file_shortener_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDestinationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDestinationsRequest) ProtoMessage() {}
+
+func (x *GetDestinationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDestinationsRequest.ProtoReflect.Descriptor instead.
+func (*GetDestinationsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDestinationsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type DestinationStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   *Destination           `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Hits          uint64                 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestinationStat) Reset() {
+	*x = DestinationStat{}
+	mi := &file_shortener_shortener_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestinationStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationStat) ProtoMessage() {}
+
+func (x *DestinationStat) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationStat.ProtoReflect.Descriptor instead.
+func (*DestinationStat) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DestinationStat) GetDestination() *Destination {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *DestinationStat) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+type GetDestinationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destinations  []*DestinationStat     `protobuf:"bytes,1,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDestinationsResponse) Reset() {
+	*x = GetDestinationsResponse{}
+	mi := &file_shortener_shortener_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDestinationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDestinationsResponse) ProtoMessage() {}
+
+func (x *GetDestinationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_shortener_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDestinationsResponse.ProtoReflect.Descriptor instead.
+func (*GetDestinationsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_shortener_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetDestinationsResponse) GetDestinations() []*DestinationStat {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+var File_shortener_shortener_proto protoreflect.FileDescriptor
+
+const file_shortener_shortener_proto_rawDesc = "" +
+	"\n" +
+	"\x19shortener/shortener.proto\x12\tshortener\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x01\n" +
+	"\x11ShortenURLRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12;\n" +
+	"\vactive_from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"1\n" +
+	"\x12ShortenURLResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"G\n" +
+	"\x16BatchShortenURLRequest\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.shortener.BatchURLItemR\x05items\"X\n" +
+	"\fBatchURLItem\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"K\n" +
+	"\x17BatchShortenURLResponse\x120\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.shortener.BatchResultItemR\x05items\"U\n" +
+	"\x0fBatchResultItem\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"M\n" +
+	"\x12RetrieveURLRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"8\n" +
+	"\x13RetrieveURLResponse\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"1\n" +
+	"\x12DescribeURLRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"\x85\x03\n" +
+	"\x13DescribeURLResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vactive_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12-\n" +
+	"\x12password_protected\x18\a \x01(\bR\x11passwordProtected\x12$\n" +
+	"\vclicks_left\x18\b \x01(\x03H\x00R\n" +
+	"clicksLeft\x88\x01\x01B\x0e\n" +
+	"\f_clicks_left\"8\n" +
+	"\x17BatchRetrieveURLRequest\x12\x1d\n" +
+	"\n" +
+	"short_urls\x18\x01 \x03(\tR\tshortUrls\"Q\n" +
+	"\x18BatchRetrieveURLResponse\x125\n" +
+	"\x05items\x18\x01 \x03(\v2\x1f.shortener.BatchRetrieveURLItemR\x05items\"\x9d\x01\n" +
+	"\x14BatchRetrieveURLItem\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12-\n" +
+	"\x12password_protected\x18\x04 \x01(\bR\x11passwordProtected\"A\n" +
+	"\x13GetUserURLsResponse\x12*\n" +
+	"\x04urls\x18\x01 \x03(\v2\x16.shortener.UserURLItemR\x04urls\"\xc5\x01\n" +
+	"\vUserURLItem\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12;\n" +
+	"\vactive_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x93\x03\n" +
+	"\rExportURLItem\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vactive_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12$\n" +
+	"\vclicks_left\x18\a \x01(\x03H\x00R\n" +
+	"clicksLeft\x88\x01\x01\x12-\n" +
+	"\x12password_protected\x18\b \x01(\bR\x11passwordProtected\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tagsB\x0e\n" +
+	"\f_clicks_left\"6\n" +
+	"\x15DeleteUserURLsRequest\x12\x1d\n" +
+	"\n" +
+	"short_urls\x18\x01 \x03(\tR\tshortUrls\"\xa1\x01\n" +
+	"\x10ImportURLRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x93\x01\n" +
+	"\x0fImportURLResult\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x04R\x03row\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tshort_url\x18\x03 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x04 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xc9\x01\n" +
+	"\x12ImportURLsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.shortener.ImportURLResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x04R\acreated\x12\x1c\n" +
+	"\tconflicts\x18\x03 \x01(\x04R\tconflicts\x12\x18\n" +
+	"\ainvalid\x18\x04 \x01(\x04R\ainvalid\x12+\n" +
+	"\x11results_truncated\x18\x05 \x01(\bR\x10resultsTruncated\"<\n" +
+	"\x10GetStatsResponse\x12\x12\n" +
+	"\x04urls\x18\x01 \x01(\x04R\x04urls\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x04R\x05users\"Q\n" +
+	"\vDestination\x12\x18\n" +
+	"\avariant\x18\x01 \x01(\tR\avariant\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\rR\x06weight\"q\n" +
+	"\x16SetDestinationsRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12:\n" +
+	"\fdestinations\x18\x02 \x03(\v2\x16.shortener.DestinationR\fdestinations\"5\n" +
+	"\x16GetDestinationsRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"_\n" +
+	"\x0fDestinationStat\x128\n" +
+	"\vdestination\x18\x01 \x01(\v2\x16.shortener.DestinationR\vdestination\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x04R\x04hits\"Y\n" +
+	"\x17GetDestinationsResponse\x12>\n" +
+	"\fdestinations\x18\x01 \x03(\v2\x1a.shortener.DestinationStatR\fdestinations2\xdd\b\n" +
+	"\x10ShortenerService\x12K\n" +
+	"\n" +
+	"ShortenURL\x12\x1c.shortener.ShortenURLRequest\x1a\x1d.shortener.ShortenURLResponse\"\x00\x12Z\n" +
+	"\x0fBatchShortenURL\x12!.shortener.BatchShortenURLRequest\x1a\".shortener.BatchShortenURLResponse\"\x00\x12N\n" +
+	"\vRetrieveURL\x12\x1d.shortener.RetrieveURLRequest\x1a\x1e.shortener.RetrieveURLResponse\"\x00\x12N\n" +
+	"\vDescribeURL\x12\x1d.shortener.DescribeURLRequest\x1a\x1e.shortener.DescribeURLResponse\"\x00\x12]\n" +
+	"\x10BatchRetrieveURL\x12\".shortener.BatchRetrieveURLRequest\x1a#.shortener.BatchRetrieveURLResponse\"\x00\x12G\n" +
+	"\vGetUserURLs\x12\x16.google.protobuf.Empty\x1a\x1e.shortener.GetUserURLsResponse\"\x00\x12K\n" +
+	"\x0fGetUpcomingURLs\x12\x16.google.protobuf.Empty\x1a\x1e.shortener.GetUserURLsResponse\"\x00\x12F\n" +
+	"\x0eExportUserURLs\x12\x16.google.protobuf.Empty\x1a\x18.shortener.ExportURLItem\"\x000\x01\x12L\n" +
+	"\x0eDeleteUserURLs\x12 .shortener.DeleteUserURLsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12L\n" +
+	"\n" +
+	"ImportURLs\x12\x1b.shortener.ImportURLRequest\x1a\x1d.shortener.ImportURLsResponse\"\x00(\x01\x128\n" +
 	"\x04Ping\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\"\x00\x12A\n" +
-	"\bGetStats\x12\x16.google.protobuf.Empty\x1a\x1b.shortener.GetStatsResponse\"\x00B-Z+github.com/rycln/shorturl/api/gen/shortenerb\x06proto3"
+	"\bGetStats\x12\x16.google.protobuf.Empty\x1a\x1b.shortener.GetStatsResponse\"\x00\x12N\n" +
+	"\x0fSetDestinations\x12!.shortener.SetDestinationsRequest\x1a\x16.google.protobuf.Empty\"\x00\x12Z\n" +
+	"\x0fGetDestinations\x12!.shortener.GetDestinationsRequest\x1a\".shortener.GetDestinationsResponse\"\x00B-Z+github.com/rycln/shorturl/api/gen/shortenerb\x06proto3"
 
 var (
 	file_shortener_shortener_proto_rawDescOnce sync.Once
@@ -638,45 +1649,90 @@ func file_shortener_shortener_proto_rawDescGZIP() []byte {
 	return file_shortener_shortener_proto_rawDescData
 }
 
-var file_shortener_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_shortener_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_shortener_shortener_proto_goTypes = []any{
-	(*ShortenURLRequest)(nil),       // 0: shortener.ShortenURLRequest
-	(*ShortenURLResponse)(nil),      // 1: shortener.ShortenURLResponse
-	(*BatchShortenURLRequest)(nil),  // 2: shortener.BatchShortenURLRequest
-	(*BatchURLItem)(nil),            // 3: shortener.BatchURLItem
-	(*BatchShortenURLResponse)(nil), // 4: shortener.BatchShortenURLResponse
-	(*BatchResultItem)(nil),         // 5: shortener.BatchResultItem
-	(*RetrieveURLRequest)(nil),      // 6: shortener.RetrieveURLRequest
-	(*RetrieveURLResponse)(nil),     // 7: shortener.RetrieveURLResponse
-	(*GetUserURLsResponse)(nil),     // 8: shortener.GetUserURLsResponse
-	(*UserURLItem)(nil),             // 9: shortener.UserURLItem
-	(*DeleteUserURLsRequest)(nil),   // 10: shortener.DeleteUserURLsRequest
-	(*GetStatsResponse)(nil),        // 11: shortener.GetStatsResponse
-	(*emptypb.Empty)(nil),           // 12: google.protobuf.Empty
+	(*ShortenURLRequest)(nil),        // 0: shortener.ShortenURLRequest
+	(*ShortenURLResponse)(nil),       // 1: shortener.ShortenURLResponse
+	(*BatchShortenURLRequest)(nil),   // 2: shortener.BatchShortenURLRequest
+	(*BatchURLItem)(nil),             // 3: shortener.BatchURLItem
+	(*BatchShortenURLResponse)(nil),  // 4: shortener.BatchShortenURLResponse
+	(*BatchResultItem)(nil),          // 5: shortener.BatchResultItem
+	(*RetrieveURLRequest)(nil),       // 6: shortener.RetrieveURLRequest
+	(*RetrieveURLResponse)(nil),      // 7: shortener.RetrieveURLResponse
+	(*DescribeURLRequest)(nil),       // 8: shortener.DescribeURLRequest
+	(*DescribeURLResponse)(nil),      // 9: shortener.DescribeURLResponse
+	(*BatchRetrieveURLRequest)(nil),  // 10: shortener.BatchRetrieveURLRequest
+	(*BatchRetrieveURLResponse)(nil), // 11: shortener.BatchRetrieveURLResponse
+	(*BatchRetrieveURLItem)(nil),     // 12: shortener.BatchRetrieveURLItem
+	(*GetUserURLsResponse)(nil),      // 13: shortener.GetUserURLsResponse
+	(*UserURLItem)(nil),              // 14: shortener.UserURLItem
+	(*ExportURLItem)(nil),            // 15: shortener.ExportURLItem
+	(*DeleteUserURLsRequest)(nil),    // 16: shortener.DeleteUserURLsRequest
+	(*ImportURLRequest)(nil),         // 17: shortener.ImportURLRequest
+	(*ImportURLResult)(nil),          // 18: shortener.ImportURLResult
+	(*ImportURLsResponse)(nil),       // 19: shortener.ImportURLsResponse
+	(*GetStatsResponse)(nil),         // 20: shortener.GetStatsResponse
+	(*Destination)(nil),              // 21: shortener.Destination
+	(*SetDestinationsRequest)(nil),   // 22: shortener.SetDestinationsRequest
+	(*GetDestinationsRequest)(nil),   // 23: shortener.GetDestinationsRequest
+	(*DestinationStat)(nil),          // 24: shortener.DestinationStat
+	(*GetDestinationsResponse)(nil),  // 25: shortener.GetDestinationsResponse
+	(*timestamppb.Timestamp)(nil),    // 26: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),            // 27: google.protobuf.Empty
 }
 var file_shortener_shortener_proto_depIdxs = []int32{
-	3,  // 0: shortener.BatchShortenURLRequest.items:type_name -> shortener.BatchURLItem
-	5,  // 1: shortener.BatchShortenURLResponse.items:type_name -> shortener.BatchResultItem
-	9,  // 2: shortener.GetUserURLsResponse.urls:type_name -> shortener.UserURLItem
-	0,  // 3: shortener.ShortenerService.ShortenURL:input_type -> shortener.ShortenURLRequest
-	2,  // 4: shortener.ShortenerService.BatchShortenURL:input_type -> shortener.BatchShortenURLRequest
-	6,  // 5: shortener.ShortenerService.RetrieveURL:input_type -> shortener.RetrieveURLRequest
-	12, // 6: shortener.ShortenerService.GetUserURLs:input_type -> google.protobuf.Empty
-	10, // 7: shortener.ShortenerService.DeleteUserURLs:input_type -> shortener.DeleteUserURLsRequest
-	12, // 8: shortener.ShortenerService.Ping:input_type -> google.protobuf.Empty
-	12, // 9: shortener.ShortenerService.GetStats:input_type -> google.protobuf.Empty
-	1,  // 10: shortener.ShortenerService.ShortenURL:output_type -> shortener.ShortenURLResponse
-	4,  // 11: shortener.ShortenerService.BatchShortenURL:output_type -> shortener.BatchShortenURLResponse
-	7,  // 12: shortener.ShortenerService.RetrieveURL:output_type -> shortener.RetrieveURLResponse
-	8,  // 13: shortener.ShortenerService.GetUserURLs:output_type -> shortener.GetUserURLsResponse
-	12, // 14: shortener.ShortenerService.DeleteUserURLs:output_type -> google.protobuf.Empty
-	12, // 15: shortener.ShortenerService.Ping:output_type -> google.protobuf.Empty
-	11, // 16: shortener.ShortenerService.GetStats:output_type -> shortener.GetStatsResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	26, // 0: shortener.ShortenURLRequest.active_from:type_name -> google.protobuf.Timestamp
+	26, // 1: shortener.ShortenURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: shortener.BatchShortenURLRequest.items:type_name -> shortener.BatchURLItem
+	5,  // 3: shortener.BatchShortenURLResponse.items:type_name -> shortener.BatchResultItem
+	26, // 4: shortener.DescribeURLResponse.created_at:type_name -> google.protobuf.Timestamp
+	26, // 5: shortener.DescribeURLResponse.active_from:type_name -> google.protobuf.Timestamp
+	26, // 6: shortener.DescribeURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 7: shortener.BatchRetrieveURLResponse.items:type_name -> shortener.BatchRetrieveURLItem
+	14, // 8: shortener.GetUserURLsResponse.urls:type_name -> shortener.UserURLItem
+	26, // 9: shortener.UserURLItem.active_from:type_name -> google.protobuf.Timestamp
+	26, // 10: shortener.UserURLItem.expires_at:type_name -> google.protobuf.Timestamp
+	26, // 11: shortener.ExportURLItem.created_at:type_name -> google.protobuf.Timestamp
+	26, // 12: shortener.ExportURLItem.active_from:type_name -> google.protobuf.Timestamp
+	26, // 13: shortener.ExportURLItem.expires_at:type_name -> google.protobuf.Timestamp
+	26, // 14: shortener.ImportURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	18, // 15: shortener.ImportURLsResponse.results:type_name -> shortener.ImportURLResult
+	21, // 16: shortener.SetDestinationsRequest.destinations:type_name -> shortener.Destination
+	21, // 17: shortener.DestinationStat.destination:type_name -> shortener.Destination
+	24, // 18: shortener.GetDestinationsResponse.destinations:type_name -> shortener.DestinationStat
+	0,  // 19: shortener.ShortenerService.ShortenURL:input_type -> shortener.ShortenURLRequest
+	2,  // 20: shortener.ShortenerService.BatchShortenURL:input_type -> shortener.BatchShortenURLRequest
+	6,  // 21: shortener.ShortenerService.RetrieveURL:input_type -> shortener.RetrieveURLRequest
+	8,  // 22: shortener.ShortenerService.DescribeURL:input_type -> shortener.DescribeURLRequest
+	10, // 23: shortener.ShortenerService.BatchRetrieveURL:input_type -> shortener.BatchRetrieveURLRequest
+	27, // 24: shortener.ShortenerService.GetUserURLs:input_type -> google.protobuf.Empty
+	27, // 25: shortener.ShortenerService.GetUpcomingURLs:input_type -> google.protobuf.Empty
+	27, // 26: shortener.ShortenerService.ExportUserURLs:input_type -> google.protobuf.Empty
+	16, // 27: shortener.ShortenerService.DeleteUserURLs:input_type -> shortener.DeleteUserURLsRequest
+	17, // 28: shortener.ShortenerService.ImportURLs:input_type -> shortener.ImportURLRequest
+	27, // 29: shortener.ShortenerService.Ping:input_type -> google.protobuf.Empty
+	27, // 30: shortener.ShortenerService.GetStats:input_type -> google.protobuf.Empty
+	22, // 31: shortener.ShortenerService.SetDestinations:input_type -> shortener.SetDestinationsRequest
+	23, // 32: shortener.ShortenerService.GetDestinations:input_type -> shortener.GetDestinationsRequest
+	1,  // 33: shortener.ShortenerService.ShortenURL:output_type -> shortener.ShortenURLResponse
+	4,  // 34: shortener.ShortenerService.BatchShortenURL:output_type -> shortener.BatchShortenURLResponse
+	7,  // 35: shortener.ShortenerService.RetrieveURL:output_type -> shortener.RetrieveURLResponse
+	9,  // 36: shortener.ShortenerService.DescribeURL:output_type -> shortener.DescribeURLResponse
+	11, // 37: shortener.ShortenerService.BatchRetrieveURL:output_type -> shortener.BatchRetrieveURLResponse
+	13, // 38: shortener.ShortenerService.GetUserURLs:output_type -> shortener.GetUserURLsResponse
+	13, // 39: shortener.ShortenerService.GetUpcomingURLs:output_type -> shortener.GetUserURLsResponse
+	15, // 40: shortener.ShortenerService.ExportUserURLs:output_type -> shortener.ExportURLItem
+	27, // 41: shortener.ShortenerService.DeleteUserURLs:output_type -> google.protobuf.Empty
+	19, // 42: shortener.ShortenerService.ImportURLs:output_type -> shortener.ImportURLsResponse
+	27, // 43: shortener.ShortenerService.Ping:output_type -> google.protobuf.Empty
+	20, // 44: shortener.ShortenerService.GetStats:output_type -> shortener.GetStatsResponse
+	27, // 45: shortener.ShortenerService.SetDestinations:output_type -> google.protobuf.Empty
+	25, // 46: shortener.ShortenerService.GetDestinations:output_type -> shortener.GetDestinationsResponse
+	33, // [33:47] is the sub-list for method output_type
+	19, // [19:33] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_shortener_shortener_proto_init() }
@@ -684,13 +1740,15 @@ func file_shortener_shortener_proto_init() {
 	if File_shortener_shortener_proto != nil {
 		return
 	}
+	file_shortener_shortener_proto_msgTypes[9].OneofWrappers = []any{}
+	file_shortener_shortener_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortener_shortener_proto_rawDesc), len(file_shortener_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
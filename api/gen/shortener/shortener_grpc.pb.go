@@ -20,13 +20,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortenerService_ShortenURL_FullMethodName      = "/shortener.ShortenerService/ShortenURL"
-	ShortenerService_BatchShortenURL_FullMethodName = "/shortener.ShortenerService/BatchShortenURL"
-	ShortenerService_RetrieveURL_FullMethodName     = "/shortener.ShortenerService/RetrieveURL"
-	ShortenerService_GetUserURLs_FullMethodName     = "/shortener.ShortenerService/GetUserURLs"
-	ShortenerService_DeleteUserURLs_FullMethodName  = "/shortener.ShortenerService/DeleteUserURLs"
-	ShortenerService_Ping_FullMethodName            = "/shortener.ShortenerService/Ping"
-	ShortenerService_GetStats_FullMethodName        = "/shortener.ShortenerService/GetStats"
+	ShortenerService_ShortenURL_FullMethodName       = "/shortener.ShortenerService/ShortenURL"
+	ShortenerService_BatchShortenURL_FullMethodName  = "/shortener.ShortenerService/BatchShortenURL"
+	ShortenerService_RetrieveURL_FullMethodName      = "/shortener.ShortenerService/RetrieveURL"
+	ShortenerService_DescribeURL_FullMethodName      = "/shortener.ShortenerService/DescribeURL"
+	ShortenerService_BatchRetrieveURL_FullMethodName = "/shortener.ShortenerService/BatchRetrieveURL"
+	ShortenerService_GetUserURLs_FullMethodName      = "/shortener.ShortenerService/GetUserURLs"
+	ShortenerService_GetUpcomingURLs_FullMethodName  = "/shortener.ShortenerService/GetUpcomingURLs"
+	ShortenerService_ExportUserURLs_FullMethodName   = "/shortener.ShortenerService/ExportUserURLs"
+	ShortenerService_DeleteUserURLs_FullMethodName   = "/shortener.ShortenerService/DeleteUserURLs"
+	ShortenerService_ImportURLs_FullMethodName       = "/shortener.ShortenerService/ImportURLs"
+	ShortenerService_Ping_FullMethodName             = "/shortener.ShortenerService/Ping"
+	ShortenerService_GetStats_FullMethodName         = "/shortener.ShortenerService/GetStats"
+	ShortenerService_SetDestinations_FullMethodName  = "/shortener.ShortenerService/SetDestinations"
+	ShortenerService_GetDestinations_FullMethodName  = "/shortener.ShortenerService/GetDestinations"
 )
 
 // ShortenerServiceClient is the client API for ShortenerService service.
@@ -36,10 +43,17 @@ type ShortenerServiceClient interface {
 	ShortenURL(ctx context.Context, in *ShortenURLRequest, opts ...grpc.CallOption) (*ShortenURLResponse, error)
 	BatchShortenURL(ctx context.Context, in *BatchShortenURLRequest, opts ...grpc.CallOption) (*BatchShortenURLResponse, error)
 	RetrieveURL(ctx context.Context, in *RetrieveURLRequest, opts ...grpc.CallOption) (*RetrieveURLResponse, error)
+	DescribeURL(ctx context.Context, in *DescribeURLRequest, opts ...grpc.CallOption) (*DescribeURLResponse, error)
+	BatchRetrieveURL(ctx context.Context, in *BatchRetrieveURLRequest, opts ...grpc.CallOption) (*BatchRetrieveURLResponse, error)
 	GetUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetUserURLsResponse, error)
+	GetUpcomingURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetUserURLsResponse, error)
+	ExportUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportURLItem], error)
 	DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ImportURLs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportURLRequest, ImportURLsResponse], error)
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	SetDestinations(ctx context.Context, in *SetDestinationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetDestinations(ctx context.Context, in *GetDestinationsRequest, opts ...grpc.CallOption) (*GetDestinationsResponse, error)
 }
 
 type shortenerServiceClient struct {
@@ -80,6 +94,26 @@ func (c *shortenerServiceClient) RetrieveURL(ctx context.Context, in *RetrieveUR
 	return out, nil
 }
 
+func (c *shortenerServiceClient) DescribeURL(ctx context.Context, in *DescribeURLRequest, opts ...grpc.CallOption) (*DescribeURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeURLResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_DescribeURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) BatchRetrieveURL(ctx context.Context, in *BatchRetrieveURLRequest, opts ...grpc.CallOption) (*BatchRetrieveURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRetrieveURLResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_BatchRetrieveURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortenerServiceClient) GetUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetUserURLsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserURLsResponse)
@@ -90,6 +124,35 @@ func (c *shortenerServiceClient) GetUserURLs(ctx context.Context, in *emptypb.Em
 	return out, nil
 }
 
+func (c *shortenerServiceClient) GetUpcomingURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetUserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetUpcomingURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ExportUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportURLItem], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortenerService_ServiceDesc.Streams[0], ShortenerService_ExportUserURLs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, ExportURLItem]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ExportUserURLsClient = grpc.ServerStreamingClient[ExportURLItem]
+
 func (c *shortenerServiceClient) DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -100,6 +163,19 @@ func (c *shortenerServiceClient) DeleteUserURLs(ctx context.Context, in *DeleteU
 	return out, nil
 }
 
+func (c *shortenerServiceClient) ImportURLs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportURLRequest, ImportURLsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortenerService_ServiceDesc.Streams[1], ShortenerService_ImportURLs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportURLRequest, ImportURLsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ImportURLsClient = grpc.ClientStreamingClient[ImportURLRequest, ImportURLsResponse]
+
 func (c *shortenerServiceClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -120,6 +196,26 @@ func (c *shortenerServiceClient) GetStats(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
+func (c *shortenerServiceClient) SetDestinations(ctx context.Context, in *SetDestinationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ShortenerService_SetDestinations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetDestinations(ctx context.Context, in *GetDestinationsRequest, opts ...grpc.CallOption) (*GetDestinationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDestinationsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetDestinations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility.
@@ -127,10 +223,17 @@ type ShortenerServiceServer interface {
 	ShortenURL(context.Context, *ShortenURLRequest) (*ShortenURLResponse, error)
 	BatchShortenURL(context.Context, *BatchShortenURLRequest) (*BatchShortenURLResponse, error)
 	RetrieveURL(context.Context, *RetrieveURLRequest) (*RetrieveURLResponse, error)
+	DescribeURL(context.Context, *DescribeURLRequest) (*DescribeURLResponse, error)
+	BatchRetrieveURL(context.Context, *BatchRetrieveURLRequest) (*BatchRetrieveURLResponse, error)
 	GetUserURLs(context.Context, *emptypb.Empty) (*GetUserURLsResponse, error)
+	GetUpcomingURLs(context.Context, *emptypb.Empty) (*GetUserURLsResponse, error)
+	ExportUserURLs(*emptypb.Empty, grpc.ServerStreamingServer[ExportURLItem]) error
 	DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*emptypb.Empty, error)
+	ImportURLs(grpc.ClientStreamingServer[ImportURLRequest, ImportURLsResponse]) error
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	GetStats(context.Context, *emptypb.Empty) (*GetStatsResponse, error)
+	SetDestinations(context.Context, *SetDestinationsRequest) (*emptypb.Empty, error)
+	GetDestinations(context.Context, *GetDestinationsRequest) (*GetDestinationsResponse, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -150,18 +253,39 @@ func (UnimplementedShortenerServiceServer) BatchShortenURL(context.Context, *Bat
 func (UnimplementedShortenerServiceServer) RetrieveURL(context.Context, *RetrieveURLRequest) (*RetrieveURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveURL not implemented")
 }
+func (UnimplementedShortenerServiceServer) DescribeURL(context.Context, *DescribeURLRequest) (*DescribeURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) BatchRetrieveURL(context.Context, *BatchRetrieveURLRequest) (*BatchRetrieveURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRetrieveURL not implemented")
+}
 func (UnimplementedShortenerServiceServer) GetUserURLs(context.Context, *emptypb.Empty) (*GetUserURLsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) GetUpcomingURLs(context.Context, *emptypb.Empty) (*GetUserURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpcomingURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) ExportUserURLs(*emptypb.Empty, grpc.ServerStreamingServer[ExportURLItem]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserURLs not implemented")
+}
 func (UnimplementedShortenerServiceServer) DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) ImportURLs(grpc.ClientStreamingServer[ImportURLRequest, ImportURLsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportURLs not implemented")
+}
 func (UnimplementedShortenerServiceServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
 func (UnimplementedShortenerServiceServer) GetStats(context.Context, *emptypb.Empty) (*GetStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedShortenerServiceServer) SetDestinations(context.Context, *SetDestinationsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDestinations not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetDestinations(context.Context, *GetDestinationsRequest) (*GetDestinationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDestinations not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 func (UnimplementedShortenerServiceServer) testEmbeddedByValue()                          {}
 
@@ -237,6 +361,42 @@ func _ShortenerService_RetrieveURL_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_DescribeURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DescribeURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_DescribeURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DescribeURL(ctx, req.(*DescribeURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_BatchRetrieveURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRetrieveURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).BatchRetrieveURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_BatchRetrieveURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).BatchRetrieveURL(ctx, req.(*BatchRetrieveURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShortenerService_GetUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -255,6 +415,35 @@ func _ShortenerService_GetUserURLs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_GetUpcomingURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetUpcomingURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetUpcomingURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetUpcomingURLs(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ExportUserURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortenerServiceServer).ExportUserURLs(m, &grpc.GenericServerStream[emptypb.Empty, ExportURLItem]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ExportUserURLsServer = grpc.ServerStreamingServer[ExportURLItem]
+
 func _ShortenerService_DeleteUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteUserURLsRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +462,13 @@ func _ShortenerService_DeleteUserURLs_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_ImportURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShortenerServiceServer).ImportURLs(&grpc.GenericServerStream[ImportURLRequest, ImportURLsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ImportURLsServer = grpc.ClientStreamingServer[ImportURLRequest, ImportURLsResponse]
+
 func _ShortenerService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -309,6 +505,42 @@ func _ShortenerService_GetStats_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_SetDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDestinationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetDestinations(ctx, req.(*SetDestinationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetDestinations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDestinationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetDestinations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetDestinations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetDestinations(ctx, req.(*GetDestinationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortenerService_ServiceDesc is the grpc.ServiceDesc for ShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -328,10 +560,22 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveURL",
 			Handler:    _ShortenerService_RetrieveURL_Handler,
 		},
+		{
+			MethodName: "DescribeURL",
+			Handler:    _ShortenerService_DescribeURL_Handler,
+		},
+		{
+			MethodName: "BatchRetrieveURL",
+			Handler:    _ShortenerService_BatchRetrieveURL_Handler,
+		},
 		{
 			MethodName: "GetUserURLs",
 			Handler:    _ShortenerService_GetUserURLs_Handler,
 		},
+		{
+			MethodName: "GetUpcomingURLs",
+			Handler:    _ShortenerService_GetUpcomingURLs_Handler,
+		},
 		{
 			MethodName: "DeleteUserURLs",
 			Handler:    _ShortenerService_DeleteUserURLs_Handler,
@@ -344,7 +588,26 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStats",
 			Handler:    _ShortenerService_GetStats_Handler,
 		},
+		{
+			MethodName: "SetDestinations",
+			Handler:    _ShortenerService_SetDestinations_Handler,
+		},
+		{
+			MethodName: "GetDestinations",
+			Handler:    _ShortenerService_GetDestinations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserURLs",
+			Handler:       _ShortenerService_ExportUserURLs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportURLs",
+			Handler:       _ShortenerService_ImportURLs_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "shortener/shortener.proto",
 }
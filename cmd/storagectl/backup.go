@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/rycln/shorturl/internal/transfer"
+)
+
+func runBackup(args []string) (err error) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	from := fs.String("from", "", "Storage to back up")
+	out := fs.String("o", "", "Backup archive file")
+	err = fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *from == "" || *out == "" {
+		return errors.New("storage and archive file required")
+	}
+
+	src, err := openStorage(*from, false)
+	if err != nil {
+		return fmt.Errorf("open storage: %w", err)
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close storage: %w", closeErr))
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// the archive appears under its name only once complete
+	tmp, err := os.CreateTemp(filepath.Dir(*out), filepath.Base(*out)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if tmpCloseErr := tmp.Close(); tmpCloseErr != nil && !errors.Is(tmpCloseErr, os.ErrClosed) {
+			err = errors.Join(err, tmpCloseErr)
+		}
+		if removeErr := os.Remove(tmp.Name()); removeErr != nil {
+			err = errors.Join(err, removeErr)
+		}
+	}()
+
+	manifest, err := transfer.NewBackuper(src).WriteBackup(ctx, tmp)
+	if err != nil {
+		return err
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), *out)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("backup written: %d links, %d tombstones, taken at %s",
+		manifest.Pairs, manifest.Tombstones, manifest.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	return nil
+}
+
+func runRestore(args []string) (err error) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := fs.String("i", "", "Backup archive file")
+	mf := addMigrationFlags(fs)
+	err = fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *in == "" {
+		return errors.New("archive file required")
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+	}()
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	backup, err := transfer.OpenBackup(file, size)
+	if err != nil {
+		return err
+	}
+
+	manifest := backup.Manifest()
+	log.Printf("backup: version %d, %d links, %d tombstones, taken at %s",
+		manifest.Version, manifest.Pairs, manifest.Tombstones, manifest.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	return migrate(backup, "backup:"+*in, mf)
+}
@@ -0,0 +1,58 @@
+// Storagectl is an offline maintenance tool for URL shortener storages.
+//
+// Usage:
+//
+//	storagectl migrate -from <storage> -to <storage> [-dry-run] [-batch n] [-checkpoint file]
+//	storagectl backup -from <storage> -o <archive>
+//	storagectl restore -i <archive> -to <storage> [-dry-run] [-batch n] [-checkpoint file]
+//
+// A storage is given as one of:
+//
+//	db:<dsn>      PostgreSQL database, apply migrations with cmd/migrator first
+//	file:<path>   file storage
+//	mem:<path>    in-memory storage snapshot
+//
+// The migrate command copies URL pairs with their owners and deletion marks.
+// Links already present in the destination are skipped, so an interrupted
+// migration can be restarted. Variant redirect counters are not copied.
+//
+// The backup command writes a point-in-time zip archive of JSON lines files
+// with links and deletion marks, the restore command copies links from such
+// an archive the same way migrate does.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+const usage = `usage: storagectl <command> [flags]
+
+commands:
+  migrate   copy all links from one storage to another
+  backup    write a backup archive of a storage
+  restore   copy all links from a backup archive into a storage
+
+storages: db:<dsn>, file:<path>, mem:<snapshot path>`
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "migrate":
+		err = runMigrate(os.Args[2:])
+	case "backup":
+		err = runBackup(os.Args[2:])
+	case "restore":
+		err = runRestore(os.Args[2:])
+	default:
+		err = fmt.Errorf("unknown command %q\n%s", os.Args[1], usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/transfer"
+)
+
+var errMigrationIncomplete = errors.New("some links are not present in the destination")
+
+// migrationSource is a storage or a backup links are copied from.
+type migrationSource interface {
+	IterateURLPairs(context.Context, func(models.URLInfo) error) error
+}
+
+// migrationFlags are flags common to commands writing into a storage.
+type migrationFlags struct {
+	to     *string
+	dryRun *bool
+	batch  *int
+	cp     *string
+}
+
+func addMigrationFlags(fs *flag.FlagSet) *migrationFlags {
+	return &migrationFlags{
+		to:     fs.String("to", "", "Destination storage"),
+		dryRun: fs.Bool("dry-run", false, "Only report what would be written"),
+		batch:  fs.Int("batch", 500, "Number of links written at once"),
+		cp:     fs.String("checkpoint", "", "Progress file to resume an interrupted run"),
+	}
+}
+
+func runMigrate(args []string) (err error) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	from := fs.String("from", "", "Source storage")
+	mf := addMigrationFlags(fs)
+	err = fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *from == "" || *mf.to == "" {
+		return errors.New("source and destination required")
+	}
+	if *from == *mf.to {
+		return errors.New("source and destination are the same")
+	}
+
+	src, err := openStorage(*from, false)
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close source: %w", closeErr))
+		}
+	}()
+
+	return migrate(src, *from, mf)
+}
+
+// migrate copies all links from src into the destination given by flags.
+// The source spec identifies the run in a checkpoint.
+func migrate(src migrationSource, from string, mf *migrationFlags) (err error) {
+	if *mf.to == "" {
+		return errors.New("destination required")
+	}
+
+	dst, err := openStorage(*mf.to, true)
+	if err != nil {
+		return fmt.Errorf("open destination: %w", err)
+	}
+	defer func() {
+		if closeErr := dst.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close destination: %w", closeErr))
+		}
+	}()
+
+	if *mf.cp != "" && dst.snapshot != "" {
+		// in-memory destination is saved only at the end,
+		// a checkpoint would run ahead of it
+		return errors.New("checkpoint is not supported for mem destination, rerun skips written links anyway")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	migrator := transfer.NewMigrator(src, dst,
+		transfer.WithBatchSize(*mf.batch),
+		transfer.WithDryRun(*mf.dryRun),
+		transfer.WithCheckpoint(*mf.cp, migrationKey(from, *mf.to)),
+	)
+
+	report, err := migrator.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !*mf.dryRun {
+		err = dst.save()
+		if err != nil {
+			return fmt.Errorf("save destination: %w", err)
+		}
+	}
+
+	log.Printf("source: %d links, %d deleted, %d owners", report.Pairs, report.Deleted, report.Owners)
+	if *mf.dryRun {
+		log.Printf("dry run: %d to write, %d already present, %d conflicts",
+			report.Written, report.Existing, report.Conflicts)
+	} else {
+		log.Printf("written: %d resumed, %d written, %d already present, %d conflicts, %d failed verification",
+			report.Resumed, report.Written, report.Existing, report.Conflicts, report.Mismatched)
+	}
+
+	if !report.OK() {
+		return errMigrationIncomplete
+	}
+
+	return nil
+}
+
+// migrationKey identifies the run in a checkpoint
+// without exposing credentials from the storage specs.
+func migrationKey(from, to string) string {
+	sum := sha256.Sum256([]byte(from + "\x00" + to))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rycln/shorturl/internal/storage"
+)
+
+var errStorageSpec = errors.New("storage must be db:<dsn>, file:<path> or mem:<path>")
+
+// openedStorage is a storage opened by the tool.
+type openedStorage struct {
+	storage.Storage
+
+	// snapshot is the file of in-memory storage, empty for other types.
+	snapshot string
+}
+
+// openStorage opens the storage described by spec.
+//
+// A missing snapshot of in-memory storage is only accepted if create is set.
+func openStorage(spec string, create bool) (*openedStorage, error) {
+	strgType, location, ok := strings.Cut(spec, ":")
+	if !ok || location == "" {
+		return nil, fmt.Errorf("%w: %q", errStorageSpec, spec)
+	}
+
+	switch strgType {
+	case "db":
+		strg, err := storage.Factory(storage.NewStorageConfig(
+			storage.WithStorageType("db"),
+			storage.WithDatabaseDsn(location),
+		))
+		if err != nil {
+			return nil, err
+		}
+		return &openedStorage{Storage: strg}, nil
+	case "file":
+		if !create {
+			_, err := os.Stat(location)
+			if err != nil {
+				return nil, err
+			}
+		}
+		strg, err := storage.Factory(storage.NewStorageConfig(
+			storage.WithStorageType("file"),
+			storage.WithFilePath(location),
+		))
+		if err != nil {
+			return nil, err
+		}
+		return &openedStorage{Storage: strg}, nil
+	case "mem":
+		strg := storage.NewAppMemStorage()
+		err := strg.LoadSnapshot(location)
+		if err != nil && !(create && errors.Is(err, os.ErrNotExist)) {
+			return nil, err
+		}
+		return &openedStorage{Storage: strg, snapshot: location}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errStorageSpec, spec)
+	}
+}
+
+// save persists in-memory storage into its snapshot file.
+// It is a no-op for other storage types.
+func (s *openedStorage) save() error {
+	if s.snapshot == "" {
+		return nil
+	}
+	return s.Storage.(*storage.AppMemStorage).SaveSnapshot(s.snapshot)
+}
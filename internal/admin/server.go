@@ -0,0 +1,170 @@
+// Package admin provides the administrative HTTP server exposing profiling,
+// metrics and internal APIs on a private address apart from the public one.
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/rycln/shorturl/internal/middleware"
+)
+
+// realm names the protected area in basic authentication challenges.
+const realm = "shorturl admin"
+
+var (
+	errPublicAddr    = errors.New("admin server must listen on a loopback or private address")
+	errNoCertificate = errors.New("client certificate verification requires a server certificate")
+	errInvalidCA     = errors.New("no certificates found in client CA file")
+)
+
+// options holds admin server configuration.
+type options struct {
+	user         string
+	password     string
+	certFile     string
+	keyFile      string
+	clientCAFile string
+}
+
+// option configures the admin server.
+type option func(*options)
+
+// WithBasicAuth requires HTTP basic authentication with the given credentials.
+// Empty user disables authentication.
+func WithBasicAuth(user, password string) option {
+	return func(o *options) {
+		o.user = user
+		o.password = password
+	}
+}
+
+// WithTLS serves HTTPS with the certificate and key from the given PEM files.
+// Empty paths keep plain HTTP.
+func WithTLS(certFile, keyFile string) option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithClientCA requires clients to present a certificate signed by a CA
+// from the given PEM file (mutual TLS). Requires WithTLS.
+func WithClientCA(caFile string) option {
+	return func(o *options) {
+		o.clientCAFile = caFile
+	}
+}
+
+// Server is the administrative HTTP server.
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer creates an admin server serving h on addr.
+//
+// The address must be a loopback or private IP address or localhost,
+// so the server is never exposed to the public network by mistake.
+func NewServer(addr string, h http.Handler, opts ...option) (*Server, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := checkAddr(addr); err != nil {
+		return nil, err
+	}
+
+	tlsCfg, err := newTLSConfig(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		srv: &http.Server{
+			Addr:      addr,
+			Handler:   middleware.BasicAuth(realm, o.user, o.password)(h),
+			TLSConfig: tlsCfg,
+		},
+	}, nil
+}
+
+// checkAddr verifies that addr is not reachable from the public network.
+func checkAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+
+	if host == "localhost" {
+		return nil
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !(ip.IsLoopback() || ip.IsPrivate()) {
+		return fmt.Errorf("%w: %s", errPublicAddr, addr)
+	}
+	return nil
+}
+
+// newTLSConfig returns nil if TLS is not configured.
+func newTLSConfig(o *options) (*tls.Config, error) {
+	if o.certFile == "" && o.keyFile == "" {
+		if o.clientCAFile != "" {
+			return nil, errNoCertificate
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if o.clientCAFile != "" {
+		pem, err := os.ReadFile(o.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCA, o.clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// ListenAndServe listens on the configured address and serves requests
+// until Shutdown.
+func (s *Server) ListenAndServe() error {
+	l, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve serves requests accepted by l until Shutdown.
+func (s *Server) Serve(l net.Listener) error {
+	if s.srv.TLSConfig != nil {
+		return s.srv.ServeTLS(l, "", "")
+	}
+	return s.srv.Serve(l)
+}
+
+// Shutdown gracefully stops the server, see http.Server.Shutdown.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
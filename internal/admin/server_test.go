@@ -0,0 +1,204 @@
+package admin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestNewServer_Addr(t *testing.T) {
+	tests := []struct {
+		addr string
+		err  error
+	}{
+		{addr: "localhost:8081"},
+		{addr: "127.0.0.1:8081"},
+		{addr: "[::1]:8081"},
+		{addr: "10.0.0.5:8081"},
+		{addr: "192.168.1.10:8081"},
+		{addr: ":8081", err: errPublicAddr},
+		{addr: "0.0.0.0:8081", err: errPublicAddr},
+		{addr: "8.8.8.8:8081", err: errPublicAddr},
+		{addr: "example.com:8081", err: errPublicAddr},
+	}
+	for _, test := range tests {
+		t.Run(test.addr, func(t *testing.T) {
+			_, err := NewServer(test.addr, okHandler)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("missing port", func(t *testing.T) {
+		_, err := NewServer("localhost", okHandler)
+		assert.Error(t, err)
+	})
+}
+
+// serve starts the server on a random local port and returns its address.
+func serve(t *testing.T, s *Server) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(l)
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown(context.Background()))
+		assert.ErrorIs(t, <-done, http.ErrServerClosed)
+	})
+
+	return l.Addr().String()
+}
+
+func TestServer_BasicAuth(t *testing.T) {
+	s, err := NewServer("localhost:0", okHandler, WithBasicAuth("admin", "secret"))
+	require.NoError(t, err)
+	url := "http://" + serve(t, s) + "/"
+
+	get := func(user, password string) int {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, get("admin", "secret"))
+	assert.Equal(t, http.StatusUnauthorized, get("admin", "wrong"))
+	assert.Equal(t, http.StatusUnauthorized, get("", ""))
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, caKey := newCert(t, nil, nil, "test CA")
+	srvCert, srvKey := newCert(t, ca, caKey, "127.0.0.1")
+	clientCert, clientKey := newCert(t, ca, caKey, "client")
+	otherCA, otherKey := newCert(t, nil, nil, "other CA")
+	strangerCert, strangerKey := newCert(t, otherCA, otherKey, "stranger")
+
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", ca.Raw)
+	certFile := writePEM(t, dir, "cert.pem", "CERTIFICATE", srvCert.Raw)
+	keyFile := writePEM(t, dir, "key.pem", "EC PRIVATE KEY", marshalKey(t, srvKey))
+
+	s, err := NewServer("localhost:0", okHandler, WithTLS(certFile, keyFile), WithClientCA(caFile))
+	require.NoError(t, err)
+	url := "https://" + serve(t, s) + "/"
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	get := func(cert *x509.Certificate, key *ecdsa.PrivateKey) error {
+		tlsCfg := &tls.Config{RootCAs: roots}
+		if cert != nil {
+			tlsCfg.Certificates = []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+		defer client.CloseIdleConnections()
+
+		res, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return errors.New(res.Status)
+		}
+		return nil
+	}
+
+	assert.NoError(t, get(clientCert, clientKey))
+	assert.Error(t, get(nil, nil))
+	assert.Error(t, get(strangerCert, strangerKey))
+
+	t.Run("client CA without certificate", func(t *testing.T) {
+		_, err := NewServer("localhost:0", okHandler, WithClientCA(caFile))
+		assert.ErrorIs(t, err, errNoCertificate)
+	})
+
+	t.Run("invalid client CA", func(t *testing.T) {
+		_, err := NewServer("localhost:0", okHandler, WithTLS(certFile, keyFile), WithClientCA(keyFile))
+		assert.ErrorIs(t, err, errInvalidCA)
+	})
+}
+
+// newCert issues a certificate signed by parent, or a self-signed CA
+// certificate if parent is nil.
+func newCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if ip := net.ParseIP(name); ip != nil {
+		tmpl.IPAddresses = []net.IP{ip}
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func marshalKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return der
+}
+
+func writePEM(t *testing.T, dir, name, typ string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600)
+	require.NoError(t, err)
+	return path
+}
@@ -4,12 +4,12 @@ package app
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"net/http/pprof"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -21,17 +21,27 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	pb "github.com/rycln/shorturl/api/gen/shortener"
+	"github.com/rycln/shorturl/internal/admin"
 	"github.com/rycln/shorturl/internal/config"
 	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/geoip"
 	"github.com/rycln/shorturl/internal/grpc/interceptors"
 	"github.com/rycln/shorturl/internal/grpc/server"
 	"github.com/rycln/shorturl/internal/handlers"
+	"github.com/rycln/shorturl/internal/health"
 	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/metrics"
 	"github.com/rycln/shorturl/internal/middleware"
+	"github.com/rycln/shorturl/internal/qr"
 	"github.com/rycln/shorturl/internal/services"
 	"github.com/rycln/shorturl/internal/storage"
+	"github.com/rycln/shorturl/internal/tracing"
+	"github.com/rycln/shorturl/internal/transfer"
 	"github.com/rycln/shorturl/internal/worker"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // buildInfo holds application build metadata that can be set during compilation.
@@ -53,8 +63,16 @@ const (
 	// tickerPeriod specifies the interval for batch operations processing.
 	tickerPeriod = time.Duration(10) * time.Second
 
+	// leaderElectionName names the lock which instances sharing a database
+	// compete for to run singleton background jobs.
+	leaderElectionName = "shorturl-singleton-jobs"
+
 	// shutdownTimeout defines timeout for graceful shutdown
 	shutdownTimeout = 5 * time.Second
+
+	// healthPeriod specifies how often readiness is published
+	// to the gRPC health service.
+	healthPeriod = 5 * time.Second
 )
 
 // App represents the core application layer.
@@ -62,6 +80,7 @@ const (
 // The struct combines all main components and manages their lifecycle:
 // - HTTP server
 // - gRPC server
+// - Admin HTTP server
 // - Background workers
 // - Configuration
 // - Storage
@@ -72,8 +91,27 @@ type App struct {
 	httpserver *http.Server
 	grpcserver *grpc.Server
 	storage    storage.Storage
-	worker     *worker.DeletionProcessor
-	cfg        *config.Cfg
+	geo        *geoip.Reader
+	scheduler  *worker.Scheduler
+	health     *health.Checker
+	grpcHealth *grpchealth.Server
+	// snapshotter is nil unless in-memory storage snapshots are enabled
+	snapshotter *worker.Snapshotter
+	// deletionLog is nil unless deletion queue write-ahead log is enabled
+	deletionLog *worker.DeletionLog
+	// adminserver is nil unless the admin server is enabled
+	adminserver *admin.Server
+	cfg         *config.Cfg
+}
+
+// snapshotSaver is implemented by storages which can be saved into a file.
+type snapshotSaver interface {
+	SaveSnapshot(string) error
+}
+
+// migrationChecker is implemented by storages with a versioned schema.
+type migrationChecker interface {
+	CheckMigrations(context.Context) error
 }
 
 // New constructs and initializes the complete application.
@@ -96,92 +134,192 @@ func New() (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize config: %v", err)
 	}
+	var trustedProxy *net.IPNet
+	if cfg.TrustedProxy != "" {
+		_, trustedProxy, err = net.ParseCIDR(cfg.TrustedProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %v", err)
+		}
+	}
 
-	err = logger.LogInit(cfg.LogLevel)
+	err = logger.LogInit(cfg.LogLevel,
+		logger.WithFormat(cfg.LogFormat),
+		logger.WithSampling(cfg.LogSampling),
+		logger.WithFile(cfg.LogFile),
+		logger.WithAccessFile(cfg.AccessLogFile),
+		logger.WithRotation(cfg.LogMaxSize, cfg.LogMaxAge, cfg.LogMaxBackups),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize logger: %v", err)
 	}
 
+	err = tracing.TraceInit(cfg.TraceExporter)
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize tracing: %v", err)
+	}
+
 	scfg := storage.NewStorageConfig(
 		storage.WithDatabaseDsn(cfg.DatabaseDsn),
 		storage.WithFilePath(cfg.StorageFilePath),
 		storage.WithStorageType(cfg.StorageType),
+		storage.WithMemSnapshotPath(cfg.MemSnapshotPath),
 	)
-	strg, err := storage.Factory(scfg)
+	backend, err := storage.Factory(scfg)
 	if err != nil {
 		return nil, fmt.Errorf("can't initialize storage: %v", err)
 	}
+	// Optional capabilities like snapshots are looked up on the backend itself.
+	strg := storage.Instrument(backend)
+
+	var geo *geoip.Reader
+	if cfg.GeoIPDBPath != "" {
+		geo, err = geoip.Open(cfg.GeoIPDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("can't open GeoIP database: %v", err)
+		}
+	}
 
 	hashService := services.NewHashGen(lengthOfShortURL)
-	shortenerService := services.NewShortener(strg, hashService)
+	shortenerService := newShortenerService(strg, hashService, geo)
 	batchShortenerService := services.NewBatchShortener(strg, hashService)
 	pingService := services.NewPing(strg)
 	authService := services.NewAuth(cfg.Key, jwtExpires)
 	deleteBatchService := services.NewBatchDeleter(strg)
 	statsService := services.NewStatsCollector(strg)
+	backupService := transfer.NewBackuper(strg)
 
-	worker := worker.NewDeletionProcessor(deleteBatchService)
+	var snapshotter *worker.Snapshotter
+	if saver, ok := backend.(snapshotSaver); ok && cfg.MemSnapshotPath != "" {
+		snapshotter = worker.NewSnapshotter(saver, cfg.MemSnapshotPath)
+	}
+
+	var deletionLog *worker.DeletionLog
+	if cfg.DeletionLogPath != "" {
+		deletionLog, err = worker.OpenDeletionLog(cfg.DeletionLogPath)
+		if err != nil {
+			return nil, fmt.Errorf("can't open deletion log: %v", err)
+		}
+	}
+
+	leader := storage.NewLeaderElector(backend, leaderElectionName)
+	scheduler := worker.NewScheduler(worker.WithLeaderElector(leader))
+	if snapshotter != nil {
+		snapshotter.Schedule(scheduler, cfg.MemSnapshotPeriod)
+	}
+
+	worker := newDeletionProcessor(deleteBatchService, deletionLog)
+	worker.Schedule(scheduler, tickerPeriod, cfg.Timeout)
+	err = metrics.Replace(metrics.NewDeletionQueueCollector(worker))
+	if err != nil {
+		return nil, fmt.Errorf("can't register deletion queue metrics: %v", err)
+	}
+
+	checker := health.NewChecker()
+	checker.Register("storage", pingService.PingStorage)
+	checker.Register("deletion_worker", worker.Check)
+	if mc, ok := backend.(migrationChecker); ok {
+		checker.Register("migrations", mc.CheckMigrations)
+	}
 
 	shortenHandler := handlers.NewShortenHandler(shortenerService, authService, cfg.ShortBaseAddr)
 	apiShortenHandler := handlers.NewAPIShortenHandler(shortenerService, authService, cfg.ShortBaseAddr)
-	retrieveHandler := handlers.NewRetrieveHandler(shortenerService)
+	retrieveHandler := handlers.NewRetrieveHandler(shortenerService, trustedProxy)
+	expandHandler := handlers.NewExpandHandler(shortenerService, authService, cfg.ShortBaseAddr)
+	expandBatchHandler := handlers.NewExpandBatchHandler(shortenerService, authService, cfg.ShortBaseAddr)
+	updateOptionsHandler := handlers.NewUpdateOptionsHandler(shortenerService, authService)
+	testRulesHandler := handlers.NewTestRulesHandler(shortenerService, authService)
+	setDestinationsHandler := handlers.NewSetDestinationsHandler(shortenerService, authService)
+	destinationStatsHandler := handlers.NewDestinationStatsHandler(shortenerService, authService)
+	setPasswordHandler := handlers.NewSetPasswordHandler(shortenerService, authService)
+	setClickLimitHandler := handlers.NewSetClickLimitHandler(shortenerService, authService)
 	shortenBatchHandler := handlers.NewShortenBatchHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
 	retrieveBatchHandler := handlers.NewRetrieveBatchHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
+	upcomingHandler := handlers.NewUpcomingHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
+	qrHandler := handlers.NewQRHandler(shortenerService, authService, cfg.ShortBaseAddr)
+	pngQRHandler := handlers.NewPublicQRHandler(shortenerService, cfg.ShortBaseAddr, qr.FormatPNG)
+	svgQRHandler := handlers.NewPublicQRHandler(shortenerService, cfg.ShortBaseAddr, qr.FormatSVG)
+	qrBatchHandler := handlers.NewQRBatchHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
+	importHandler := handlers.NewImportHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
+	exportHandler := handlers.NewExportHandler(batchShortenerService, authService, cfg.ShortBaseAddr)
 	pingHandler := handlers.NewPingHandler(pingService)
 	deleteBatchHandler := handlers.NewDeleteBatchHandler(worker, authService)
 	statsHandler := handlers.NewStatsHandler(statsService)
+	backupHandler := handlers.NewBackupHandler(backupService)
+	deletionQueueHandler := handlers.NewDeletionQueueStatsHandler(worker)
+	livenessHandler := handlers.NewLivenessHandler()
+	readinessHandler := handlers.NewReadinessHandler(checker)
 
 	authMiddleware := middleware.NewAuthMiddleware(authService)
 
-	r := chi.NewRouter()
-
-	r.Use(middleware.Logger)
-	r.Use(chimiddleware.Recoverer)
-
-	r.Group(func(r chi.Router) {
-		r.Use(chimiddleware.Timeout(cfg.Timeout))
-		r.Use(middleware.Compress)
-
-		r.Route("/api", func(r chi.Router) {
-			r.Route("/internal", func(r chi.Router) {
-				r.Use(middleware.TrustedSubnet(cfg.TrustedSubnet))
-				r.Get("/stats", statsHandler.ServeHTTP)
-			})
-			r.Group(func(r chi.Router) {
-				r.Use(authMiddleware.JWT)
-				r.Route("/shorten", func(r chi.Router) {
-					r.Post("/batch", shortenBatchHandler.ServeHTTP)
-					r.Post("/", apiShortenHandler.ServeHTTP)
-				})
-				r.Route("/user/urls", func(r chi.Router) {
-					r.Get("/", retrieveBatchHandler.ServeHTTP)
-					r.Delete("/", deleteBatchHandler.ServeHTTP)
-				})
-			})
+	r := newPublicRouter(publicHandlers{
+		auth:             authMiddleware.JWT,
+		shorten:          shortenHandler,
+		apiShorten:       apiShortenHandler,
+		shortenBatch:     shortenBatchHandler,
+		retrieve:         retrieveHandler,
+		retrieveBatch:    retrieveBatchHandler,
+		expand:           expandHandler,
+		expandBatch:      expandBatchHandler,
+		deleteBatch:      deleteBatchHandler,
+		upcoming:         upcomingHandler,
+		updateOptions:    updateOptionsHandler,
+		testRules:        testRulesHandler,
+		setDestinations:  setDestinationsHandler,
+		destinationStats: destinationStatsHandler,
+		setPassword:      setPasswordHandler,
+		setClickLimit:    setClickLimitHandler,
+		qr:               qrHandler,
+		qrBatch:          qrBatchHandler,
+		pngQR:            pngQRHandler,
+		svgQR:            svgQRHandler,
+		importLinks:      importHandler,
+		export:           exportHandler,
+		ping:             pingHandler,
+		liveness:         livenessHandler,
+		readiness:        readinessHandler,
+	}, cfg.Timeout)
+
+	var adminServer *admin.Server
+	if cfg.AdminAddr != "" {
+		ar := chi.NewRouter()
+
+		ar.Use(middleware.RequestID)
+		ar.Use(middleware.Logger)
+		ar.Use(chimiddleware.Recoverer)
+
+		ar.Get("/metrics", metrics.Handler().ServeHTTP)
+		ar.Route("/api/internal", func(r chi.Router) {
+			r.Use(chimiddleware.Timeout(cfg.Timeout))
+			r.Get("/stats", statsHandler.ServeHTTP)
+			r.Get("/backup", backupHandler.ServeHTTP)
+			r.Get("/deletion-queue", deletionQueueHandler.ServeHTTP)
+			r.Method(http.MethodGet, "/log-level", logger.Level)
+			r.Method(http.MethodPut, "/log-level", logger.Level)
 		})
-		r.With(authMiddleware.JWT).Post("/", shortenHandler.ServeHTTP)
-
-		r.Get("/ping", pingHandler.ServeHTTP)
-		r.Get("/{short}", func(res http.ResponseWriter, req *http.Request) {
-			ctx := context.WithValue(req.Context(), contextkeys.ShortURL, chi.URLParam(req, "short"))
-			retrieveHandler.ServeHTTP(res, req.WithContext(ctx))
+		ar.Route("/debug/pprof", func(r chi.Router) {
+			r.HandleFunc("/", pprof.Index)
+			r.HandleFunc("/cmdline", pprof.Cmdline)
+			r.HandleFunc("/profile", pprof.Profile)
+			r.HandleFunc("/symbol", pprof.Symbol)
+			r.HandleFunc("/trace", pprof.Trace)
+
+			r.Handle("/heap", pprof.Handler("heap"))
+			r.Handle("/goroutine", pprof.Handler("goroutine"))
+			r.Handle("/allocs", pprof.Handler("allocs"))
+			r.Handle("/block", pprof.Handler("block"))
+			r.Handle("/threadcreate", pprof.Handler("threadcreate"))
+			r.Handle("/mutex", pprof.Handler("mutex"))
 		})
-	})
-
-	r.Group(func(r chi.Router) {
-		r.HandleFunc("/debug/pprof/", pprof.Index)
-		r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-		r.HandleFunc("/debug/pprof/profile", pprof.Profile)
-		r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-		r.HandleFunc("/debug/pprof/trace", pprof.Trace)
-
-		r.Handle("/debug/pprof/heap", pprof.Handler("heap"))
-		r.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
-		r.Handle("/debug/pprof/allocs", pprof.Handler("allocs"))
-		r.Handle("/debug/pprof/block", pprof.Handler("block"))
-		r.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
-		r.Handle("/debug/pprof/mutex", pprof.Handler("mutex"))
-	})
+
+		adminServer, err = admin.NewServer(cfg.AdminAddr, ar,
+			admin.WithBasicAuth(cfg.AdminUser, cfg.AdminPassword),
+			admin.WithTLS(cfg.AdminTLSCert, cfg.AdminTLSKey),
+			admin.WithClientCA(cfg.AdminClientCA),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize admin server: %v", err)
+		}
+	}
 
 	s := &http.Server{
 		Addr:    cfg.ServerAddr,
@@ -189,10 +327,19 @@ func New() (*App, error) {
 	}
 
 	g := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
-			logging.UnaryServerInterceptor(interceptors.InterceptorLogger(logger.Log)),
+			interceptors.MetricsUnary,
+			interceptors.RequestIDUnary,
+			logging.UnaryServerInterceptor(interceptors.InterceptorLogger()),
 			auth.UnaryServerInterceptor(interceptors.NewAuthInterceptor(authService).Auth),
 		),
+		grpc.ChainStreamInterceptor(
+			interceptors.MetricsStream,
+			interceptors.RequestIDStream,
+			logging.StreamServerInterceptor(interceptors.InterceptorLogger()),
+			auth.StreamServerInterceptor(interceptors.NewAuthInterceptor(authService).Auth),
+		),
 	)
 
 	gs := server.NewShortenerServer(
@@ -204,18 +351,30 @@ func New() (*App, error) {
 		authService,
 		pingService,
 		statsService,
+		shortenerService,
 		cfg.ShortBaseAddr,
 		cfg.TrustedSubnet,
+		trustedProxy,
 	)
 
 	pb.RegisterShortenerServiceServer(g, gs)
 
+	grpcHealth := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(g, grpcHealth)
+	checker.Schedule(scheduler, healthPeriod, grpcHealth, "", pb.ShortenerService_ServiceDesc.ServiceName)
+
 	return &App{
-		httpserver: s,
-		grpcserver: g,
-		storage:    strg,
-		worker:     worker,
-		cfg:        cfg,
+		httpserver:  s,
+		adminserver: adminServer,
+		grpcserver:  g,
+		storage:     strg,
+		geo:         geo,
+		scheduler:   scheduler,
+		health:      checker,
+		grpcHealth:  grpcHealth,
+		snapshotter: snapshotter,
+		deletionLog: deletionLog,
+		cfg:         cfg,
 	}, nil
 }
 
@@ -224,9 +383,11 @@ func New() (*App, error) {
 // Launches:
 // - HTTP server
 // - gRPC server
-// - Background deletion processor
+// - Admin HTTP server if enabled
+// - Background job scheduler running the deletion processor
+// and in-memory storage snapshots if enabled
 func (app *App) Run() error {
-	doneCh := app.worker.Run(tickerPeriod, app.cfg.Timeout)
+	app.scheduler.Start()
 
 	go func() {
 		if app.cfg.EnableHTTPS {
@@ -242,6 +403,15 @@ func (app *App) Run() error {
 		}
 	}()
 
+	if app.adminserver != nil {
+		go func() {
+			err := app.adminserver.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Admin server error: %v", err)
+			}
+		}()
+	}
+
 	go func() {
 		listen, err := net.Listen("tcp", app.cfg.GRPCPort)
 		if err != nil {
@@ -260,10 +430,10 @@ func (app *App) Run() error {
 
 	<-shutdown
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout+app.cfg.ShutdownDrain)
 	defer cancel()
 
-	err := app.shutdown(shutdownCtx, doneCh)
+	err := app.shutdown(shutdownCtx)
 	if err != nil {
 		return fmt.Errorf("shutdown error: %v", err)
 	}
@@ -280,44 +450,112 @@ func (app *App) Run() error {
 
 // shutdown gracefully shuts down the application components.
 // It performs the following steps in order:
-//  1. Shuts down the HTTP server with the given context
-//  2. Shuts down the gRPC server
-//  3. Shuts down the worker component
-//  4. Waits for either worker completion (doneCh) or context timeout
-func (app *App) shutdown(ctx context.Context, doneCh <-chan struct{}) error {
+//  1. Reports not ready over HTTP and gRPC and keeps serving for the drain period
+//  2. Shuts down the HTTP and admin servers with the given context
+//  3. Shuts down the gRPC server
+//  4. Shuts down the background job scheduler, the deletion processor flushes its queue
+//  5. Waits for either background jobs completion or context timeout
+//  6. Exports buffered trace spans
+func (app *App) shutdown(ctx context.Context) error {
+	app.health.Shutdown()
+	app.grpcHealth.Shutdown()
+
+	select {
+	case <-time.After(app.cfg.ShutdownDrain):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
 	if err := app.httpserver.Shutdown(ctx); err != nil {
 		return err
 	}
 
-	app.grpcserver.GracefulStop()
+	if app.adminserver != nil {
+		if err := app.adminserver.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
 
-	app.worker.Shutdown()
+	app.grpcserver.GracefulStop()
 
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("worker shutdown timeout: %w", ctx.Err())
-	case <-doneCh:
+	if err := app.scheduler.Shutdown(ctx); err != nil {
+		return err
 	}
 
-	return nil
+	return tracing.Shutdown(ctx)
 }
 
 // cleanup performs resource cleanup operations for the application.
 // It handles:
+//   - Closing deletion queue write-ahead log
+//   - Saving in-memory storage snapshot
 //   - Closing storage connections
-//   - Syncing logger buffers (ignoring EINVAL errors for non-buffered logger)
+//   - Closing GeoIP database
+//   - Syncing logger buffers and closing log files
 func (app *App) cleanup() error {
+	if app.deletionLog != nil {
+		if err := app.deletionLog.Close(); err != nil {
+			return fmt.Errorf("deletion log close failed: %w", err)
+		}
+	}
+
+	if app.snapshotter != nil {
+		if err := app.snapshotter.Save(); err != nil {
+			return fmt.Errorf("storage snapshot failed: %w", err)
+		}
+	}
+
 	if err := app.storage.Close(); err != nil {
 		return fmt.Errorf("storage close failed: %w", err)
 	}
 
-	if err := logger.Log.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) {
+	if app.geo != nil {
+		if err := app.geo.Close(); err != nil {
+			return fmt.Errorf("GeoIP database close failed: %w", err)
+		}
+	}
+
+	if err := logger.Sync(); err != nil {
 		return fmt.Errorf("log sync failed: %w", err)
 	}
 
 	return nil
 }
 
+// withShortURL stores short URL path parameter and trailing path segments
+// in request context before calling the handler.
+func withShortURL(h http.Handler) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx := context.WithValue(req.Context(), contextkeys.ShortURL, chi.URLParam(req, "short"))
+		suffix := chi.URLParam(req, "*")
+		if req.URL.RawPath == "" {
+			// chi matches the decoded path unless the request is escaped
+			// in a non-default way, keep the suffix escaped either way
+			suffix = (&url.URL{Path: suffix}).EscapedPath()
+		}
+		ctx = context.WithValue(ctx, contextkeys.PathSuffix, suffix)
+		h.ServeHTTP(res, req.WithContext(ctx))
+	}
+}
+
+// newShortenerService creates shortener service resolving visitor
+// countries with GeoIP database if it is configured.
+func newShortenerService(strg storage.Storage, hashService *services.HashGen, geo *geoip.Reader) *services.Shortener {
+	if geo == nil {
+		return services.NewShortener(strg, hashService)
+	}
+	return services.NewShortener(strg, hashService, services.WithCountryResolver(geo))
+}
+
+// newDeletionProcessor creates deletion processor keeping
+// its queue in the write-ahead log if it is configured.
+func newDeletionProcessor(deleteBatchService *services.BatchDeleter, deletionLog *worker.DeletionLog) *worker.DeletionProcessor {
+	if deletionLog == nil {
+		return worker.NewDeletionProcessor(deleteBatchService)
+	}
+	return worker.NewDeletionProcessor(deleteBatchService, worker.WithDeletionLog(deletionLog))
+}
+
 // printBuildInfo displays the build metadata in a standardized format.
 func printBuildInfo() {
 	if buildVersion == "" {
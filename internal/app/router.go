@@ -0,0 +1,111 @@
+package app
+
+import (
+	"net/http"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/rycln/shorturl/internal/middleware"
+)
+
+// publicHandlers holds handlers of the public HTTP API.
+type publicHandlers struct {
+	auth func(http.Handler) http.Handler
+
+	shorten          http.Handler
+	apiShorten       http.Handler
+	shortenBatch     http.Handler
+	retrieve         http.Handler
+	retrieveBatch    http.Handler
+	expand           http.Handler
+	expandBatch      http.Handler
+	deleteBatch      http.Handler
+	upcoming         http.Handler
+	updateOptions    http.Handler
+	testRules        http.Handler
+	setDestinations  http.Handler
+	destinationStats http.Handler
+	setPassword      http.Handler
+	setClickLimit    http.Handler
+	qr               http.Handler
+	qrBatch          http.Handler
+	pngQR            http.Handler
+	svgQR            http.Handler
+	importLinks      http.Handler
+	export           http.Handler
+	ping             http.Handler
+	liveness         http.Handler
+	readiness        http.Handler
+}
+
+// newPublicRouter routes the public HTTP API.
+//
+// Static first path segments of the routes shadow short URLs,
+// so they must be listed in services.ReservedSlugs.
+func newPublicRouter(h publicHandlers, timeout time.Duration) chi.Router {
+	r := chi.NewRouter()
+
+	r.Use(middleware.Tracing)
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Metrics)
+	r.Use(chimiddleware.Recoverer)
+
+	r.Group(func(r chi.Router) {
+		r.Use(chimiddleware.Timeout(timeout))
+		r.Use(middleware.Compress)
+
+		r.Route("/api", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(h.auth)
+				r.Route("/expand", func(r chi.Router) {
+					r.Method(http.MethodPost, "/batch", h.expandBatch)
+					r.Get("/{short}", withShortURL(h.expand))
+				})
+				r.Route("/shorten", func(r chi.Router) {
+					r.Method(http.MethodPost, "/batch", h.shortenBatch)
+					r.Method(http.MethodPost, "/", h.apiShorten)
+				})
+				r.Route("/user/urls", func(r chi.Router) {
+					r.Method(http.MethodGet, "/", h.retrieveBatch)
+					r.Method(http.MethodDelete, "/", h.deleteBatch)
+					r.Method(http.MethodGet, "/upcoming", h.upcoming)
+					r.Method(http.MethodGet, "/qr", h.qrBatch)
+					r.Get("/{short}/qr", withShortURL(h.qr))
+					r.Put("/{short}/options", withShortURL(h.updateOptions))
+					r.Post("/{short}/rules/test", withShortURL(h.testRules))
+					r.Put("/{short}/destinations", withShortURL(h.setDestinations))
+					r.Get("/{short}/destinations", withShortURL(h.destinationStats))
+					r.Put("/{short}/password", withShortURL(h.setPassword))
+					r.Put("/{short}/limit", withShortURL(h.setClickLimit))
+				})
+			})
+		})
+		r.With(h.auth).Method(http.MethodPost, "/", h.shorten)
+
+		r.Method(http.MethodGet, "/ping", h.ping)
+		r.With(h.auth).Get("/{short}+", withShortURL(h.expand))
+		r.Get("/{short}.png", withShortURL(h.pngQR))
+		r.Get("/{short}.svg", withShortURL(h.svgQR))
+		r.Get("/{short}", withShortURL(h.retrieve))
+		r.Get("/{short}/*", withShortURL(h.retrieve))
+		r.Post("/{short}", withShortURL(h.retrieve))
+		r.Post("/{short}/*", withShortURL(h.retrieve))
+	})
+
+	// Import and export stream every link of the request or the user and
+	// may take longer than the request timeout, they are bounded by the
+	// client connection instead.
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Compress)
+		r.Use(h.auth)
+		r.Method(http.MethodPost, "/api/user/urls/import", h.importLinks)
+		r.Method(http.MethodGet, "/api/user/urls/export", h.export)
+	})
+
+	r.Method(http.MethodGet, "/healthz", h.liveness)
+	r.Method(http.MethodGet, "/readyz", h.readiness)
+
+	return r
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublicRouter_ReservedSlugs(t *testing.T) {
+	stub := http.NotFoundHandler()
+	r := newPublicRouter(publicHandlers{
+		auth:             func(h http.Handler) http.Handler { return h },
+		shorten:          stub,
+		apiShorten:       stub,
+		shortenBatch:     stub,
+		retrieve:         stub,
+		retrieveBatch:    stub,
+		expand:           stub,
+		expandBatch:      stub,
+		deleteBatch:      stub,
+		upcoming:         stub,
+		updateOptions:    stub,
+		testRules:        stub,
+		setDestinations:  stub,
+		destinationStats: stub,
+		setPassword:      stub,
+		setClickLimit:    stub,
+		qr:               stub,
+		qrBatch:          stub,
+		pngQR:            stub,
+		svgQR:            stub,
+		importLinks:      stub,
+		export:           stub,
+		ping:             stub,
+		liveness:         stub,
+		readiness:        stub,
+	}, time.Second)
+
+	var segments []models.ShortURL
+	err := chi.Walk(r, func(_ string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(route, "/"), "/")
+		short := models.ShortURL(segment)
+		if segment != "" && !strings.HasPrefix(segment, "{") && !slices.Contains(segments, short) {
+			segments = append(segments, short)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Every static route segment must be refused as a short URL
+	// and nothing else should be.
+	assert.ElementsMatch(t, segments, services.ReservedSlugs())
+}
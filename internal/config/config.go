@@ -21,6 +21,12 @@ const (
 	defaultKeyLength  = 32
 	defaultLogLevel   = "debug"
 	defaultGRPCPort   = ":50051"
+	defaultTraceExp   = "none"
+	defaultLogFormat  = "console"
+	defaultLogMaxSize = 100
+	defaultLogMaxAge  = time.Duration(30*24) * time.Hour
+
+	defaultMemSnapshotPeriod = time.Minute
 )
 
 // CfgFile specifies configuration file name
@@ -56,18 +62,91 @@ type Cfg struct {
 	// LogLevel sets logging verbosity (debug|info|warn|error)
 	LogLevel string `json:"log_level" env:"LOG_LEVEL"`
 
+	// LogFormat sets log entries encoding (console|json)
+	LogFormat string `json:"log_format" env:"LOG_FORMAT"`
+
+	// LogSampling enables sampling of repeated log entries
+	LogSampling bool `json:"log_sampling" env:"LOG_SAMPLING"`
+
+	// LogFile contains path for application log file,
+	// empty writes the log to stderr
+	LogFile string `json:"log_file" env:"LOG_FILE"`
+
+	// AccessLogFile contains path for access log file of HTTP requests
+	// and gRPC calls, empty writes them to the application log
+	AccessLogFile string `json:"access_log_file" env:"ACCESS_LOG_FILE"`
+
+	// LogMaxSize defines size in megabytes at which log files are rotated
+	LogMaxSize int `json:"log_max_size" env:"LOG_MAX_SIZE"`
+
+	// LogMaxAge defines how long rotated log files are kept,
+	// zero keeps them forever
+	LogMaxAge time.Duration `json:"log_max_age" env:"LOG_MAX_AGE"`
+
+	// LogMaxBackups limits the number of rotated log files,
+	// zero keeps all of them
+	LogMaxBackups int `json:"log_max_backups" env:"LOG_MAX_BACKUPS"`
+
 	// TrustedSubnet is classless Inter-Domain Routing (CIDR) string representation
 	TrustedSubnet string `json:"trusted_subnet" env:"TRUSTED_SUBNET"`
 
+	// TrustedProxy is CIDR of reverse proxies whose X-Real-IP header (x-real-ip
+	// metadata for gRPC) is taken as the client address, empty ignores it
+	TrustedProxy string `json:"trusted_proxy" env:"TRUSTED_PROXY"`
+
+	// AdminAddr specifies admin HTTP server listen address serving pprof,
+	// metrics and internal APIs, it must be a loopback or private address,
+	// empty disables the admin server
+	AdminAddr string `json:"admin_address" env:"ADMIN_ADDRESS"`
+
+	// AdminUser enables basic authentication on the admin server
+	AdminUser string `json:"admin_user" env:"ADMIN_USER"`
+
+	// AdminPassword is the basic authentication password of AdminUser
+	AdminPassword string `json:"admin_password" env:"ADMIN_PASSWORD"`
+
+	// AdminTLSCert contains path to PEM certificate enabling HTTPS on the admin server
+	AdminTLSCert string `json:"admin_tls_cert" env:"ADMIN_TLS_CERT"`
+
+	// AdminTLSKey contains path to PEM private key of AdminTLSCert
+	AdminTLSKey string `json:"admin_tls_key" env:"ADMIN_TLS_KEY"`
+
+	// AdminClientCA contains path to PEM CA certificates enabling mutual TLS,
+	// clients of the admin server must present certificates signed by them
+	AdminClientCA string `json:"admin_client_ca" env:"ADMIN_CLIENT_CA"`
+
 	// GRPCPort defines port for gRPC endpoints
 	GRPCPort string `json:"grpc_port" env:"GRPC_PORT"`
 
+	// GeoIPDBPath contains path to MaxMind country database used by routing rules
+	GeoIPDBPath string `json:"geoip_db_path" env:"GEOIP_DB_PATH"`
+
+	// MemSnapshotPath contains path for in-memory storage snapshot,
+	// empty disables snapshots
+	MemSnapshotPath string `json:"mem_snapshot_path" env:"MEM_SNAPSHOT_PATH"`
+
+	// MemSnapshotPeriod defines how often in-memory storage snapshot is saved,
+	// zero saves it on shutdown only
+	MemSnapshotPeriod time.Duration `json:"mem_snapshot_period" env:"MEM_SNAPSHOT_PERIOD"`
+
+	// DeletionLogPath contains path for write-ahead log of deletion requests,
+	// empty keeps the deletion queue in memory only
+	DeletionLogPath string `json:"deletion_log_path" env:"DELETION_LOG_PATH"`
+
+	// TraceExporter selects where traces are exported (otlp|stdout|none),
+	// OTLP exporter is configured by standard OTEL_EXPORTER_OTLP_* variables
+	TraceExporter string `json:"trace_exporter" env:"TRACE_EXPORTER"`
+
 	// StorageType is derived from other parameters (memory|file|db)
 	StorageType string `json:"-" env:"-"`
 
 	// Timeout defines default network operation timeout
 	Timeout time.Duration `json:"timeout_dur" env:"TIMEOUT_DUR"`
 
+	// ShutdownDrain defines how long the server keeps serving after it reports
+	// not ready on shutdown, so load balancers stop routing requests to it
+	ShutdownDrain time.Duration `json:"shutdown_drain" env:"SHUTDOWN_DRAIN"`
+
 	// HTTPS flag
 	EnableHTTPS bool `json:"enable_https" env:"ENABLE_HTTPS"`
 }
@@ -87,6 +166,12 @@ func NewConfigBuilder() *ConfigBuilder {
 			Timeout:       defaultTimeout,
 			LogLevel:      defaultLogLevel,
 			GRPCPort:      defaultGRPCPort,
+			TraceExporter: defaultTraceExp,
+			LogFormat:     defaultLogFormat,
+			LogMaxSize:    defaultLogMaxSize,
+			LogMaxAge:     defaultLogMaxAge,
+
+			MemSnapshotPeriod: defaultMemSnapshotPeriod,
 		},
 		err: nil,
 	}
@@ -157,7 +242,27 @@ func (b *ConfigBuilder) WithFlagParsing() *ConfigBuilder {
 	flag.StringVarP(&b.cfg.Key, "k", "k", b.cfg.Key, "Key for jwt autorization")
 	flag.StringVarP(&b.cfg.LogLevel, "l", "l", b.cfg.LogLevel, "Logger level")
 	flag.StringVarP(&b.cfg.TrustedSubnet, "t", "t", b.cfg.TrustedSubnet, "CIDR")
+	flag.StringVar(&b.cfg.TrustedProxy, "trusted-proxy", b.cfg.TrustedProxy, "Reverse proxy CIDR trusted to set X-Real-IP")
 	flag.StringVarP(&b.cfg.GRPCPort, "g", "g", b.cfg.GRPCPort, "gRPC port")
+	flag.StringVarP(&b.cfg.GeoIPDBPath, "i", "i", b.cfg.GeoIPDBPath, "GeoIP country database file path")
+	flag.StringVarP(&b.cfg.MemSnapshotPath, "m", "m", b.cfg.MemSnapshotPath, "In-memory storage snapshot file path")
+	flag.DurationVarP(&b.cfg.MemSnapshotPeriod, "p", "p", b.cfg.MemSnapshotPeriod, "In-memory storage snapshot period")
+	flag.StringVarP(&b.cfg.DeletionLogPath, "w", "w", b.cfg.DeletionLogPath, "Deletion queue write-ahead log file path")
+	flag.StringVarP(&b.cfg.TraceExporter, "x", "x", b.cfg.TraceExporter, "Trace exporter (otlp|stdout|none)")
+	flag.StringVar(&b.cfg.LogFormat, "log-format", b.cfg.LogFormat, "Log format (console|json)")
+	flag.BoolVar(&b.cfg.LogSampling, "log-sampling", b.cfg.LogSampling, "Enable sampling of repeated log entries")
+	flag.StringVar(&b.cfg.LogFile, "log-file", b.cfg.LogFile, "Application log file path")
+	flag.StringVar(&b.cfg.AccessLogFile, "access-log-file", b.cfg.AccessLogFile, "Access log file path")
+	flag.IntVar(&b.cfg.LogMaxSize, "log-max-size", b.cfg.LogMaxSize, "Log file size in megabytes triggering rotation")
+	flag.DurationVar(&b.cfg.LogMaxAge, "log-max-age", b.cfg.LogMaxAge, "Rotated log files retention period")
+	flag.IntVar(&b.cfg.LogMaxBackups, "log-max-backups", b.cfg.LogMaxBackups, "Number of rotated log files to keep")
+	flag.StringVar(&b.cfg.AdminAddr, "admin-address", b.cfg.AdminAddr, "Address and port to start the admin server")
+	flag.StringVar(&b.cfg.AdminUser, "admin-user", b.cfg.AdminUser, "Admin server basic authentication user")
+	flag.StringVar(&b.cfg.AdminPassword, "admin-password", b.cfg.AdminPassword, "Admin server basic authentication password")
+	flag.StringVar(&b.cfg.AdminTLSCert, "admin-tls-cert", b.cfg.AdminTLSCert, "Admin server TLS certificate file path")
+	flag.StringVar(&b.cfg.AdminTLSKey, "admin-tls-key", b.cfg.AdminTLSKey, "Admin server TLS key file path")
+	flag.StringVar(&b.cfg.AdminClientCA, "admin-client-ca", b.cfg.AdminClientCA, "Admin server client CA file path")
+	flag.DurationVar(&b.cfg.ShutdownDrain, "shutdown-drain", b.cfg.ShutdownDrain, "Serving duration after reporting not ready on shutdown")
 	flag.BoolVarP(&b.cfg.EnableHTTPS, "s", "s", b.cfg.EnableHTTPS, "Enable HTTPS flag")
 	flag.Parse()
 
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -20,7 +21,30 @@ const (
 	testLoggerLevel   = "info"
 	testCfgFileName   = "testcfg.json"
 	testTrustedSubnet = "192.168.1.0/24"
+	testTrustedProxy  = "10.0.0.0/8"
 	testGRPCPort      = ":50052"
+	testGeoIPDBPath   = "country.mmdb"
+
+	testMemSnapshotPath   = "snapshot.json"
+	testMemSnapshotPeriod = time.Duration(30) * time.Second
+	testDeletionLogPath   = "deletions.log"
+	testTraceExporter     = "stdout"
+
+	testLogFormat     = "json"
+	testLogFile       = "app.log"
+	testAccessLogFile = "access.log"
+	testLogMaxSize    = 10
+	testLogMaxAge     = time.Duration(24) * time.Hour
+	testLogMaxBackups = 5
+
+	testShutdownDrain = time.Duration(5) * time.Second
+
+	testAdminAddr     = "localhost:8082"
+	testAdminUser     = "admin"
+	testAdminPassword = "secret"
+	testAdminTLSCert  = "admin.crt"
+	testAdminTLSKey   = "admin.key"
+	testAdminClientCA = "ca.crt"
 )
 
 func TestConfigBuilder_WithEnvParsing(t *testing.T) {
@@ -33,9 +57,33 @@ func TestConfigBuilder_WithEnvParsing(t *testing.T) {
 		Key:             testKey,
 		LogLevel:        testLoggerLevel,
 		TrustedSubnet:   testTrustedSubnet,
+		TrustedProxy:    testTrustedProxy,
 		GRPCPort:        testGRPCPort,
+		GeoIPDBPath:     testGeoIPDBPath,
 		StorageType:     "db",
 		EnableHTTPS:     true,
+
+		MemSnapshotPath:   testMemSnapshotPath,
+		MemSnapshotPeriod: testMemSnapshotPeriod,
+		DeletionLogPath:   testDeletionLogPath,
+		TraceExporter:     testTraceExporter,
+
+		LogFormat:     testLogFormat,
+		LogSampling:   true,
+		LogFile:       testLogFile,
+		AccessLogFile: testAccessLogFile,
+		LogMaxSize:    testLogMaxSize,
+		LogMaxAge:     testLogMaxAge,
+		LogMaxBackups: testLogMaxBackups,
+
+		ShutdownDrain: testShutdownDrain,
+
+		AdminAddr:     testAdminAddr,
+		AdminUser:     testAdminUser,
+		AdminPassword: testAdminPassword,
+		AdminTLSCert:  testAdminTLSCert,
+		AdminTLSKey:   testAdminTLSKey,
+		AdminClientCA: testAdminClientCA,
 	}
 
 	t.Setenv("SERVER_ADDRESS", testCfg.ServerAddr)
@@ -46,7 +94,27 @@ func TestConfigBuilder_WithEnvParsing(t *testing.T) {
 	t.Setenv("JWT_KEY", testCfg.Key)
 	t.Setenv("LOG_LEVEL", testCfg.LogLevel)
 	t.Setenv("TRUSTED_SUBNET", testTrustedSubnet)
+	t.Setenv("TRUSTED_PROXY", testTrustedProxy)
 	t.Setenv("GRPC_PORT", testGRPCPort)
+	t.Setenv("GEOIP_DB_PATH", testGeoIPDBPath)
+	t.Setenv("MEM_SNAPSHOT_PATH", testMemSnapshotPath)
+	t.Setenv("MEM_SNAPSHOT_PERIOD", testMemSnapshotPeriod.String())
+	t.Setenv("DELETION_LOG_PATH", testDeletionLogPath)
+	t.Setenv("TRACE_EXPORTER", testTraceExporter)
+	t.Setenv("LOG_FORMAT", testLogFormat)
+	t.Setenv("LOG_SAMPLING", "true")
+	t.Setenv("LOG_FILE", testLogFile)
+	t.Setenv("ACCESS_LOG_FILE", testAccessLogFile)
+	t.Setenv("LOG_MAX_SIZE", strconv.Itoa(testLogMaxSize))
+	t.Setenv("LOG_MAX_AGE", testLogMaxAge.String())
+	t.Setenv("LOG_MAX_BACKUPS", strconv.Itoa(testLogMaxBackups))
+	t.Setenv("SHUTDOWN_DRAIN", testShutdownDrain.String())
+	t.Setenv("ADMIN_ADDRESS", testAdminAddr)
+	t.Setenv("ADMIN_USER", testAdminUser)
+	t.Setenv("ADMIN_PASSWORD", testAdminPassword)
+	t.Setenv("ADMIN_TLS_CERT", testAdminTLSCert)
+	t.Setenv("ADMIN_TLS_KEY", testAdminTLSKey)
+	t.Setenv("ADMIN_CLIENT_CA", testAdminClientCA)
 	t.Setenv("ENABLE_HTTPS", "true")
 
 	t.Run("valid test", func(t *testing.T) {
@@ -83,9 +151,33 @@ func TestConfigBuilder_WithFlagParsing(t *testing.T) {
 		Key:             testKey,
 		LogLevel:        testLoggerLevel,
 		TrustedSubnet:   testTrustedSubnet,
+		TrustedProxy:    testTrustedProxy,
 		GRPCPort:        testGRPCPort,
+		GeoIPDBPath:     testGeoIPDBPath,
 		StorageType:     "db",
 		EnableHTTPS:     true,
+
+		MemSnapshotPath:   testMemSnapshotPath,
+		MemSnapshotPeriod: testMemSnapshotPeriod,
+		DeletionLogPath:   testDeletionLogPath,
+		TraceExporter:     testTraceExporter,
+
+		LogFormat:     testLogFormat,
+		LogSampling:   true,
+		LogFile:       testLogFile,
+		AccessLogFile: testAccessLogFile,
+		LogMaxSize:    testLogMaxSize,
+		LogMaxAge:     testLogMaxAge,
+		LogMaxBackups: testLogMaxBackups,
+
+		ShutdownDrain: testShutdownDrain,
+
+		AdminAddr:     testAdminAddr,
+		AdminUser:     testAdminUser,
+		AdminPassword: testAdminPassword,
+		AdminTLSCert:  testAdminTLSCert,
+		AdminTLSKey:   testAdminTLSKey,
+		AdminClientCA: testAdminClientCA,
 	}
 
 	t.Run("valid test", func(t *testing.T) {
@@ -99,7 +191,27 @@ func TestConfigBuilder_WithFlagParsing(t *testing.T) {
 			"-k=" + testCfg.Key,
 			"-l=" + testCfg.LogLevel,
 			"-t=" + testTrustedSubnet,
+			"--trusted-proxy=" + testTrustedProxy,
 			"-g=" + testGRPCPort,
+			"-i=" + testGeoIPDBPath,
+			"-m=" + testMemSnapshotPath,
+			"-p=" + testMemSnapshotPeriod.String(),
+			"-w=" + testDeletionLogPath,
+			"-x=" + testTraceExporter,
+			"--log-format=" + testLogFormat,
+			"--log-sampling",
+			"--log-file=" + testLogFile,
+			"--access-log-file=" + testAccessLogFile,
+			"--log-max-size=" + strconv.Itoa(testLogMaxSize),
+			"--log-max-age=" + testLogMaxAge.String(),
+			"--log-max-backups=" + strconv.Itoa(testLogMaxBackups),
+			"--shutdown-drain=" + testShutdownDrain.String(),
+			"--admin-address=" + testAdminAddr,
+			"--admin-user=" + testAdminUser,
+			"--admin-password=" + testAdminPassword,
+			"--admin-tls-cert=" + testAdminTLSCert,
+			"--admin-tls-key=" + testAdminTLSKey,
+			"--admin-client-ca=" + testAdminClientCA,
 			"-s",
 		}
 
@@ -147,9 +259,33 @@ func TestConfigBuilder_WithConfigFile(t *testing.T) {
 		Key:             testKey,
 		LogLevel:        testLoggerLevel,
 		TrustedSubnet:   testTrustedSubnet,
+		TrustedProxy:    testTrustedProxy,
 		GRPCPort:        testGRPCPort,
+		GeoIPDBPath:     testGeoIPDBPath,
 		StorageType:     "db",
 		EnableHTTPS:     true,
+
+		MemSnapshotPath:   testMemSnapshotPath,
+		MemSnapshotPeriod: testMemSnapshotPeriod,
+		DeletionLogPath:   testDeletionLogPath,
+		TraceExporter:     testTraceExporter,
+
+		LogFormat:     testLogFormat,
+		LogSampling:   true,
+		LogFile:       testLogFile,
+		AccessLogFile: testAccessLogFile,
+		LogMaxSize:    testLogMaxSize,
+		LogMaxAge:     testLogMaxAge,
+		LogMaxBackups: testLogMaxBackups,
+
+		ShutdownDrain: testShutdownDrain,
+
+		AdminAddr:     testAdminAddr,
+		AdminUser:     testAdminUser,
+		AdminPassword: testAdminPassword,
+		AdminTLSCert:  testAdminTLSCert,
+		AdminTLSKey:   testAdminTLSKey,
+		AdminClientCA: testAdminClientCA,
 	}
 
 	file, err := os.Create(testCfgFileName)
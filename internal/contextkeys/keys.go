@@ -1,15 +1,35 @@
 // Package contextkeys provides type-safe keys for storing values in request context.
 package contextkeys
 
-type contextKey struct{}
+// contextKey is a private key type. The name field keeps keys distinct:
+// comparable empty structs would all be equal to each other.
+type contextKey struct {
+	name string
+}
 
 // Package-level context keys for storing common request values.
 var (
 	// ShortURL is the context key for storing shortened URL value.
 	// Used in middleware and handlers to pass parsed URL path.
-	ShortURL = contextKey{}
+	ShortURL = contextKey{"short_url"}
 
 	// UserID is the context key for storing authenticated user ID.
 	// Populated by auth middleware after JWT verification.
-	UserID = contextKey{}
+	UserID = contextKey{"user_id"}
+
+	// PathSuffix is the context key for storing escaped trailing path segments
+	// that follow the short URL in redirect requests.
+	PathSuffix = contextKey{"path_suffix"}
+
+	// Visit is the context key for storing redirect request details.
+	// Populated by redirect handler before URL resolution.
+	Visit = contextKey{"visit"}
+
+	// RequestID is the context key for storing the request ID.
+	// Populated by request ID middleware and interceptors.
+	RequestID = contextKey{"request_id"}
+
+	// Logger is the context key for storing the request-scoped logger.
+	// Use logger.FromContext and logger.With instead of accessing it directly.
+	Logger = contextKey{"logger"}
 )
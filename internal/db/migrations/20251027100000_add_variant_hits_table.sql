@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS variant_hits (
+    short_url VARCHAR(7),
+    variant TEXT,
+    hits BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (short_url, variant)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS variant_hits;
+-- +goose StatementEnd
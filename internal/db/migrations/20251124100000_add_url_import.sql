@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ALTER COLUMN short_url TYPE VARCHAR(64);
+ALTER TABLE variant_hits ALTER COLUMN short_url TYPE VARCHAR(64);
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS tags JSONB;
+CREATE UNIQUE INDEX IF NOT EXISTS urls_short_url_idx ON urls (short_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS urls_short_url_idx;
+ALTER TABLE urls DROP COLUMN IF EXISTS tags;
+ALTER TABLE variant_hits ALTER COLUMN short_url TYPE VARCHAR(7);
+ALTER TABLE urls ALTER COLUMN short_url TYPE VARCHAR(7);
+-- +goose StatementEnd
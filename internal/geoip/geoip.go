@@ -0,0 +1,80 @@
+// Package geoip resolves visitor countries using a local MaxMind database file.
+//
+// Both GeoLite2 and GeoIP2 Country (or City) databases are supported.
+// No network access is performed.
+package geoip
+
+import (
+	"errors"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+var errNoCountry = errors.New("country not found")
+
+// Reader looks up countries in a memory mapped database.
+//
+// Reader is safe for concurrent use.
+type Reader struct {
+	db *maxminddb.Reader
+}
+
+// record is a subset of the country database record.
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+}
+
+// Open opens the database file.
+func Open(path string) (*Reader, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		db: db,
+	}, nil
+}
+
+// FromBytes creates Reader over database contents.
+func FromBytes(data []byte) (*Reader, error) {
+	db, err := maxminddb.FromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		db: db,
+	}, nil
+}
+
+// Country returns ISO 3166-1 alpha-2 code of the country the address belongs to.
+//
+// Falls back to registered country for anycast and similar networks.
+func (r *Reader) Country(ip net.IP) (string, error) {
+	var rec record
+	err := r.db.Lookup(ip, &rec)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case rec.Country.ISOCode != "":
+		return rec.Country.ISOCode, nil
+	case rec.RegisteredCountry.ISOCode != "":
+		return rec.RegisteredCountry.ISOCode, nil
+	default:
+		return "", errNoCountry
+	}
+}
+
+// Close releases the database.
+func (r *Reader) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,81 @@
+package geoip
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) []byte {
+	t.Helper()
+
+	w, err := mmdbwriter.New(mmdbwriter.Options{
+		DatabaseType: "GeoLite2-Country",
+		IPVersion:    6,
+	})
+	require.NoError(t, err)
+
+	insert := func(cidr string, rec mmdbtype.Map) {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		err = w.Insert(network, rec)
+		require.NoError(t, err)
+	}
+
+	insert("81.2.69.0/24", mmdbtype.Map{
+		"country": mmdbtype.Map{"iso_code": mmdbtype.String("GB")},
+	})
+	insert("2a02:6b8::/32", mmdbtype.Map{
+		"country": mmdbtype.Map{"iso_code": mmdbtype.String("DE")},
+	})
+	insert("1.1.1.0/24", mmdbtype.Map{
+		"registered_country": mmdbtype.Map{"iso_code": mmdbtype.String("AU")},
+	})
+
+	var buf bytes.Buffer
+	_, err = w.WriteTo(&buf)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+func TestReader_Country(t *testing.T) {
+	r, err := FromBytes(newTestDB(t))
+	require.NoError(t, err)
+	defer func() {
+		err := r.Close()
+		require.NoError(t, err)
+	}()
+
+	tests := []struct {
+		ip      string
+		want    string
+		wantErr bool
+	}{
+		{ip: "81.2.69.142", want: "GB"},
+		{ip: "2a02:6b8::1", want: "DE"},
+		{ip: "1.1.1.1", want: "AU"},
+		{ip: "10.0.0.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got, err := r.Country(net.ParseIP(tt.ip))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open("not-exist.mmdb")
+	assert.Error(t, err)
+}
@@ -25,7 +25,7 @@ type authServicer interface {
 	ParseIDFromAuthHeader(string) (models.UserID, error)
 }
 
-// AuthInterceptor implements gRPC unary server interceptor for authentication.
+// AuthInterceptor implements gRPC unary and stream server interceptors for authentication.
 // It handles both existing JWT validation and new user registration.
 type AuthInterceptor struct {
 	authService authServicer // Service handling JWT operations
@@ -44,7 +44,7 @@ func NewAuthInterceptor(authService authServicer) *AuthInterceptor {
 // 1. Checks for existing Bearer token in Authorization header
 // 2. Validates token if present and extracts user ID
 // 3. Generates new token for new users
-// 4. Sets user ID in request context
+// 4. Sets user ID in request context and its request-scoped logger
 // 5. Adds new token to response headers when created
 func (i *AuthInterceptor) Auth(ctx context.Context) (context.Context, error) {
 	var userID models.UserID
@@ -57,7 +57,7 @@ func (i *AuthInterceptor) Auth(ctx context.Context) (context.Context, error) {
 				token := strings.TrimPrefix(header, "Bearer ")
 				uid, err := i.authService.ParseIDFromAuthHeader(token)
 				if err != nil {
-					logger.Log.Debug("auth interceptor", zap.Error(err))
+					logger.FromContext(ctx).Debug("auth interceptor", zap.Error(err))
 				} else {
 					userID = uid
 				}
@@ -70,7 +70,7 @@ func (i *AuthInterceptor) Auth(ctx context.Context) (context.Context, error) {
 
 		jwtString, err := i.authService.NewJWTString(userID)
 		if err != nil {
-			logger.Log.Debug("auth interceptor", zap.Error(err))
+			logger.FromContext(ctx).Debug("auth interceptor", zap.Error(err))
 			return nil, status.Error(codes.Internal, "failed to generate token")
 		}
 
@@ -81,5 +81,6 @@ func (i *AuthInterceptor) Auth(ctx context.Context) (context.Context, error) {
 		}
 	}
 
+	ctx = logger.With(ctx, zap.String("user_id", string(userID)))
 	return context.WithValue(ctx, contextkeys.UserID, userID), nil
 }
@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/rycln/shorturl/internal/logger"
 	"go.uber.org/zap"
 )
 
 // InterceptorLogger creates a logging adapter that bridges between
 // gRPC middleware logging and Zap logger.
-func InterceptorLogger(l *zap.Logger) logging.Logger {
+//
+// Calls are logged by the request-scoped access logger, see logger.AccessFromContext.
+func InterceptorLogger() logging.Logger {
 	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
 		f := make([]zap.Field, 0, len(fields)/2)
 
@@ -30,17 +33,17 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 			}
 		}
 
-		logger := l.WithOptions(zap.AddCallerSkip(1)).With(f...)
+		l := logger.AccessFromContext(ctx).WithOptions(zap.AddCallerSkip(1)).With(f...)
 
 		switch lvl {
 		case logging.LevelDebug:
-			logger.Debug(msg)
+			l.Debug(msg)
 		case logging.LevelInfo:
-			logger.Info(msg)
+			l.Info(msg)
 		case logging.LevelWarn:
-			logger.Warn(msg)
+			l.Warn(msg)
 		case logging.LevelError:
-			logger.Error(msg)
+			l.Error(msg)
 		default:
 			panic(fmt.Sprintf("unknown level %v", lvl))
 		}
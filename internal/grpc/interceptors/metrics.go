@@ -0,0 +1,34 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/shorturl/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnary records metrics of unary gRPC calls by method and status code.
+func MetricsUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+// MetricsStream records metrics of streaming gRPC calls by method and status code.
+func MetricsStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeCall(info.FullMethod, start, err)
+	return err
+}
+
+// observeCall records a finished gRPC call.
+func observeCall(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+
+	metrics.GRPCRequests.WithLabelValues(method, code).Inc()
+	metrics.GRPCDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"github.com/rycln/shorturl/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDUnary assigns an ID to unary gRPC calls.
+//
+// A valid x-request-id metadata value of the call is reused, otherwise
+// a new ID is generated. The ID is sent back in the x-request-id header
+// and stored in the call context together with a request-scoped logger.
+func RequestIDUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := withRequestID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// RequestIDStream assigns an ID to streaming gRPC calls like RequestIDUnary.
+func RequestIDStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := withRequestID(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	wrapped := middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
+}
+
+// withRequestID resolves the request ID of the call and returns it to the client.
+func withRequestID(ctx context.Context) (context.Context, error) {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestid.MetadataKey); len(ids) > 0 {
+			incoming = ids[0]
+		}
+	}
+	id := requestid.Resolve(incoming)
+
+	err := grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "can't write the header")
+	}
+
+	return requestid.NewContext(ctx, id), nil
+}
@@ -16,13 +16,28 @@ import (
 // immediate deletion, as this is typically a background operation.
 type deletionProcessor interface {
 	// AddURLsIntoDeletionQueue queues URLs for asynchronous deletion.
-	AddURLsIntoDeletionQueue(models.UserID, []models.ShortURL)
+	// Returns after the request is durably queued.
+	AddURLsIntoDeletionQueue(models.UserID, []models.ShortURL) error
+}
+
+// errDeleteQueueFull defines the interface for full deletion queue errors.
+type errDeleteQueueFull interface {
+	error
+	// IsErrQueueFull returns true if the deletion queue has no room for the request
+	IsErrQueueFull() bool
+}
+
+// errDeleteTooLarge defines the interface for deletion requests exceeding queue capacity.
+type errDeleteTooLarge interface {
+	error
+	// IsErrTooLarge returns true if the request can never fit into the deletion queue
+	IsErrTooLarge() bool
 }
 
 // DeleteUserURLs handles batch URL deletion requests.
 //
 // This endpoint accepts a list of short URLs to delete and queues them for
-// asynchronous processing. The operation completes immediately after queuing,
+// asynchronous processing. The operation completes right after durable queuing,
 // while actual deletion happens in the background. Requires authentication.
 func (s *ShortenerServer) DeleteUserURLs(
 	ctx context.Context,
@@ -38,7 +53,16 @@ func (s *ShortenerServer) DeleteUserURLs(
 		surls[i] = models.ShortURL(url)
 	}
 
-	s.delProc.AddURLsIntoDeletionQueue(uid, surls)
+	err = s.delProc.AddURLsIntoDeletionQueue(uid, surls)
+	if e, ok := err.(errDeleteQueueFull); ok && e.IsErrQueueFull() {
+		return nil, status.Error(codes.Unavailable, "deletion queue is full")
+	}
+	if e, ok := err.(errDeleteTooLarge); ok && e.IsErrTooLarge() {
+		return nil, status.Error(codes.InvalidArgument, "too many URLs to delete at once")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to queue deletion")
+	}
 
 	return &emptypb.Empty{}, nil
 }
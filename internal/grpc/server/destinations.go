@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"net/url"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/rycln/shorturl/api/gen/shortener"
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// destinationsServicer defines the interface for multi-destination link operations.
+// Implementations should only allow owners to manage their links.
+type destinationsServicer interface {
+	// SetDestinations replaces weighted destinations of the user's URL.
+	SetDestinations(context.Context, models.UserID, models.ShortURL, []models.Destination) error
+
+	// GetDestinationStats returns destinations of the user's URL with redirect counts.
+	GetDestinationStats(context.Context, models.UserID, models.ShortURL) ([]models.VariantStat, error)
+}
+
+// errDestinationsNotExist defines the interface for missing URL errors.
+// Implementations should indicate when a user has no such URL.
+type errDestinationsNotExist interface {
+	error
+	// IsErrNotExist returns true if the error represents a missing URL
+	IsErrNotExist() bool
+}
+
+// SetDestinations handles requests to split link traffic between weighted destinations.
+//
+// Every destination must have a unique variant name, a valid URL and a positive
+// weight. Empty destinations list turns the link back into a single destination one.
+// Requires authentication.
+func (s *ShortenerServer) SetDestinations(
+	ctx context.Context,
+	req *pb.SetDestinationsRequest,
+) (*emptypb.Empty, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	dests := make([]models.Destination, len(req.Destinations))
+	variants := make(map[string]struct{}, len(req.Destinations))
+	for i, d := range req.Destinations {
+		if _, ok := variants[d.Variant]; ok || d.Variant == "" || d.Weight == 0 {
+			return nil, status.Error(codes.InvalidArgument, "bad destination")
+		}
+		variants[d.Variant] = struct{}{}
+
+		_, err := url.ParseRequestURI(d.Url)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "bad destination")
+		}
+
+		dests[i] = models.Destination{
+			Variant: d.Variant,
+			URL:     models.OrigURL(d.Url),
+			Weight:  int(d.Weight),
+		}
+	}
+
+	err = s.destinations.SetDestinations(ctx, uid, models.ShortURL(req.ShortUrl), dests)
+	if err != nil {
+		if e, ok := err.(errDestinationsNotExist); ok && e.IsErrNotExist() {
+			return nil, status.Error(codes.NotFound, "URL not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to set destinations")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetDestinations returns weighted destinations of a link
+// together with the number of redirects to each of them.
+// Requires authentication.
+func (s *ShortenerServer) GetDestinations(
+	ctx context.Context,
+	req *pb.GetDestinationsRequest,
+) (*pb.GetDestinationsResponse, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	stats, err := s.destinations.GetDestinationStats(ctx, uid, models.ShortURL(req.ShortUrl))
+	if err != nil {
+		if e, ok := err.(errDestinationsNotExist); ok && e.IsErrNotExist() {
+			return nil, status.Error(codes.NotFound, "URL not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get destinations")
+	}
+
+	res := &pb.GetDestinationsResponse{
+		Destinations: make([]*pb.DestinationStat, len(stats)),
+	}
+	for i, stat := range stats {
+		res.Destinations[i] = &pb.DestinationStat{
+			Destination: &pb.Destination{
+				Variant: stat.Variant,
+				Url:     string(stat.URL),
+				Weight:  uint32(stat.Weight),
+			},
+			Hits: uint64(stat.Hits),
+		}
+	}
+
+	return res, nil
+}
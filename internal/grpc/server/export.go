@@ -0,0 +1,58 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rycln/shorturl/api/gen/shortener"
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// ExportUserURLs streams all URLs of the authenticated user including deleted ones.
+//
+// Items are sent as storage reads them, each carries the link status
+// (active, scheduled, expired, deleted) and metadata. A failure in the middle
+// ends the stream with an error, so a partial export can not be mistaken
+// for a complete one.
+func (s *ShortenerServer) ExportUserURLs(_ *emptypb.Empty, stream grpc.ServerStreamingServer[pb.ExportURLItem]) error {
+	ctx := stream.Context()
+
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	var sendErr error
+	err = s.batchRetrieve.ExportUserURLs(ctx, uid, func(info models.URLInfo) error {
+		sendErr = stream.Send(s.exportURLItem(info))
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "failed to export user URLs")
+	}
+
+	return nil
+}
+
+func (s *ShortenerServer) exportURLItem(info models.URLInfo) *pb.ExportURLItem {
+	item := &pb.ExportURLItem{
+		ShortUrl:          s.baseAddr + "/" + string(info.Short),
+		OriginalUrl:       string(info.Orig),
+		Status:            string(info.Status),
+		ActiveFrom:        timestampOrNil(info.ActiveFrom),
+		ExpiresAt:         timestampOrNil(info.ExpiresAt),
+		ClicksLeft:        info.ClicksLeft,
+		PasswordProtected: info.Options != nil && info.Options.PasswordHash != "",
+		Tags:              info.Tags,
+	}
+	if !info.CreatedAt.IsZero() {
+		item.CreatedAt = timestamppb.New(info.CreatedAt)
+	}
+	return item
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rycln/shorturl/api/gen/shortener"
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// importChunkSize is the number of streamed records stored within one service call.
+const importChunkSize = 500
+
+// maxImportResults limits the number of per record results kept for the response.
+const maxImportResults = 10000
+
+// ImportURLs imports links streamed by the client.
+//
+// Records are stored in chunks as they arrive, only the compact report
+// is kept until the client closes the stream. The response reports
+// totals and the outcome of the first maxImportResults records in stream
+// order, results_truncated is set if the rest were counted only.
+// Custom short_url is optional, links without it get a generated one.
+func (s *ShortenerServer) ImportURLs(stream grpc.ClientStreamingServer[pb.ImportURLRequest, pb.ImportURLsResponse]) error {
+	ctx := stream.Context()
+
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	res := &pb.ImportURLsResponse{}
+	chunk := make([]models.ImportRecord, 0, importChunkSize)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		results, err := s.batchShorten.ImportURLs(ctx, uid, chunk)
+		if err != nil {
+			return status.Error(codes.Internal, "import failed")
+		}
+		chunk = chunk[:0]
+
+		for _, result := range results {
+			if len(res.Results) < maxImportResults {
+				res.Results = append(res.Results, s.importURLResult(result))
+			} else {
+				res.ResultsTruncated = true
+			}
+			switch result.Status {
+			case models.ImportCreated:
+				res.Created++
+			case models.ImportConflict:
+				res.Conflicts++
+			case models.ImportInvalid:
+				res.Invalid++
+			}
+		}
+		return nil
+	}
+
+	for row := 1; ; row++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		rec := models.ImportRecord{
+			Row:   row,
+			Orig:  models.OrigURL(req.OriginalUrl),
+			Short: models.ShortURL(req.ShortUrl),
+			Tags:  req.Tags,
+		}
+		if req.ExpiresAt != nil {
+			expiresAt := req.ExpiresAt.AsTime()
+			rec.ExpiresAt = &expiresAt
+		}
+
+		chunk = append(chunk, rec)
+		if len(chunk) == importChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(res)
+}
+
+func (s *ShortenerServer) importURLResult(result models.ImportResult) *pb.ImportURLResult {
+	item := &pb.ImportURLResult{
+		Row:         uint64(result.Row),
+		Status:      string(result.Status),
+		OriginalUrl: string(result.Orig),
+		Reason:      result.Reason,
+	}
+	if result.Status != models.ImportInvalid {
+		item.ShortUrl = s.baseAddr + "/" + string(result.Short)
+	}
+	return item
+}
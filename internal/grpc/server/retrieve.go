@@ -2,11 +2,17 @@ package server
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/rycln/shorturl/api/gen/shortener"
+	"github.com/rycln/shorturl/internal/contextkeys"
 	"github.com/rycln/shorturl/internal/models"
 )
 
@@ -18,8 +24,17 @@ type retrieveServicer interface {
 
 	// GetOrigURLByShort looks up the original URL by its short version.
 	GetOrigURLByShort(context.Context, models.ShortURL) (models.OrigURL, error)
+
+	// DescribeURL returns short URL details and status without redirecting.
+	DescribeURL(context.Context, models.ShortURL) (*models.URLInfo, error)
+
+	// BatchDescribeURL describes many short URLs with a single storage request.
+	BatchDescribeURL(context.Context, []models.ShortURL) ([]models.URLInfo, error)
 }
 
+// maxRetrieveBatchSize limits the number of short URLs resolved in one request.
+const maxRetrieveBatchSize = 1000
+
 // errRetrieveDeletedURL defines the interface for deleted URL errors.
 // Implementations should indicate when a requested URL has been deleted.
 type errRetrieveDeletedURL interface {
@@ -28,21 +43,81 @@ type errRetrieveDeletedURL interface {
 	IsErrDeletedURL() bool
 }
 
+// errRetrieveNotExist defines the interface for unknown URL errors.
+type errRetrieveNotExist interface {
+	error
+	// IsErrNotExist returns true if the URL is unknown
+	IsErrNotExist() bool
+}
+
+// errRetrievePasswordRequired defines the interface for protected URL errors.
+type errRetrievePasswordRequired interface {
+	error
+	// IsErrPasswordRequired returns true if the URL requires password
+	IsErrPasswordRequired() bool
+}
+
+// errRetrieveWrongPassword defines the interface for wrong password errors.
+type errRetrieveWrongPassword interface {
+	error
+	// IsErrWrongPassword returns true if the provided password is wrong
+	IsErrWrongPassword() bool
+}
+
+// errRetrieveNotActive defines the interface for scheduled URL errors.
+type errRetrieveNotActive interface {
+	error
+	// IsErrNotActive returns true if the URL is not live yet
+	IsErrNotActive() bool
+	// ActiveFrom returns the moment the URL goes live
+	ActiveFrom() time.Time
+}
+
+// errRetrieveTooManyAttempts defines the interface for throttled password errors.
+type errRetrieveTooManyAttempts interface {
+	error
+	// IsErrTooManyAttempts returns true if password guessing is throttled
+	IsErrTooManyAttempts() bool
+}
+
 // RetrieveURL handles URL retrieval requests by short URL identifier.
 //
 // It looks up the original URL corresponding to the provided short URL,
 // handling special cases for deleted URLs and other error conditions.
+// Routing rules of the link see the client through "user-agent",
+// "accept-language" metadata and the peer address. The "x-real-ip" metadata
+// replaces the peer address only for peers in the trusted proxy network.
+// Multi-destination links keep the variant sticky for clients sending the
+// same "x-visitor-id" metadata, others get a random variant on every call.
+// Password protected links require the password field.
+// Scheduled links are reported as not found after expiry.
 func (s *ShortenerServer) RetrieveURL(
 	ctx context.Context,
 	req *pb.RetrieveURLRequest,
 ) (*pb.RetrieveURLResponse, error) {
 	shortURL := models.ShortURL(req.ShortUrl)
 
+	visit := s.newVisit(ctx)
+	visit.Password = req.Password
+	ctx = context.WithValue(ctx, contextkeys.Visit, visit)
+
 	origURL, err := s.retrieve.GetOrigURLByShort(ctx, shortURL)
 	if err != nil {
 		if e, ok := err.(errRetrieveDeletedURL); ok && e.IsErrDeletedURL() {
 			return nil, status.Error(codes.NotFound, "URL was deleted")
 		}
+		if e, ok := err.(errRetrieveNotActive); ok && e.IsErrNotActive() {
+			return nil, status.Error(codes.FailedPrecondition, "URL is not active until "+e.ActiveFrom().UTC().Format(time.RFC3339))
+		}
+		if e, ok := err.(errRetrievePasswordRequired); ok && e.IsErrPasswordRequired() {
+			return nil, status.Error(codes.PermissionDenied, "password required")
+		}
+		if e, ok := err.(errRetrieveWrongPassword); ok && e.IsErrWrongPassword() {
+			return nil, status.Error(codes.PermissionDenied, "wrong password")
+		}
+		if e, ok := err.(errRetrieveTooManyAttempts); ok && e.IsErrTooManyAttempts() {
+			return nil, status.Error(codes.ResourceExhausted, "too many password attempts")
+		}
 		return nil, status.Error(codes.Internal, "failed to retrieve URL")
 	}
 
@@ -50,3 +125,128 @@ func (s *ShortenerServer) RetrieveURL(
 		OriginalUrl: string(origURL),
 	}, nil
 }
+
+// DescribeURL returns where a short URL goes and its status
+// (active, scheduled, expired or deleted) without following it.
+//
+// No click of a limited link is taken. Destination of a password protected
+// link is returned to its owner only, the owner also sees remaining clicks.
+func (s *ShortenerServer) DescribeURL(
+	ctx context.Context,
+	req *pb.DescribeURLRequest,
+) (*pb.DescribeURLResponse, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	info, err := s.retrieve.DescribeURL(ctx, models.ShortURL(req.ShortUrl))
+	if err != nil {
+		if e, ok := err.(errRetrieveNotExist); ok && e.IsErrNotExist() {
+			return nil, status.Error(codes.NotFound, "URL not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to describe URL")
+	}
+
+	owner := info.UID == uid
+	protected := info.Options != nil && info.Options.PasswordHash != ""
+
+	res := &pb.DescribeURLResponse{
+		ShortUrl:          s.baseAddr + "/" + string(info.Short),
+		Status:            string(info.Status),
+		ActiveFrom:        timestampOrNil(info.ActiveFrom),
+		ExpiresAt:         timestampOrNil(info.ExpiresAt),
+		PasswordProtected: protected,
+	}
+	if !info.CreatedAt.IsZero() {
+		res.CreatedAt = timestamppb.New(info.CreatedAt)
+	}
+	if !protected || owner {
+		res.OriginalUrl = string(info.Orig)
+	}
+	if owner {
+		res.ClicksLeft = info.ClicksLeft
+	}
+
+	return res, nil
+}
+
+// BatchRetrieveURL resolves many short URLs at once without following them.
+//
+// Items follow the request order and carry the destination and status
+// (active, scheduled, expired, deleted or not_found). Visibility rules
+// are the same as for DescribeURL.
+func (s *ShortenerServer) BatchRetrieveURL(
+	ctx context.Context,
+	req *pb.BatchRetrieveURLRequest,
+) (*pb.BatchRetrieveURLResponse, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	if len(req.ShortUrls) == 0 || len(req.ShortUrls) > maxRetrieveBatchSize {
+		return nil, status.Error(codes.InvalidArgument, "batch must contain from 1 to 1000 short URLs")
+	}
+
+	shorts := make([]models.ShortURL, len(req.ShortUrls))
+	for i, short := range req.ShortUrls {
+		shorts[i] = models.ShortURL(short)
+	}
+
+	infos, err := s.retrieve.BatchDescribeURL(ctx, shorts)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to retrieve URLs")
+	}
+
+	res := &pb.BatchRetrieveURLResponse{
+		Items: make([]*pb.BatchRetrieveURLItem, len(infos)),
+	}
+	for i, info := range infos {
+		protected := info.Options != nil && info.Options.PasswordHash != ""
+		item := &pb.BatchRetrieveURLItem{
+			ShortUrl:          s.baseAddr + "/" + string(info.Short),
+			Status:            string(info.Status),
+			PasswordProtected: protected,
+		}
+		if !protected || info.UID == uid {
+			item.OriginalUrl = string(info.Orig)
+		}
+		res.Items[i] = item
+	}
+
+	return res, nil
+}
+
+// newVisit collects redirect request details from gRPC metadata.
+func (s *ShortenerServer) newVisit(ctx context.Context) *models.Visit {
+	visit := &models.Visit{
+		Time: time.Now(),
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("user-agent"); len(v) > 0 {
+		visit.UserAgent = v[0]
+	}
+	if v := md.Get("accept-language"); len(v) > 0 {
+		visit.AcceptLanguage = v[0]
+	}
+	if v := md.Get("x-visitor-id"); len(v) > 0 {
+		visit.VisitorID = v[0]
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		visit.IP = parseIPFromAddr(p.Addr.String())
+	}
+
+	if s.trustedProxy == nil || visit.IP == nil || !s.trustedProxy.Contains(visit.IP) {
+		return visit
+	}
+	if v := md.Get("x-real-ip"); len(v) > 0 {
+		if ip := net.ParseIP(v[0]); ip != nil {
+			visit.IP = ip
+		}
+	}
+
+	return visit
+}
@@ -16,6 +16,12 @@ import (
 type retrieveBatchServicer interface {
 	// GetUserURLs retrieves all URL pairs (original and short) for a given user.
 	GetUserURLs(context.Context, models.UserID) ([]models.URLPair, error)
+
+	// GetUpcomingURLs retrieves user URL pairs which are not live yet.
+	GetUpcomingURLs(context.Context, models.UserID) ([]models.URLPair, error)
+
+	// ExportUserURLs streams all user URLs including deleted ones.
+	ExportUserURLs(context.Context, models.UserID, func(models.URLInfo) error) error
 }
 
 // errRetrieveBatchNotExist defines the interface for non-existent user errors.
@@ -51,11 +57,46 @@ func (s *ShortenerServer) GetUserURLs(
 		Urls: make([]*pb.UserURLItem, len(pairs)),
 	}
 	for i, pair := range pairs {
-		res.Urls[i] = &pb.UserURLItem{
-			ShortUrl:    s.baseAddr + "/" + string(pair.Short),
-			OriginalUrl: string(pair.Orig),
+		res.Urls[i] = s.userURLItem(pair)
+	}
+
+	return res, nil
+}
+
+// GetUpcomingURLs retrieves scheduled URLs of the authenticated user
+// which are not live yet, ordered by activation time.
+func (s *ShortenerServer) GetUpcomingURLs(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.GetUserURLsResponse, error) {
+	uid, err := s.auth.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication failed")
+	}
+
+	pairs, err := s.batchRetrieve.GetUpcomingURLs(ctx, uid)
+	if err != nil {
+		if e, ok := err.(errRetrieveBatchNotExist); ok && e.IsErrNotExist() {
+			return &pb.GetUserURLsResponse{Urls: nil}, nil
 		}
+		return nil, status.Error(codes.Internal, "failed to get upcoming URLs")
+	}
+
+	res := &pb.GetUserURLsResponse{
+		Urls: make([]*pb.UserURLItem, len(pairs)),
+	}
+	for i, pair := range pairs {
+		res.Urls[i] = s.userURLItem(pair)
 	}
 
 	return res, nil
 }
+
+func (s *ShortenerServer) userURLItem(pair models.URLPair) *pb.UserURLItem {
+	return &pb.UserURLItem{
+		ShortUrl:    s.baseAddr + "/" + string(pair.Short),
+		OriginalUrl: string(pair.Orig),
+		ActiveFrom:  timestampOrNil(pair.ActiveFrom),
+		ExpiresAt:   timestampOrNil(pair.ExpiresAt),
+	}
+}
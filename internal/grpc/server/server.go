@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"net"
 
 	pb "github.com/rycln/shorturl/api/gen/shortener"
 	"github.com/rycln/shorturl/internal/models"
@@ -21,11 +22,13 @@ type authServicer interface {
 // - Single and batch URL shortening
 // - URL retrieval (single and batch)
 // - URL deletion
+// - Multi-destination (A/B) links
 // - System health checks
 // - Usage statistics
 //
 // The server requires a base address for constructing full short URLs
-// and optionally a trusted subnet for admin functionality.
+// and optionally a trusted subnet for admin functionality and a trusted
+// proxy network whose "x-real-ip" metadata is taken as the client address.
 type ShortenerServer struct {
 	pb.UnimplementedShortenerServiceServer
 
@@ -37,8 +40,10 @@ type ShortenerServer struct {
 	auth          authServicer          // Handles user authentication
 	ping          pingServicer          // Handles health checks
 	stats         statsServicer         // Handles statistics collection
+	destinations  destinationsServicer  // Handles multi-destination links
 	baseAddr      string                // Base address for short URLs
 	trustedSubnet string                // Trusted subnet (CIDR notation)
+	trustedProxy  *net.IPNet            // Trusted reverse proxies, nil if none
 }
 
 // NewShortenerServer creates and initializes a new ShortenerServer instance.
@@ -54,8 +59,10 @@ func NewShortenerServer(
 	auth authServicer,
 	ping pingServicer,
 	stats statsServicer,
+	destinations destinationsServicer,
 	baseAddr string,
 	trustedSubnet string,
+	trustedProxy *net.IPNet,
 ) *ShortenerServer {
 	return &ShortenerServer{
 		shorten:       shorten,
@@ -66,7 +73,9 @@ func NewShortenerServer(
 		auth:          auth,
 		ping:          ping,
 		stats:         stats,
+		destinations:  destinations,
 		baseAddr:      baseAddr,
 		trustedSubnet: trustedSubnet,
+		trustedProxy:  trustedProxy,
 	}
 }
@@ -2,10 +2,13 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net/url"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/rycln/shorturl/api/gen/shortener"
 	"github.com/rycln/shorturl/internal/models"
@@ -16,6 +19,9 @@ import (
 type shortenServicer interface {
 	// ShortenURL creates a shortened version of the original URL.
 	ShortenURL(context.Context, models.UserID, models.OrigURL) (*models.URLPair, error)
+
+	// ShortenScheduledURL creates a shortened URL live only within the schedule.
+	ShortenScheduledURL(context.Context, models.UserID, models.OrigURL, models.Schedule) (*models.URLPair, error)
 }
 
 // errShortenConflict defines the interface for URL conflict errors.
@@ -30,7 +36,8 @@ type errShortenConflict interface {
 //
 // It validates the input URL, delegates the shortening operation to the service,
 // and returns the shortened URL. Handles conflict cases gracefully by returning
-// the existing short URL when available.
+// the existing short URL when available. Optional active_from and expires_at
+// limit the period the link redirects in.
 func (s *ShortenerServer) ShortenURL(ctx context.Context, req *pb.ShortenURLRequest) (*pb.ShortenURLResponse, error) {
 	uid, ok := ctx.Value("userID").(models.UserID)
 	if !ok {
@@ -42,7 +49,17 @@ func (s *ShortenerServer) ShortenURL(ctx context.Context, req *pb.ShortenURLRequ
 		return nil, status.Error(codes.InvalidArgument, "bad request")
 	}
 
-	pair, err := s.shorten.ShortenURL(ctx, uid, models.OrigURL(req.OriginalUrl))
+	sched, err := scheduleFromProto(req.ActiveFrom, req.ExpiresAt)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var pair *models.URLPair
+	if sched == (models.Schedule{}) {
+		pair, err = s.shorten.ShortenURL(ctx, uid, models.OrigURL(req.OriginalUrl))
+	} else {
+		pair, err = s.shorten.ShortenScheduledURL(ctx, uid, models.OrigURL(req.OriginalUrl), sched)
+	}
 	if err != nil {
 		if e, ok := err.(errShortenConflict); ok && e.IsErrConflict() {
 			return &pb.ShortenURLResponse{
@@ -56,3 +73,36 @@ func (s *ShortenerServer) ShortenURL(ctx context.Context, req *pb.ShortenURLRequ
 		ShortUrl: s.baseAddr + "/" + string(pair.Short),
 	}, nil
 }
+
+var errBadSchedule = errors.New("expires_at must be later than active_from")
+
+// scheduleFromProto converts optional schedule timestamps of the request.
+func scheduleFromProto(activeFrom, expiresAt *timestamppb.Timestamp) (models.Schedule, error) {
+	var sched models.Schedule
+	if activeFrom != nil {
+		if err := activeFrom.CheckValid(); err != nil {
+			return sched, err
+		}
+		t := activeFrom.AsTime()
+		sched.ActiveFrom = &t
+	}
+	if expiresAt != nil {
+		if err := expiresAt.CheckValid(); err != nil {
+			return sched, err
+		}
+		t := expiresAt.AsTime()
+		sched.ExpiresAt = &t
+	}
+	if sched.ActiveFrom != nil && sched.ExpiresAt != nil && !sched.ExpiresAt.After(*sched.ActiveFrom) {
+		return sched, errBadSchedule
+	}
+	return sched, nil
+}
+
+// timestampOrNil converts optional time into protobuf timestamp.
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
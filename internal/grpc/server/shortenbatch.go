@@ -16,6 +16,9 @@ import (
 type shortenBatchServicer interface {
 	// BatchShortenURL processes multiple URLs in a single atomic operation.
 	BatchShortenURL(context.Context, models.UserID, []models.OrigURL) ([]models.URLPair, error)
+
+	// ImportURLs stores a chunk of imported links reporting the outcome of each.
+	ImportURLs(context.Context, models.UserID, []models.ImportRecord) ([]models.ImportResult, error)
 }
 
 // BatchShortenURL handles batch URL shortening requests.
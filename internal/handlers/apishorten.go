@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/rycln/shorturl/internal/logger"
 	"github.com/rycln/shorturl/internal/models"
@@ -15,8 +17,11 @@ import (
 
 type apiShortenServicer interface {
 	ShortenURL(context.Context, models.UserID, models.OrigURL) (*models.URLPair, error)
+	ShortenScheduledURL(context.Context, models.UserID, models.OrigURL, models.Schedule) (*models.URLPair, error)
 }
 
+var errBadSchedule = errors.New("expires_at must be later than active_from")
+
 type apiShortenAuthServicer interface {
 	GetUserIDFromCtx(context.Context) (models.UserID, error)
 }
@@ -25,7 +30,7 @@ type apiShortenAuthServicer interface {
 //
 // The handler:
 // 1. Extracts user ID from request context (set by auth middleware)
-// 2. Validates input URL from request body
+// 2. Validates input URL and optional schedule from request body
 // 3. Processes through shortening service
 // 4. Returns appropriate HTTP response and body:
 //   - 201 Created: successful shortening
@@ -53,7 +58,9 @@ func NewAPIShortenHandler(apiShortenService apiShortenServicer, authService apiS
 }
 
 type apiShortenReq struct {
-	URL string `json:"url"`
+	URL        string     `json:"url"`
+	ActiveFrom *time.Time `json:"active_from,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
 }
 
 type apiShortenRes struct {
@@ -67,11 +74,15 @@ type apiShortenRes struct {
 //	POST /api/shorten
 //	Content-Type: application/json
 //	Authorization: Bearer <token>
+//
+//	{"url": "<url>", "active_from": "<RFC 3339>", "expires_at": "<RFC 3339>"}
+//
+// Schedule fields are optional, the link redirects only within them.
 func (h *APIShortenHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	uid, err := h.authService.GetUserIDFromCtx(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
@@ -79,36 +90,52 @@ func (h *APIShortenHandler) ServeHTTP(res http.ResponseWriter, req *http.Request
 	err = json.NewDecoder(req.Body).Decode(&reqBody)
 	if err != nil {
 		res.WriteHeader(http.StatusBadRequest)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 	_, err = url.ParseRequestURI(reqBody.URL)
 	if err != nil {
 		res.WriteHeader(http.StatusBadRequest)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	sched := models.Schedule{
+		ActiveFrom: reqBody.ActiveFrom,
+		ExpiresAt:  reqBody.ExpiresAt,
+	}
+	err = validateSchedule(sched)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
-	pair, err := h.apiShortenService.ShortenURL(req.Context(), uid, models.OrigURL(reqBody.URL))
+	var pair *models.URLPair
+	if sched == (models.Schedule{}) {
+		pair, err = h.apiShortenService.ShortenURL(req.Context(), uid, models.OrigURL(reqBody.URL))
+	} else {
+		pair, err = h.apiShortenService.ShortenScheduledURL(req.Context(), uid, models.OrigURL(reqBody.URL), sched)
+	}
 	if e, ok := err.(errAPIShortenConflict); ok && e.IsErrConflict() {
 		err = h.sendResponse(res, http.StatusConflict, string(pair.Short))
 		if err != nil {
 			res.WriteHeader(http.StatusInternalServerError)
-			logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 			return
 		}
 		return
 	}
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
 	err = h.sendResponse(res, http.StatusCreated, string(pair.Short))
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 }
@@ -126,3 +153,10 @@ func (h *APIShortenHandler) sendResponse(res http.ResponseWriter, code int, shor
 	}
 	return nil
 }
+
+func validateSchedule(sched models.Schedule) error {
+	if sched.ActiveFrom != nil && sched.ExpiresAt != nil && !sched.ExpiresAt.After(*sched.ActiveFrom) {
+		return errBadSchedule
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/shorturl/internal/handlers/mocks"
@@ -57,6 +58,44 @@ func TestAPIShortenHandler_HandleHTTP(t *testing.T) {
 		assert.Equal(t, string(jsonRes)+"\n", string(resBody))
 	})
 
+	t.Run("scheduled link", func(t *testing.T) {
+		from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.Add(time.Hour)
+		sched := models.Schedule{ActiveFrom: &from, ExpiresAt: &to}
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testPair.UID, nil)
+		mShort.EXPECT().ShortenScheduledURL(gomock.Any(), testPair.UID, testPair.Orig, sched).Return(&testPair, nil)
+
+		reqBody := strings.NewReader(`{"url":"` + string(testOrigURL) + `","active_from":"2030-01-01T00:00:00Z","expires_at":"2030-01-01T01:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+		w := httptest.NewRecorder()
+		apiShortenHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+	})
+
+	t.Run("wrong schedule", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testPair.UID, nil)
+
+		reqBody := strings.NewReader(`{"url":"` + string(testOrigURL) + `","active_from":"2030-01-01T01:00:00Z","expires_at":"2030-01-01T00:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+		w := httptest.NewRecorder()
+		apiShortenHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
 	t.Run("user id error", func(t *testing.T) {
 		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
 
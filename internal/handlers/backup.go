@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type backupServicer interface {
+	WriteBackup(context.Context, io.Writer) (*models.BackupManifest, error)
+}
+
+// BackupHandler handles download of a point-in-time backup of the whole storage.
+//
+// The archive is streamed as the storage is read. If the backup fails
+// after it has started, the connection is dropped so that a partial
+// archive is not mistaken for a complete one.
+//
+// Response codes:
+//   - 200 OK: backup streamed
+//   - 500 Internal Server Error: processing failure
+type BackupHandler struct {
+	backupService backupServicer
+}
+
+// NewBackupHandler creates new backup handler instance.
+func NewBackupHandler(backupService backupServicer) *BackupHandler {
+	return &BackupHandler{
+		backupService: backupService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for backup endpoint.
+//
+// Expected request format:
+//
+//	GET /api/internal/backup
+func (h *BackupHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	w := &backupResponseWriter{res: res}
+
+	manifest, err := h.backupService.WriteBackup(req.Context(), w)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		if !w.started {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	logger.FromContext(req.Context()).Info("Backup written",
+		zap.Int64("pairs", manifest.Pairs),
+		zap.Int64("tombstones", manifest.Tombstones),
+	)
+}
+
+// backupResponseWriter sends response headers with the first chunk of the archive.
+type backupResponseWriter struct {
+	res     http.ResponseWriter
+	started bool
+}
+
+func (w *backupResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.res.Header().Set("Content-Type", "application/zip")
+		w.res.Header().Set("Content-Disposition", `attachment; filename="shorturl-backup.zip"`)
+		w.res.WriteHeader(http.StatusOK)
+	}
+	return w.res.Write(p)
+}
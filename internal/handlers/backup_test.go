@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBackup := mocks.NewMockbackupServicer(ctrl)
+
+	backupHandler := NewBackupHandler(mBackup)
+
+	serve := func() *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/api/internal/backup", nil)
+		w := httptest.NewRecorder()
+		backupHandler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mBackup.EXPECT().WriteBackup(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, w io.Writer) (*models.BackupManifest, error) {
+				_, err := w.Write([]byte("archive"))
+				return &models.BackupManifest{Version: 1}, err
+			})
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/zip", res.Header.Get("Content-Type"))
+		assert.Contains(t, res.Header.Get("Content-Disposition"), "attachment")
+		body, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", string(body))
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mBackup.EXPECT().WriteBackup(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("error after start", func(t *testing.T) {
+		mBackup.EXPECT().WriteBackup(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, w io.Writer) (*models.BackupManifest, error) {
+				_, err := w.Write([]byte("arch"))
+				require.NoError(t, err)
+				return nil, errTest
+			})
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			serve()
+		})
+	})
+}
@@ -3,7 +3,10 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/rycln/shorturl/internal/logger"
 	"github.com/rycln/shorturl/internal/models"
@@ -13,23 +16,37 @@ import (
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
 type deletionProcessor interface {
-	AddURLsIntoDeletionQueue(models.UserID, []models.ShortURL)
+	AddURLsIntoDeletionQueue(models.UserID, []models.ShortURL) error
 }
 
 type deleteBatchAuthServicer interface {
 	GetUserIDFromCtx(context.Context) (models.UserID, error)
 }
 
+type errDeleteBatchQueueFull interface {
+	error
+	IsErrQueueFull() bool
+	RetryAfter() time.Duration
+}
+
+type errDeleteBatchTooLarge interface {
+	error
+	IsErrTooLarge() bool
+}
+
 // DeleteBatchHandler handles asynchronous batch URL deletion requests.
 //
 // The handler:
 // 1. Extracts user ID from request context (set by auth middleware)
 // 2. Queues deletion tasks in background
-// 3. Immediately returns 202 Accepted
+// 3. Returns 202 Accepted as soon as the tasks are durably queued
 //
 // Response codes:
 //   - 202 Accepted: request queued for processing
+//   - 400 Bad Request: malformed request body
+//   - 413 Request Entity Too Large: request exceeds deletion queue capacity
 //   - 500 Internal Server Error: queue failure
+//   - 503 Service Unavailable: queue is full, Retry-After tells when to retry
 //
 // Only URL owner can successfully delete URLs.
 type DeleteBatchHandler struct {
@@ -56,18 +73,35 @@ func (h *DeleteBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Reques
 	uid, err := h.authService.GetUserIDFromCtx(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
 	var surls []models.ShortURL
 	err = json.NewDecoder(req.Body).Decode(&surls)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	err = h.delProc.AddURLsIntoDeletionQueue(models.UserID(uid), surls)
+	if e, ok := err.(errDeleteBatchQueueFull); ok && e.IsErrQueueFull() {
+		res.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter().Seconds()))))
+		res.WriteHeader(http.StatusServiceUnavailable)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if e, ok := err.(errDeleteBatchTooLarge); ok && e.IsErrTooLarge() {
+		res.WriteHeader(http.StatusRequestEntityTooLarge)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
-	h.delProc.AddURLsIntoDeletionQueue(models.UserID(uid), surls)
 	res.WriteHeader(http.StatusAccepted)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/shorturl/internal/handlers/mocks"
@@ -30,7 +31,7 @@ func TestDeleteBatchHandler_ServeHTTP(t *testing.T) {
 	}
 	t.Run("valid test", func(t *testing.T) {
 		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
-		mProc.EXPECT().AddURLsIntoDeletionQueue(gomock.Any(), gomock.Any())
+		mProc.EXPECT().AddURLsIntoDeletionQueue(gomock.Any(), gomock.Any()).Return(nil)
 
 		jsonReq, err := json.Marshal(&testShortURLs)
 		require.NoError(t, err)
@@ -48,6 +49,76 @@ func TestDeleteBatchHandler_ServeHTTP(t *testing.T) {
 		assert.Equal(t, http.StatusAccepted, res.StatusCode)
 	})
 
+	t.Run("queue error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mProc.EXPECT().AddURLsIntoDeletionQueue(testUserID, testShortURLs).Return(errTest)
+
+		jsonReq, err := json.Marshal(&testShortURLs)
+		require.NoError(t, err)
+		reqBody := bytes.NewReader(jsonReq)
+		req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+		w := httptest.NewRecorder()
+		delBatchHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err = res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		mErr := mocks.NewMockerrDeleteBatchQueueFull(ctrl)
+		mErr.EXPECT().IsErrQueueFull().Return(true)
+		mErr.EXPECT().RetryAfter().Return(1500 * time.Millisecond)
+		mErr.EXPECT().Error().Return(errTest.Error()).AnyTimes()
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mProc.EXPECT().AddURLsIntoDeletionQueue(testUserID, testShortURLs).Return(mErr)
+
+		jsonReq, err := json.Marshal(&testShortURLs)
+		require.NoError(t, err)
+		reqBody := bytes.NewReader(jsonReq)
+		req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+		w := httptest.NewRecorder()
+		delBatchHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err = res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get("Retry-After"))
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		mErr := mocks.NewMockerrDeleteBatchTooLarge(ctrl)
+		mErr.EXPECT().IsErrTooLarge().Return(true)
+		mErr.EXPECT().Error().Return(errTest.Error()).AnyTimes()
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mProc.EXPECT().AddURLsIntoDeletionQueue(testUserID, testShortURLs).Return(mErr)
+
+		jsonReq, err := json.Marshal(&testShortURLs)
+		require.NoError(t, err)
+		reqBody := bytes.NewReader(jsonReq)
+		req := httptest.NewRequest(http.MethodPost, "/", reqBody)
+		w := httptest.NewRecorder()
+		delBatchHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err = res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+
 	t.Run("user id error", func(t *testing.T) {
 		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
 
@@ -81,6 +152,6 @@ func TestDeleteBatchHandler_ServeHTTP(t *testing.T) {
 			require.NoError(t, err)
 		}()
 
-		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 	})
 }
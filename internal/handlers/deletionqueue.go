@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type deletionQueueStater interface {
+	Stats() models.DeletionQueueStats
+}
+
+// DeletionQueueStatsHandler reports state of the background deletion queue.
+//
+// Response codes:
+//   - 200 OK: queue state returned
+//   - 500 Internal Server Error: encoding failure
+type DeletionQueueStatsHandler struct {
+	queue deletionQueueStater
+}
+
+// NewDeletionQueueStatsHandler creates new deletion queue stats handler instance.
+func NewDeletionQueueStatsHandler(queue deletionQueueStater) *DeletionQueueStatsHandler {
+	return &DeletionQueueStatsHandler{
+		queue: queue,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for deletion queue stats endpoint.
+//
+// Expected request format:
+//
+//	GET /api/internal/deletion-queue
+//	X-Real-IP: <trusted address>
+func (h *DeletionQueueStatsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(res).Encode(h.queue.Stats())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+	}
+}
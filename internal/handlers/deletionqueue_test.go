@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionQueueStatsHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mQueue := mocks.NewMockdeletionQueueStater(ctrl)
+
+	handler := NewDeletionQueueStatsHandler(mQueue)
+
+	mQueue.EXPECT().Stats().Return(models.DeletionQueueStats{
+		Depth:         3,
+		Capacity:      10,
+		InFlight:      2,
+		Deleted:       5,
+		Rejected:      1,
+		FailedBatches: 4,
+		RetryIn:       time.Second,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer func() {
+		err := res.Body.Close()
+		require.NoError(t, err)
+	}()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	resBody, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"depth":3,"capacity":10,"in_flight":2,"deleted":5,"rejected":1,"failed_batches":4,"retry_in":1000000000}`, string(resBody))
+}
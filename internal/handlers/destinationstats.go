@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type destinationStatsServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	GetDestinationStats(context.Context, models.UserID, models.ShortURL) ([]models.VariantStat, error)
+}
+
+type destinationStatsAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// DestinationStatsHandler handles requests for weighted destinations
+// of a link together with redirect counts of each variant.
+//
+// Response codes:
+//   - 200 OK: destinations returned
+//   - 204 No Content: link has a single destination
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type DestinationStatsHandler struct {
+	destinationStatsService destinationStatsServicer
+	authService             destinationStatsAuthServicer
+}
+
+type errDestinationStatsNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewDestinationStatsHandler creates new destination statistics handler instance.
+func NewDestinationStatsHandler(destinationStatsService destinationStatsServicer, authService destinationStatsAuthServicer) *DestinationStatsHandler {
+	return &DestinationStatsHandler{
+		destinationStatsService: destinationStatsService,
+		authService:             authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for destination statistics endpoint.
+//
+// Expected request format:
+//
+//	GET /api/user/urls/{id}/destinations
+//	Authorization: Bearer <token>
+func (h *DestinationStatsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.destinationStatsService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	stats, err := h.destinationStatsService.GetDestinationStats(req.Context(), uid, shortURL)
+	if e, ok := err.(errDestinationStatsNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	if len(stats) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(res).Encode(stats)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestinationStatsHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockdestinationStatsServicer(ctrl)
+	mAuth := mocks.NewMockdestinationStatsAuthServicer(ctrl)
+
+	handler := NewDestinationStatsHandler(mServ, mAuth)
+
+	testStats := []models.VariantStat{
+		{Destination: models.Destination{Variant: "a", URL: "https://example.com/a", Weight: 3}, Hits: 30},
+		{Destination: models.Destination{Variant: "b", URL: "https://example.com/b", Weight: 1}, Hits: 10},
+	}
+
+	serve := func() *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetDestinationStats(gomock.Any(), testUserID, testShortURL).Return(testStats, nil)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var got []models.VariantStat
+		err := json.NewDecoder(res.Body).Decode(&got)
+		require.NoError(t, err)
+		assert.Equal(t, testStats, got)
+	})
+
+	t.Run("no destinations", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetDestinationStats(gomock.Any(), testUserID, testShortURL).Return(nil, nil)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrDestinationStatsNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().GetDestinationStats(gomock.Any(), testUserID, testShortURL).Return(nil, mErr)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("some service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetDestinationStats(gomock.Any(), testUserID, testShortURL).Return(nil, errTest)
+
+		res := serve()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
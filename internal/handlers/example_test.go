@@ -52,7 +52,7 @@ func ExampleRetrieveHandler_ServeHTTP() {
 
 	mServ := mocks.NewMockretrieveServicer(ctrl)
 
-	handler := NewRetrieveHandler(mServ)
+	handler := NewRetrieveHandler(mServ, nil)
 
 	shortURL := models.ShortURL("abc123")
 	origURL := models.OrigURL("https://example.com")
@@ -214,7 +214,7 @@ func ExampleDeleteBatchHandler_ServeHTTP() {
 
 	userID := models.UserID("user1")
 	mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(userID, nil)
-	mProc.EXPECT().AddURLsIntoDeletionQueue(gomock.Any(), gomock.Any())
+	mProc.EXPECT().AddURLsIntoDeletionQueue(gomock.Any(), gomock.Any()).Return(nil)
 
 	body := strings.NewReader(`["6qxTVvsy", "RTfd56hn", "Jlfd67ds"]`)
 	req := httptest.NewRequest("POST", "/", body)
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+//go:embed templates/preview.html
+var previewPage string
+
+var previewTmpl = template.Must(template.New("preview").Parse(previewPage))
+
+type expandServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	DescribeURL(context.Context, models.ShortURL) (*models.URLInfo, error)
+}
+
+type expandAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// ExpandHandler handles requests to see where a short link goes
+// without following it.
+//
+// Neither a click of a limited link is taken nor a visit is counted.
+// Destination of a password protected link is shown to its owner only.
+// The owner also sees link settings and remaining clicks.
+// Clients accepting text/html get a preview page, others get JSON.
+//
+// Response codes:
+//   - 200 OK: link described
+//   - 404 Not Found: no such short URL
+//   - 500 Internal Server Error: processing failure
+type ExpandHandler struct {
+	expandService expandServicer
+	authService   expandAuthServicer
+	baseAddr      string
+}
+
+type errExpandNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewExpandHandler creates new link preview handler instance.
+func NewExpandHandler(expandService expandServicer, authService expandAuthServicer, baseAddr string) *ExpandHandler {
+	return &ExpandHandler{
+		expandService: expandService,
+		authService:   authService,
+		baseAddr:      baseAddr,
+	}
+}
+
+type expandRes struct {
+	ShortURL          string              `json:"short_url"`
+	OrigURL           string              `json:"original_url,omitempty"`
+	Status            models.URLStatus    `json:"status"`
+	CreatedAt         *time.Time          `json:"created_at,omitempty"`
+	ActiveFrom        *time.Time          `json:"active_from,omitempty"`
+	ExpiresAt         *time.Time          `json:"expires_at,omitempty"`
+	PasswordProtected bool                `json:"password_protected,omitempty"`
+	ClicksLeft        *int64              `json:"clicks_left,omitempty"`
+	Options           *models.LinkOptions `json:"options,omitempty"`
+}
+
+// ServeHTTP implements http.Handler interface for link preview endpoint.
+//
+// Expected request format:
+//
+//	GET /{id}+
+//	GET /api/expand/{id}
+func (h *ExpandHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.expandService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	info, err := h.expandService.DescribeURL(req.Context(), shortURL)
+	if e, ok := err.(errExpandNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	resBody := newExpandRes(h.baseAddr, info, info.UID == uid)
+
+	if strings.Contains(req.Header.Get("Accept"), "text/html") {
+		res.Header().Set("Content-Type", "text/html; charset=utf-8")
+		res.WriteHeader(http.StatusOK)
+		err = previewTmpl.Execute(res, resBody)
+		if err != nil {
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		}
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(res).Encode(&resBody)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+}
+
+// newExpandRes builds link description visible to the requesting user.
+func newExpandRes(baseAddr string, info *models.URLInfo, owner bool) expandRes {
+	protected := info.Options != nil && info.Options.PasswordHash != ""
+
+	resBody := expandRes{
+		ShortURL:          baseAddr + "/" + string(info.Short),
+		Status:            info.Status,
+		ActiveFrom:        info.ActiveFrom,
+		ExpiresAt:         info.ExpiresAt,
+		PasswordProtected: protected,
+	}
+	if !info.CreatedAt.IsZero() {
+		resBody.CreatedAt = &info.CreatedAt
+	}
+	if !protected || owner {
+		resBody.OrigURL = string(info.Orig)
+	}
+
+	if owner {
+		resBody.ClicksLeft = info.ClicksLeft
+		if o := info.Options; o != nil && (o.Passthrough != nil || len(o.Destinations) > 0 || len(o.Rules) > 0) {
+			opts := *o
+			opts.PasswordHash = ""
+			resBody.Options = &opts
+		}
+	}
+
+	return resBody
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockexpandServicer(ctrl)
+	mAuth := mocks.NewMockexpandAuthServicer(ctrl)
+
+	expandHandler := NewExpandHandler(mServ, mAuth, testBaseAddr)
+
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	left := int64(3)
+
+	info := &models.URLInfo{
+		URLPair: testPair,
+		Status:  models.StatusActive,
+	}
+	info.CreatedAt = created
+	info.ClicksLeft = &left
+
+	protected := &models.URLInfo{
+		URLPair: testPair,
+		Status:  models.StatusActive,
+	}
+	protected.Options = &models.LinkOptions{PasswordHash: "hash"}
+
+	serve := func(t *testing.T, accept string) (*http.Response, []byte) {
+		req := httptest.NewRequest(http.MethodGet, "/abc123+", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		expandHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, body
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID("visitor"), nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, body := serve(t, "")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		var got expandRes
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, testBaseAddr+"/"+string(testShortURL), got.ShortURL)
+		assert.Equal(t, string(testOrigURL), got.OrigURL)
+		assert.Equal(t, models.StatusActive, got.Status)
+		require.NotNil(t, got.CreatedAt)
+		assert.True(t, created.Equal(*got.CreatedAt))
+		assert.Nil(t, got.ClicksLeft)
+	})
+
+	t.Run("owner view", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, body := serve(t, "")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var got expandRes
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.ClicksLeft)
+		assert.Equal(t, left, *got.ClicksLeft)
+	})
+
+	t.Run("password protected", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID("visitor"), nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(protected, nil)
+
+		res, body := serve(t, "")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotContains(t, string(body), string(testOrigURL))
+		assert.NotContains(t, string(body), "hash")
+		assert.Contains(t, string(body), `"password_protected":true`)
+	})
+
+	t.Run("password protected owner view", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(protected, nil)
+
+		_, body := serve(t, "")
+
+		assert.Contains(t, string(body), string(testOrigURL))
+		assert.NotContains(t, string(body), "hash")
+		assert.NotContains(t, string(body), "options")
+		assert.Equal(t, "hash", protected.Options.PasswordHash)
+	})
+
+	t.Run("html preview", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID("visitor"), nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, body := serve(t, "text/html,application/xhtml+xml")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Content-Type"), "text/html")
+		assert.Contains(t, string(body), string(testOrigURL))
+		assert.Contains(t, string(body), "active")
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrExpandNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(nil, mErr)
+
+		res, _ := serve(t, "")
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(nil, errTest)
+
+		res, _ := serve(t, "")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res, _ := serve(t, "")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// maxExpandBatchSize limits the number of short URLs resolved in one request.
+const maxExpandBatchSize = 1000
+
+var errBadExpandBatch = errors.New("batch must contain from 1 to 1000 short URLs")
+
+type expandBatchServicer interface {
+	BatchDescribeURL(context.Context, []models.ShortURL) ([]models.URLInfo, error)
+}
+
+type expandBatchAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// ExpandBatchHandler handles requests to resolve many short URLs at once
+// without following them.
+//
+// Items of the response follow the request order. Each carries
+// the destination or the reason it is unavailable in status field
+// (active, scheduled, expired, deleted, not_found).
+// Visibility rules are the same as for ExpandHandler.
+//
+// Response codes:
+//   - 200 OK: short URLs resolved
+//   - 400 Bad Request: invalid request body or batch size
+//   - 500 Internal Server Error: processing failure
+type ExpandBatchHandler struct {
+	expandBatchService expandBatchServicer
+	authService        expandBatchAuthServicer
+	baseAddr           string
+}
+
+// NewExpandBatchHandler creates new bulk expand handler instance.
+func NewExpandBatchHandler(expandBatchService expandBatchServicer, authService expandBatchAuthServicer, baseAddr string) *ExpandBatchHandler {
+	return &ExpandBatchHandler{
+		expandBatchService: expandBatchService,
+		authService:        authService,
+		baseAddr:           baseAddr,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for bulk expand endpoint.
+//
+// Expected request format:
+//
+//	POST /api/expand/batch
+//	Content-Type: application/json
+//
+//	["<id>", "<id>", ...]
+func (h *ExpandBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var shorts []models.ShortURL
+	err = json.NewDecoder(req.Body).Decode(&shorts)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if len(shorts) == 0 || len(shorts) > maxExpandBatchSize {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(errBadExpandBatch))
+		return
+	}
+
+	infos, err := h.expandBatchService.BatchDescribeURL(req.Context(), shorts)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	resBatch := make([]expandRes, len(infos))
+	for i := range infos {
+		resBatch[i] = newExpandRes(h.baseAddr, &infos[i], infos[i].UID == uid)
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(res).Encode(&resBatch)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+}
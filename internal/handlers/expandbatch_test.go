@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandBatchHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockexpandBatchServicer(ctrl)
+	mAuth := mocks.NewMockexpandBatchAuthServicer(ctrl)
+
+	expandBatchHandler := NewExpandBatchHandler(mServ, mAuth, testBaseAddr)
+
+	serve := func(t *testing.T, body string) (*http.Response, []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		expandBatchHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		resBody, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, resBody
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID("crawler"), nil)
+		mServ.EXPECT().BatchDescribeURL(gomock.Any(), []models.ShortURL{testShortURL, "unknown"}).Return([]models.URLInfo{
+			{URLPair: testPair, Status: models.StatusActive},
+			{URLPair: models.URLPair{Short: "unknown"}, Status: models.StatusNotFound},
+		}, nil)
+
+		res, body := serve(t, `["abc123","unknown"]`)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var got []expandRes
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, string(testOrigURL), got[0].OrigURL)
+		assert.Equal(t, models.StatusActive, got[0].Status)
+		assert.Equal(t, testBaseAddr+"/unknown", got[1].ShortURL)
+		assert.Empty(t, got[1].OrigURL)
+		assert.Equal(t, models.StatusNotFound, got[1].Status)
+	})
+
+	t.Run("bad request body", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, `{"url":"abc123"}`)
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, `[]`)
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		shorts := make([]string, maxExpandBatchSize+1)
+		for i := range shorts {
+			shorts[i] = "abc"
+		}
+		body, err := json.Marshal(shorts)
+		require.NoError(t, err)
+
+		res, _ := serve(t, string(body))
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().BatchDescribeURL(gomock.Any(), []models.ShortURL{testShortURL}).Return(nil, errTest)
+
+		res, _ := serve(t, `["abc123"]`)
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res, _ := serve(t, `["abc123"]`)
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
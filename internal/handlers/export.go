@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// Supported export formats.
+const (
+	exportFormatCSV   = "csv"
+	exportFormatJSON  = "json"
+	exportFormatJSONL = "jsonl"
+)
+
+// exportFlushRows is the number of links sent to the client at once.
+const exportFlushRows = 100
+
+var exportContentTypes = map[string]string{
+	exportFormatCSV:   "text/csv; charset=utf-8",
+	exportFormatJSON:  "application/json",
+	exportFormatJSONL: "application/x-ndjson",
+}
+
+// exportCSVHeader names CSV columns. It is accepted by the import as is.
+var exportCSVHeader = []string{
+	"short_url",
+	"original_url",
+	"status",
+	"created_at",
+	"active_from",
+	"expires_at",
+	"clicks_left",
+	"password_protected",
+	"tags",
+}
+
+type exportServicer interface {
+	ExportUserURLs(context.Context, models.UserID, func(models.URLInfo) error) error
+}
+
+type exportAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// ExportHandler handles download of all user's links including deleted ones.
+//
+// Links are written as storage reads them, the export is never held
+// in memory. Each link carries its status (active, scheduled, expired,
+// deleted) and metadata. Links are flushed to the client in chunks of
+// exportFlushRows, so the route is served without the request timeout.
+// If the export fails after it has started,
+// the connection is dropped so that a partial file is not mistaken
+// for a complete one.
+//
+// Response codes:
+//   - 200 OK: export streamed
+//   - 400 Bad Request: unknown format
+//   - 500 Internal Server Error: processing failure
+type ExportHandler struct {
+	exportService exportServicer
+	authService   exportAuthServicer
+	baseAddr      string
+}
+
+// NewExportHandler creates new export handler instance.
+func NewExportHandler(exportService exportServicer, authService exportAuthServicer, baseAddr string) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		authService:   authService,
+		baseAddr:      baseAddr,
+	}
+}
+
+type exportRes struct {
+	expandRes
+	Tags []string `json:"tags,omitempty"`
+}
+
+// ServeHTTP implements http.Handler interface for export endpoint.
+//
+// Expected request format:
+//
+//	GET /api/user/urls/export?format=csv|json|jsonl
+//	Authorization: Bearer <token>
+//
+// Format defaults to csv.
+func (h *ExportHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatCSV
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var w exportWriter
+	switch format {
+	case exportFormatCSV:
+		w = &csvExportWriter{w: csv.NewWriter(res)}
+	case exportFormatJSON:
+		w = &jsonExportWriter{w: res}
+	default:
+		w = &jsonlExportWriter{enc: json.NewEncoder(res)}
+	}
+
+	var started bool
+	var rows int
+	start := func() error {
+		started = true
+		res.Header().Set("Content-Type", contentType)
+		res.Header().Set("Content-Disposition", `attachment; filename="urls.`+format+`"`)
+		res.WriteHeader(http.StatusOK)
+		return w.begin()
+	}
+
+	err = h.exportService.ExportUserURLs(req.Context(), uid, func(info models.URLInfo) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		err := w.write(exportRes{
+			expandRes: newExpandRes(h.baseAddr, &info, true),
+			Tags:      info.Tags,
+		})
+		if err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushRows != 0 {
+			return nil
+		}
+		err = w.flush()
+		if err != nil {
+			return err
+		}
+		err = http.NewResponseController(res).Flush()
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = w.end()
+	}
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		if !started {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockexportServicer(ctrl)
+	mAuth := mocks.NewMockexportAuthServicer(ctrl)
+
+	exportHandler := NewExportHandler(mServ, mAuth, testBaseAddr)
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	clicks := int64(3)
+	tagged := testPair
+	tagged.CreatedAt = created
+	tagged.ClicksLeft = &clicks
+	tagged.Tags = []string{"sale", "2025"}
+	tagged.Options = &models.LinkOptions{PasswordHash: "hash"}
+
+	infos := []models.URLInfo{
+		{URLPair: tagged, Status: models.StatusActive},
+		{URLPair: testPair, Status: models.StatusDeleted},
+	}
+
+	export := func(_ context.Context, _ models.UserID, fn func(models.URLInfo) error) error {
+		for _, info := range infos {
+			if err := fn(info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	serve := func(t *testing.T, target string) (*http.Response, string) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		exportHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, string(body)
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(export)
+
+		res, body := serve(t, "/")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="urls.csv"`, res.Header.Get("Content-Disposition"))
+		assert.Equal(t, "short_url,original_url,status,created_at,active_from,expires_at,clicks_left,password_protected,tags\n"+
+			"test//abc123,https://practicum.yandex.ru/,active,2025-01-02T03:04:05Z,,,3,true,\"sale,2025\"\n"+
+			"test//abc123,https://practicum.yandex.ru/,deleted,,,,,false,\n", body)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(export)
+
+		res, body := serve(t, "/?format=json")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var items []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(body), &items))
+		require.Len(t, items, 2)
+		assert.Equal(t, []any{"sale", "2025"}, items[0]["tags"])
+		assert.Equal(t, true, items[0]["password_protected"])
+		assert.NotContains(t, body, "hash")
+		assert.Equal(t, "deleted", items[1]["status"])
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(export)
+
+		res, body := serve(t, "/?format=jsonl")
+
+		assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"short_url":"test//abc123","original_url":"https://practicum.yandex.ru/","status":"deleted"}`, lines[1])
+	})
+
+	t.Run("empty json", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).Return(nil)
+
+		res, body := serve(t, "/?format=json")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "[]\n", body)
+	})
+
+	t.Run("flushes in chunks", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.UserID, fn func(models.URLInfo) error) error {
+				for i := 0; i < exportFlushRows; i++ {
+					require.False(t, w.Flushed)
+					if err := fn(infos[1]); err != nil {
+						return err
+					}
+				}
+				assert.True(t, w.Flushed)
+				assert.Equal(t, exportFlushRows+1, strings.Count(w.Body.String(), "\n"))
+				return fn(infos[1])
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		exportHandler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, exportFlushRows+2, strings.Count(w.Body.String(), "\n"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, "/?format=xml")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("error before export started", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).Return(errTest)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("error after export started", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ExportUserURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.UserID, fn func(models.URLInfo) error) error {
+				require.NoError(t, fn(infos[0]))
+				return errTest
+			})
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			serve(t, "/")
+		})
+	})
+}
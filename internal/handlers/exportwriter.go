@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportWriter writes links in one of export formats.
+type exportWriter interface {
+	begin() error
+	write(exportRes) error
+	flush() error
+	end() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) begin() error {
+	return e.w.Write(exportCSVHeader)
+}
+
+func (e *csvExportWriter) write(r exportRes) error {
+	var clicksLeft string
+	if r.ClicksLeft != nil {
+		clicksLeft = strconv.FormatInt(*r.ClicksLeft, 10)
+	}
+
+	return e.w.Write([]string{
+		r.ShortURL,
+		r.OrigURL,
+		string(r.Status),
+		formatExportTime(r.CreatedAt),
+		formatExportTime(r.ActiveFrom),
+		formatExportTime(r.ExpiresAt),
+		clicksLeft,
+		strconv.FormatBool(r.PasswordProtected),
+		strings.Join(r.Tags, ","),
+	})
+}
+
+func (e *csvExportWriter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) end() error {
+	return e.flush()
+}
+
+type jsonExportWriter struct {
+	w     http.ResponseWriter
+	count int
+}
+
+func (e *jsonExportWriter) begin() error {
+	_, err := e.w.Write([]byte("["))
+	return err
+}
+
+func (e *jsonExportWriter) write(r exportRes) error {
+	item, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		item = append([]byte(","), item...)
+	}
+	e.count++
+
+	_, err = e.w.Write(item)
+	return err
+}
+
+func (e *jsonExportWriter) flush() error { return nil }
+
+func (e *jsonExportWriter) end() error {
+	_, err := e.w.Write([]byte("]\n"))
+	return err
+}
+
+type jsonlExportWriter struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlExportWriter) begin() error { return nil }
+
+func (e *jsonlExportWriter) write(r exportRes) error {
+	return e.enc.Encode(r)
+}
+
+func (e *jsonlExportWriter) flush() error { return nil }
+
+func (e *jsonlExportWriter) end() error { return nil }
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
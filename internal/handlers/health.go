@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/health"
+	"github.com/rycln/shorturl/internal/logger"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type readinessChecker interface {
+	Check(context.Context) *health.Report
+}
+
+// LivenessHandler implements liveness probe endpoint.
+//
+// The process is alive as long as it serves HTTP requests, so the handler
+// always responds with 200 OK without checking dependencies: their failures
+// are reported by readiness and must not cause restarts.
+type LivenessHandler struct{}
+
+// NewLivenessHandler creates new liveness handler instance.
+func NewLivenessHandler() *LivenessHandler {
+	return &LivenessHandler{}
+}
+
+// ServeHTTP implements http.Handler interface for liveness endpoint.
+//
+// Expected request format:
+//
+//	GET /healthz
+func (h *LivenessHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "application/json")
+	_, err := res.Write([]byte(`{"status":"ok"}`))
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+	}
+}
+
+// ReadinessHandler implements readiness probe endpoint.
+//
+// Handler flow:
+// 1. Checks application components
+// 2. Responds with the status of every component as JSON:
+//   - 200 OK: all components are up
+//   - 503 Service Unavailable: a component is down or the server is shutting down
+type ReadinessHandler struct {
+	checker readinessChecker
+}
+
+// NewReadinessHandler creates new readiness handler instance.
+func NewReadinessHandler(checker readinessChecker) *ReadinessHandler {
+	return &ReadinessHandler{
+		checker: checker,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for readiness endpoint.
+//
+// Expected request format:
+//
+//	GET /readyz
+//
+// Response format:
+//
+//	{"status":"ready","components":{"storage":{"status":"up"}}}
+func (h *ReadinessHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	report := h.checker.Check(req.Context())
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	if !report.Ready() {
+		res.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err := json.NewEncoder(res).Encode(report)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivenessHandler_ServeHTTP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	NewLivenessHandler().ServeHTTP(w, req)
+
+	res := w.Result()
+	defer func() {
+		err := res.Body.Close()
+		require.NoError(t, err)
+	}()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestReadinessHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mChecker := mocks.NewMockreadinessChecker(ctrl)
+
+	readinessHandler := NewReadinessHandler(mChecker)
+
+	tests := []struct {
+		name       string
+		report     *health.Report
+		wantStatus int
+	}{
+		{
+			name: "ready",
+			report: &health.Report{
+				Status: health.StatusReady,
+				Components: map[string]health.Component{
+					"storage": {Status: health.StatusUp},
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "component down",
+			report: &health.Report{
+				Status: health.StatusNotReady,
+				Components: map[string]health.Component{
+					"storage": {Status: health.StatusDown, Error: errTest.Error()},
+				},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "shutting down",
+			report: &health.Report{
+				Status: health.StatusShuttingDown,
+				Components: map[string]health.Component{
+					"storage": {Status: health.StatusUp},
+				},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mChecker.EXPECT().Check(gomock.Any()).Return(test.report)
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			w := httptest.NewRecorder()
+
+			readinessHandler.ServeHTTP(w, req)
+
+			res := w.Result()
+			defer func() {
+				err := res.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, test.wantStatus, res.StatusCode)
+			assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+			var report health.Report
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, *test.report, report)
+		})
+	}
+}
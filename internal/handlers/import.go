@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// importChunkSize is the number of records stored within one service call.
+const importChunkSize = 500
+
+// Supported import formats.
+const (
+	importFormatCSV   = "csv"
+	importFormatJSONL = "jsonl"
+)
+
+var importMediaTypes = map[string]string{
+	"text/csv":             importFormatCSV,
+	"application/csv":      importFormatCSV,
+	"application/x-ndjson": importFormatJSONL,
+	"application/jsonl":    importFormatJSONL,
+	"application/json":     importFormatJSONL,
+}
+
+var errImportAborted = errors.New("import aborted")
+
+type importServicer interface {
+	ImportURLs(context.Context, models.UserID, []models.ImportRecord) ([]models.ImportResult, error)
+}
+
+type importAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// ImportHandler handles bulk import of existing links.
+//
+// The import is read and stored in chunks, so neither the file nor the report
+// is held in memory. The report is streamed as JSON lines, one per record
+// in input order, with status created, conflict or invalid.
+// If the import fails after the report has started, it ends with
+// an {"error": "..."} line; records reported before it are stored.
+//
+// Response codes:
+//   - 200 OK: import processed, see the report for each record
+//   - 400 Bad Request: missing header row or destination column, unreadable body
+//   - 415 Unsupported Media Type: unknown import format
+//   - 500 Internal Server Error: processing failure
+type ImportHandler struct {
+	importService importServicer
+	authService   importAuthServicer
+	baseAddr      string
+}
+
+// NewImportHandler creates new bulk import handler instance.
+func NewImportHandler(importService importServicer, authService importAuthServicer, baseAddr string) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+		authService:   authService,
+		baseAddr:      baseAddr,
+	}
+}
+
+type importRes struct {
+	Row         int                 `json:"row"`
+	Status      models.ImportStatus `json:"status"`
+	ShortURL    string              `json:"short_url,omitempty"`
+	OriginalURL string              `json:"original_url,omitempty"`
+	Reason      string              `json:"reason,omitempty"`
+}
+
+type importErrorRes struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP implements http.Handler interface for bulk import endpoint.
+//
+// Expected request format:
+//
+//	POST /api/user/urls/import[?format=csv|jsonl]
+//	Content-Type: text/csv | application/x-ndjson
+//	Authorization: Bearer <token>
+//
+//	destination,slug,tags,expires_at
+//	https://example.com,promo,"sale,2025",2026-01-01T00:00:00Z
+//
+// or
+//
+//	{"destination": "https://example.com", "slug": "promo", "tags": ["sale"], "expires_at": "2026-01-01"}
+//
+// Only destination is required. Bitly exports (long_url, link) are accepted as is.
+func (h *ImportHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	format, ok := importFormat(req)
+	if !ok {
+		res.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var dec importDecoder
+	if format == importFormatCSV {
+		dec, err = newCSVImportDecoder(req.Body)
+		if err != nil {
+			res.WriteHeader(http.StatusBadRequest)
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+			return
+		}
+	} else {
+		dec = newJSONLImportDecoder(req.Body)
+	}
+
+	var started bool
+	abort := func(code int, err error) {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		if !started {
+			res.WriteHeader(code)
+			return
+		}
+		err = json.NewEncoder(res).Encode(importErrorRes{Error: errImportAborted.Error()})
+		if err != nil {
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		}
+	}
+
+	chunk := make([]models.ImportRecord, 0, importChunkSize)
+	flush := func() error {
+		var results []models.ImportResult
+		if len(chunk) > 0 {
+			var err error
+			results, err = h.importService.ImportURLs(req.Context(), uid, chunk)
+			if err != nil {
+				return err
+			}
+			chunk = chunk[:0]
+		}
+
+		if !started {
+			res.Header().Set("Content-Type", "application/x-ndjson")
+			res.WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		enc := json.NewEncoder(res)
+		for _, result := range results {
+			err = enc.Encode(h.newImportRes(result))
+			if err != nil {
+				return err
+			}
+		}
+
+		err = http.NewResponseController(res).Flush()
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	for {
+		rec, err := dec.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			abort(http.StatusBadRequest, err)
+			return
+		}
+
+		chunk = append(chunk, rec)
+		if len(chunk) < importChunkSize {
+			continue
+		}
+
+		err = flush()
+		if err != nil {
+			abort(http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if len(chunk) == 0 && started {
+		return
+	}
+
+	// the last chunk, or an empty report if the import has no records
+	err = flush()
+	if err != nil {
+		abort(http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (h *ImportHandler) newImportRes(result models.ImportResult) importRes {
+	r := importRes{
+		Row:         result.Row,
+		Status:      result.Status,
+		OriginalURL: string(result.Orig),
+		Reason:      result.Reason,
+	}
+	if result.Status != models.ImportInvalid {
+		r.ShortURL = h.baseAddr + "/" + string(result.Short)
+	}
+	return r
+}
+
+// importFormat detects import format from format query parameter
+// or request content type.
+func importFormat(req *http.Request) (string, bool) {
+	switch format := req.URL.Query().Get("format"); format {
+	case importFormatCSV, importFormatJSONL:
+		return format, true
+	case "":
+	default:
+		return "", false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+	format, ok := importMediaTypes[mediaType]
+	return format, ok
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockimportServicer(ctrl)
+	mAuth := mocks.NewMockimportAuthServicer(ctrl)
+
+	importHandler := NewImportHandler(mServ, mAuth, testBaseAddr)
+
+	serve := func(t *testing.T, contentType, target, body string) (*http.Response, []string) {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		importHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			require.NoError(t, res.Body.Close())
+		}()
+
+		var lines []string
+		s := bufio.NewScanner(res.Body)
+		for s.Scan() {
+			lines = append(lines, s.Text())
+		}
+		require.NoError(t, s.Err())
+		return res, lines
+	}
+
+	// echo reports every record as created under its own or a fixed slug
+	echo := func(_ any, _ models.UserID, recs []models.ImportRecord) ([]models.ImportResult, error) {
+		results := make([]models.ImportResult, len(recs))
+		for i, rec := range recs {
+			results[i] = models.ImportResult{Row: rec.Row, Short: rec.Short, Orig: rec.Orig, Status: models.ImportCreated}
+			if rec.Err != nil {
+				results[i].Status = models.ImportInvalid
+				results[i].Reason = rec.Err.Error()
+			}
+		}
+		return results, nil
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		body := "destination,slug,tags,expires_at\n" +
+			"https://example.com,promo,\"sale,2025\",2030-01-01\n" +
+			"https://example.org,,,\n"
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(
+			func(ctx any, uid models.UserID, recs []models.ImportRecord) ([]models.ImportResult, error) {
+				require.Len(t, recs, 2)
+				assert.Equal(t, models.ShortURL("promo"), recs[0].Short)
+				assert.Equal(t, []string{"sale", "2025"}, recs[0].Tags)
+				require.NotNil(t, recs[0].ExpiresAt)
+				assert.Equal(t, 2030, recs[0].ExpiresAt.Year())
+				assert.Equal(t, models.OrigURL("https://example.org"), recs[1].Orig)
+				return echo(ctx, uid, recs)
+			})
+
+		res, lines := serve(t, "text/csv", "/", body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"row":1,"status":"created","short_url":"test//promo","original_url":"https://example.com"}`, lines[0])
+	})
+
+	t.Run("bitly export", func(t *testing.T) {
+		body := "title,long_url,link,tags\n" +
+			"Promo,https://example.com/a,https://bit.ly/3abcDEF,sale\n"
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, []models.ImportRecord{
+			{Row: 1, Orig: "https://example.com/a", Short: "3abcDEF", Tags: []string{"sale"}},
+		}).DoAndReturn(echo)
+
+		res, lines := serve(t, "text/csv; charset=utf-8", "/", body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Len(t, lines, 1)
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		body := `{"destination":"https://example.com","slug":"promo","tags":["sale"]}` + "\n\n" +
+			`{"original_url":"https://example.org","tags":"a;b"}` + "\n" +
+			`not json` + "\n"
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, []models.ImportRecord{
+			{Row: 1, Orig: "https://example.com", Short: "promo", Tags: []string{"sale"}},
+			{Row: 2, Orig: "https://example.org", Tags: []string{"a", "b"}},
+			{Row: 3, Err: errImportBadJSON},
+		}).DoAndReturn(echo)
+
+		res, lines := serve(t, "text/plain", "/?format=jsonl", body)
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, lines, 3)
+		assert.JSONEq(t, `{"row":3,"status":"invalid","reason":"malformed JSON line"}`, lines[2])
+	})
+
+	t.Run("chunked", func(t *testing.T) {
+		var body strings.Builder
+		body.WriteString("url\n")
+		for i := 0; i < importChunkSize+1; i++ {
+			fmt.Fprintf(&body, "https://example.com/%d\n", i)
+		}
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		gomock.InOrder(
+			mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Len(importChunkSize)).DoAndReturn(echo),
+			mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Len(1)).DoAndReturn(echo),
+		)
+
+		res, lines := serve(t, "text/csv", "/", body.String())
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, lines, importChunkSize+1)
+
+		var last importRes
+		require.NoError(t, json.Unmarshal([]byte(lines[importChunkSize]), &last))
+		assert.Equal(t, importChunkSize+1, last.Row)
+	})
+
+	t.Run("empty import", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, lines := serve(t, "application/x-ndjson", "/", "")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, lines)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, "application/xml", "/", "<links/>")
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.StatusCode)
+	})
+
+	t.Run("missing destination column", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, "text/csv", "/", "slug,tags\npromo,sale\n")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest)
+
+		res, _ := serve(t, "text/csv", "/", "url\nhttps://example.com\n")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("service error after report started", func(t *testing.T) {
+		var body strings.Builder
+		body.WriteString("url\n")
+		for i := 0; i < importChunkSize+1; i++ {
+			fmt.Fprintf(&body, "https://example.com/%d\n", i)
+		}
+
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		gomock.InOrder(
+			mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(echo),
+			mServ.EXPECT().ImportURLs(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest),
+		)
+
+		res, lines := serve(t, "text/csv", "/", body.String())
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, lines, importChunkSize+1)
+		assert.JSONEq(t, `{"error":"import aborted"}`, lines[importChunkSize])
+	})
+
+	t.Run("auth error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res, _ := serve(t, "text/csv", "/", "")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestImportDecoders(t *testing.T) {
+	t.Run("csv stray quotes and bad values do not stop import", func(t *testing.T) {
+		dec, err := newCSVImportDecoder(strings.NewReader("\ufeffDestination,Expiry\n\"https://a.example\"x\",\nhttps://b.example,bad\nhttps://c.example,2030-01-01 10:00:00\n"))
+		require.NoError(t, err)
+
+		var recs []models.ImportRecord
+		for {
+			rec, err := dec.next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			recs = append(recs, rec)
+		}
+
+		require.Len(t, recs, 3)
+		assert.NoError(t, recs[0].Err)
+		assert.Equal(t, 1, recs[0].Row)
+		assert.Equal(t, errImportBadExpiry, recs[1].Err)
+		assert.NoError(t, recs[2].Err)
+		assert.Equal(t, 10, recs[2].ExpiresAt.Hour())
+	})
+
+	t.Run("csv without rows", func(t *testing.T) {
+		_, err := newCSVImportDecoder(strings.NewReader(""))
+		assert.ErrorIs(t, err, errImportNoHeader)
+	})
+
+	t.Run("jsonl bad field types", func(t *testing.T) {
+		dec := newJSONLImportDecoder(strings.NewReader(`{"url":1}` + "\n" + `{"url":"https://a.example","tags":{}}` + "\n" + `{"url":"https://a.example","slug":null}`))
+
+		rec, err := dec.next()
+		require.NoError(t, err)
+		assert.Equal(t, errImportBadField, rec.Err)
+
+		rec, err = dec.next()
+		require.NoError(t, err)
+		assert.Equal(t, errImportBadTags, rec.Err)
+
+		rec, err = dec.next()
+		require.NoError(t, err)
+		assert.NoError(t, rec.Err)
+		assert.Equal(t, 3, rec.Row)
+
+		_, err = dec.next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("jsonl line too long", func(t *testing.T) {
+		dec := newJSONLImportDecoder(strings.NewReader(strings.Repeat("x", maxImportLineSize+1)))
+
+		_, err := dec.next()
+		assert.Error(t, err)
+		assert.NotEqual(t, io.EOF, err)
+	})
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// Import record fields.
+const (
+	importFieldOrig   = "orig"
+	importFieldShort  = "short"
+	importFieldTags   = "tags"
+	importFieldExpiry = "expiry"
+)
+
+// importFieldAliases maps column and key names of supported exports to
+// import record fields. Bitly exports name the destination long_url and
+// the short link link, exports of this service use original_url and short_url.
+var importFieldAliases = map[string]string{
+	"destination":  importFieldOrig,
+	"original_url": importFieldOrig,
+	"long_url":     importFieldOrig,
+	"url":          importFieldOrig,
+	"slug":         importFieldShort,
+	"custom_slug":  importFieldShort,
+	"short_url":    importFieldShort,
+	"short":        importFieldShort,
+	"link":         importFieldShort,
+	"bitlink":      importFieldShort,
+	"tags":         importFieldTags,
+	"expires_at":   importFieldExpiry,
+	"expiry":       importFieldExpiry,
+	"expiration":   importFieldExpiry,
+}
+
+// importExpiryLayouts are accepted expiry formats, dates are taken as UTC midnight.
+var importExpiryLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// maxImportLineSize limits a single JSON line of the import.
+const maxImportLineSize = 1 << 20
+
+var (
+	errImportNoHeader      = errors.New("import is empty")
+	errImportNoDestination = errors.New("destination column is missing")
+	errImportBadCSV        = errors.New("malformed CSV row")
+	errImportBadJSON       = errors.New("malformed JSON line")
+	errImportBadExpiry     = errors.New("expiry must be an RFC 3339 timestamp or a date")
+	errImportBadTags       = errors.New("tags must be a string or an array of strings")
+	errImportBadField      = errors.New("destination, slug and expiry must be strings")
+)
+
+// importDecoder reads import records one by one.
+//
+// next returns io.EOF when the import is over. Malformed rows are returned
+// as records with Err set, other errors abort the import.
+type importDecoder interface {
+	next() (models.ImportRecord, error)
+}
+
+// csvImportDecoder reads CSV with a header row naming the columns.
+type csvImportDecoder struct {
+	r       *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVImportDecoder(r io.Reader) (*csvImportDecoder, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errImportNoHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		field, ok := importFieldAliases[name]
+		if !ok {
+			continue
+		}
+		if _, ok := columns[field]; !ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns[importFieldOrig]; !ok {
+		return nil, errImportNoDestination
+	}
+
+	return &csvImportDecoder{
+		r:       cr,
+		columns: columns,
+	}, nil
+}
+
+func (d *csvImportDecoder) next() (models.ImportRecord, error) {
+	record, err := d.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			d.row++
+			return models.ImportRecord{Row: d.row, Err: errImportBadCSV}, nil
+		}
+		return models.ImportRecord{}, err
+	}
+	d.row++
+
+	field := func(name string) string {
+		i, ok := d.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	return newImportRecord(d.row, field(importFieldOrig), field(importFieldShort), splitImportTags(field(importFieldTags)), field(importFieldExpiry)), nil
+}
+
+// jsonlImportDecoder reads JSON lines, one object per link.
+type jsonlImportDecoder struct {
+	s   *bufio.Scanner
+	row int
+}
+
+func newJSONLImportDecoder(r io.Reader) *jsonlImportDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxImportLineSize)
+
+	return &jsonlImportDecoder{
+		s: s,
+	}
+}
+
+func (d *jsonlImportDecoder) next() (models.ImportRecord, error) {
+	var line []byte
+	for len(line) == 0 {
+		if !d.s.Scan() {
+			if err := d.s.Err(); err != nil {
+				return models.ImportRecord{}, err
+			}
+			return models.ImportRecord{}, io.EOF
+		}
+		line = []byte(strings.TrimSpace(d.s.Text()))
+	}
+	d.row++
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(line, &obj); err != nil {
+		return models.ImportRecord{Row: d.row, Err: errImportBadJSON}, nil
+	}
+
+	var orig, short, expiry string
+	var tags []string
+	for key, raw := range obj {
+		var err error
+		switch importFieldAliases[strings.ToLower(key)] {
+		case importFieldOrig:
+			err = decodeImportString(raw, &orig)
+		case importFieldShort:
+			err = decodeImportString(raw, &short)
+		case importFieldExpiry:
+			err = decodeImportString(raw, &expiry)
+		case importFieldTags:
+			tags, err = decodeImportTags(raw)
+		}
+		if err != nil {
+			return models.ImportRecord{Row: d.row, Err: err}, nil
+		}
+	}
+
+	return newImportRecord(d.row, orig, short, tags, expiry), nil
+}
+
+// decodeImportString decodes a string value, null is taken as empty.
+func decodeImportString(raw json.RawMessage, v *string) error {
+	var s *string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return errImportBadField
+	}
+	if s != nil {
+		*v = strings.TrimSpace(*s)
+	}
+	return nil
+}
+
+// decodeImportTags decodes tags given either as an array or a delimited string.
+func decodeImportTags(raw json.RawMessage) ([]string, error) {
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err == nil {
+		return tags, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, errImportBadTags
+	}
+	return splitImportTags(s), nil
+}
+
+func newImportRecord(row int, orig, short string, tags []string, expiry string) models.ImportRecord {
+	rec := models.ImportRecord{
+		Row:   row,
+		Orig:  models.OrigURL(orig),
+		Short: importSlug(short),
+		Tags:  tags,
+	}
+
+	if expiry != "" {
+		rec.ExpiresAt, rec.Err = parseImportExpiry(expiry)
+	}
+
+	return rec
+}
+
+// importSlug extracts the slug from a full short link such as bit.ly/abc.
+func importSlug(short string) models.ShortURL {
+	short = strings.TrimRight(short, "/")
+	if i := strings.LastIndexByte(short, '/'); i >= 0 {
+		short = short[i+1:]
+	}
+	return models.ShortURL(short)
+}
+
+// splitImportTags splits tags delimited by commas, semicolons or pipes.
+func splitImportTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|'
+	})
+}
+
+func parseImportExpiry(s string) (*time.Time, error) {
+	for _, layout := range importExpiryLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return &t, nil
+		}
+	}
+	return nil, errImportBadExpiry
+}
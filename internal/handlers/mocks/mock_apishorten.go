@@ -35,6 +35,21 @@ func (m *MockapiShortenServicer) EXPECT() *MockapiShortenServicerMockRecorder {
 	return m.recorder
 }
 
+// ShortenScheduledURL mocks base method.
+func (m *MockapiShortenServicer) ShortenScheduledURL(arg0 context.Context, arg1 models.UserID, arg2 models.OrigURL, arg3 models.Schedule) (*models.URLPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortenScheduledURL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.URLPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShortenScheduledURL indicates an expected call of ShortenScheduledURL.
+func (mr *MockapiShortenServicerMockRecorder) ShortenScheduledURL(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortenScheduledURL", reflect.TypeOf((*MockapiShortenServicer)(nil).ShortenScheduledURL), arg0, arg1, arg2, arg3)
+}
+
 // ShortenURL mocks base method.
 func (m *MockapiShortenServicer) ShortenURL(arg0 context.Context, arg1 models.UserID, arg2 models.OrigURL) (*models.URLPair, error) {
 	m.ctrl.T.Helper()
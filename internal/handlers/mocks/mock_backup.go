@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backup.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockbackupServicer is a mock of backupServicer interface.
+type MockbackupServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockbackupServicerMockRecorder
+}
+
+// MockbackupServicerMockRecorder is the mock recorder for MockbackupServicer.
+type MockbackupServicerMockRecorder struct {
+	mock *MockbackupServicer
+}
+
+// NewMockbackupServicer creates a new mock instance.
+func NewMockbackupServicer(ctrl *gomock.Controller) *MockbackupServicer {
+	mock := &MockbackupServicer{ctrl: ctrl}
+	mock.recorder = &MockbackupServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbackupServicer) EXPECT() *MockbackupServicerMockRecorder {
+	return m.recorder
+}
+
+// WriteBackup mocks base method.
+func (m *MockbackupServicer) WriteBackup(arg0 context.Context, arg1 io.Writer) (*models.BackupManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBackup", arg0, arg1)
+	ret0, _ := ret[0].(*models.BackupManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteBackup indicates an expected call of WriteBackup.
+func (mr *MockbackupServicerMockRecorder) WriteBackup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBackup", reflect.TypeOf((*MockbackupServicer)(nil).WriteBackup), arg0, arg1)
+}
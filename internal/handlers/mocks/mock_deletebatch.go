@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/rycln/shorturl/internal/models"
@@ -36,9 +37,11 @@ func (m *MockdeletionProcessor) EXPECT() *MockdeletionProcessorMockRecorder {
 }
 
 // AddURLsIntoDeletionQueue mocks base method.
-func (m *MockdeletionProcessor) AddURLsIntoDeletionQueue(arg0 models.UserID, arg1 []models.ShortURL) {
+func (m *MockdeletionProcessor) AddURLsIntoDeletionQueue(arg0 models.UserID, arg1 []models.ShortURL) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddURLsIntoDeletionQueue", arg0, arg1)
+	ret := m.ctrl.Call(m, "AddURLsIntoDeletionQueue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // AddURLsIntoDeletionQueue indicates an expected call of AddURLsIntoDeletionQueue.
@@ -84,3 +87,119 @@ func (mr *MockdeleteBatchAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockdeleteBatchAuthServicer)(nil).GetUserIDFromCtx), arg0)
 }
+
+// MockerrDeleteBatchQueueFull is a mock of errDeleteBatchQueueFull interface.
+type MockerrDeleteBatchQueueFull struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrDeleteBatchQueueFullMockRecorder
+}
+
+// MockerrDeleteBatchQueueFullMockRecorder is the mock recorder for MockerrDeleteBatchQueueFull.
+type MockerrDeleteBatchQueueFullMockRecorder struct {
+	mock *MockerrDeleteBatchQueueFull
+}
+
+// NewMockerrDeleteBatchQueueFull creates a new mock instance.
+func NewMockerrDeleteBatchQueueFull(ctrl *gomock.Controller) *MockerrDeleteBatchQueueFull {
+	mock := &MockerrDeleteBatchQueueFull{ctrl: ctrl}
+	mock.recorder = &MockerrDeleteBatchQueueFullMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrDeleteBatchQueueFull) EXPECT() *MockerrDeleteBatchQueueFullMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrDeleteBatchQueueFull) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrDeleteBatchQueueFullMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrDeleteBatchQueueFull)(nil).Error))
+}
+
+// IsErrQueueFull mocks base method.
+func (m *MockerrDeleteBatchQueueFull) IsErrQueueFull() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrQueueFull")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrQueueFull indicates an expected call of IsErrQueueFull.
+func (mr *MockerrDeleteBatchQueueFullMockRecorder) IsErrQueueFull() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrQueueFull", reflect.TypeOf((*MockerrDeleteBatchQueueFull)(nil).IsErrQueueFull))
+}
+
+// RetryAfter mocks base method.
+func (m *MockerrDeleteBatchQueueFull) RetryAfter() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryAfter")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RetryAfter indicates an expected call of RetryAfter.
+func (mr *MockerrDeleteBatchQueueFullMockRecorder) RetryAfter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryAfter", reflect.TypeOf((*MockerrDeleteBatchQueueFull)(nil).RetryAfter))
+}
+
+// MockerrDeleteBatchTooLarge is a mock of errDeleteBatchTooLarge interface.
+type MockerrDeleteBatchTooLarge struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrDeleteBatchTooLargeMockRecorder
+}
+
+// MockerrDeleteBatchTooLargeMockRecorder is the mock recorder for MockerrDeleteBatchTooLarge.
+type MockerrDeleteBatchTooLargeMockRecorder struct {
+	mock *MockerrDeleteBatchTooLarge
+}
+
+// NewMockerrDeleteBatchTooLarge creates a new mock instance.
+func NewMockerrDeleteBatchTooLarge(ctrl *gomock.Controller) *MockerrDeleteBatchTooLarge {
+	mock := &MockerrDeleteBatchTooLarge{ctrl: ctrl}
+	mock.recorder = &MockerrDeleteBatchTooLargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrDeleteBatchTooLarge) EXPECT() *MockerrDeleteBatchTooLargeMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrDeleteBatchTooLarge) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrDeleteBatchTooLargeMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrDeleteBatchTooLarge)(nil).Error))
+}
+
+// IsErrTooLarge mocks base method.
+func (m *MockerrDeleteBatchTooLarge) IsErrTooLarge() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTooLarge")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTooLarge indicates an expected call of IsErrTooLarge.
+func (mr *MockerrDeleteBatchTooLargeMockRecorder) IsErrTooLarge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTooLarge", reflect.TypeOf((*MockerrDeleteBatchTooLarge)(nil).IsErrTooLarge))
+}
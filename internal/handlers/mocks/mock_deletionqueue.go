@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deletionqueue.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockdeletionQueueStater is a mock of deletionQueueStater interface.
+type MockdeletionQueueStater struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeletionQueueStaterMockRecorder
+}
+
+// MockdeletionQueueStaterMockRecorder is the mock recorder for MockdeletionQueueStater.
+type MockdeletionQueueStaterMockRecorder struct {
+	mock *MockdeletionQueueStater
+}
+
+// NewMockdeletionQueueStater creates a new mock instance.
+func NewMockdeletionQueueStater(ctrl *gomock.Controller) *MockdeletionQueueStater {
+	mock := &MockdeletionQueueStater{ctrl: ctrl}
+	mock.recorder = &MockdeletionQueueStaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeletionQueueStater) EXPECT() *MockdeletionQueueStaterMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockdeletionQueueStater) Stats() models.DeletionQueueStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(models.DeletionQueueStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockdeletionQueueStaterMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockdeletionQueueStater)(nil).Stats))
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: destinationstats.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockdestinationStatsServicer is a mock of destinationStatsServicer interface.
+type MockdestinationStatsServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdestinationStatsServicerMockRecorder
+}
+
+// MockdestinationStatsServicerMockRecorder is the mock recorder for MockdestinationStatsServicer.
+type MockdestinationStatsServicerMockRecorder struct {
+	mock *MockdestinationStatsServicer
+}
+
+// NewMockdestinationStatsServicer creates a new mock instance.
+func NewMockdestinationStatsServicer(ctrl *gomock.Controller) *MockdestinationStatsServicer {
+	mock := &MockdestinationStatsServicer{ctrl: ctrl}
+	mock.recorder = &MockdestinationStatsServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdestinationStatsServicer) EXPECT() *MockdestinationStatsServicerMockRecorder {
+	return m.recorder
+}
+
+// GetDestinationStats mocks base method.
+func (m *MockdestinationStatsServicer) GetDestinationStats(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL) ([]models.VariantStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDestinationStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.VariantStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDestinationStats indicates an expected call of GetDestinationStats.
+func (mr *MockdestinationStatsServicerMockRecorder) GetDestinationStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDestinationStats", reflect.TypeOf((*MockdestinationStatsServicer)(nil).GetDestinationStats), arg0, arg1, arg2)
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MockdestinationStatsServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MockdestinationStatsServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MockdestinationStatsServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// MockdestinationStatsAuthServicer is a mock of destinationStatsAuthServicer interface.
+type MockdestinationStatsAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockdestinationStatsAuthServicerMockRecorder
+}
+
+// MockdestinationStatsAuthServicerMockRecorder is the mock recorder for MockdestinationStatsAuthServicer.
+type MockdestinationStatsAuthServicerMockRecorder struct {
+	mock *MockdestinationStatsAuthServicer
+}
+
+// NewMockdestinationStatsAuthServicer creates a new mock instance.
+func NewMockdestinationStatsAuthServicer(ctrl *gomock.Controller) *MockdestinationStatsAuthServicer {
+	mock := &MockdestinationStatsAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockdestinationStatsAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdestinationStatsAuthServicer) EXPECT() *MockdestinationStatsAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockdestinationStatsAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockdestinationStatsAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockdestinationStatsAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrDestinationStatsNotExist is a mock of errDestinationStatsNotExist interface.
+type MockerrDestinationStatsNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrDestinationStatsNotExistMockRecorder
+}
+
+// MockerrDestinationStatsNotExistMockRecorder is the mock recorder for MockerrDestinationStatsNotExist.
+type MockerrDestinationStatsNotExistMockRecorder struct {
+	mock *MockerrDestinationStatsNotExist
+}
+
+// NewMockerrDestinationStatsNotExist creates a new mock instance.
+func NewMockerrDestinationStatsNotExist(ctrl *gomock.Controller) *MockerrDestinationStatsNotExist {
+	mock := &MockerrDestinationStatsNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrDestinationStatsNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrDestinationStatsNotExist) EXPECT() *MockerrDestinationStatsNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrDestinationStatsNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrDestinationStatsNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrDestinationStatsNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrDestinationStatsNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrDestinationStatsNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrDestinationStatsNotExist)(nil).IsErrNotExist))
+}
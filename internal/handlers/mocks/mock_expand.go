@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: expand.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockexpandServicer is a mock of expandServicer interface.
+type MockexpandServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexpandServicerMockRecorder
+}
+
+// MockexpandServicerMockRecorder is the mock recorder for MockexpandServicer.
+type MockexpandServicerMockRecorder struct {
+	mock *MockexpandServicer
+}
+
+// NewMockexpandServicer creates a new mock instance.
+func NewMockexpandServicer(ctrl *gomock.Controller) *MockexpandServicer {
+	mock := &MockexpandServicer{ctrl: ctrl}
+	mock.recorder = &MockexpandServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexpandServicer) EXPECT() *MockexpandServicerMockRecorder {
+	return m.recorder
+}
+
+// DescribeURL mocks base method.
+func (m *MockexpandServicer) DescribeURL(arg0 context.Context, arg1 models.ShortURL) (*models.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeURL", arg0, arg1)
+	ret0, _ := ret[0].(*models.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeURL indicates an expected call of DescribeURL.
+func (mr *MockexpandServicerMockRecorder) DescribeURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeURL", reflect.TypeOf((*MockexpandServicer)(nil).DescribeURL), arg0, arg1)
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MockexpandServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MockexpandServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MockexpandServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// MockexpandAuthServicer is a mock of expandAuthServicer interface.
+type MockexpandAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexpandAuthServicerMockRecorder
+}
+
+// MockexpandAuthServicerMockRecorder is the mock recorder for MockexpandAuthServicer.
+type MockexpandAuthServicerMockRecorder struct {
+	mock *MockexpandAuthServicer
+}
+
+// NewMockexpandAuthServicer creates a new mock instance.
+func NewMockexpandAuthServicer(ctrl *gomock.Controller) *MockexpandAuthServicer {
+	mock := &MockexpandAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockexpandAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexpandAuthServicer) EXPECT() *MockexpandAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockexpandAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockexpandAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockexpandAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrExpandNotExist is a mock of errExpandNotExist interface.
+type MockerrExpandNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrExpandNotExistMockRecorder
+}
+
+// MockerrExpandNotExistMockRecorder is the mock recorder for MockerrExpandNotExist.
+type MockerrExpandNotExistMockRecorder struct {
+	mock *MockerrExpandNotExist
+}
+
+// NewMockerrExpandNotExist creates a new mock instance.
+func NewMockerrExpandNotExist(ctrl *gomock.Controller) *MockerrExpandNotExist {
+	mock := &MockerrExpandNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrExpandNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrExpandNotExist) EXPECT() *MockerrExpandNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrExpandNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrExpandNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrExpandNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrExpandNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrExpandNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrExpandNotExist)(nil).IsErrNotExist))
+}
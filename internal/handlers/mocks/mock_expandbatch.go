@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: expandbatch.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockexpandBatchServicer is a mock of expandBatchServicer interface.
+type MockexpandBatchServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexpandBatchServicerMockRecorder
+}
+
+// MockexpandBatchServicerMockRecorder is the mock recorder for MockexpandBatchServicer.
+type MockexpandBatchServicerMockRecorder struct {
+	mock *MockexpandBatchServicer
+}
+
+// NewMockexpandBatchServicer creates a new mock instance.
+func NewMockexpandBatchServicer(ctrl *gomock.Controller) *MockexpandBatchServicer {
+	mock := &MockexpandBatchServicer{ctrl: ctrl}
+	mock.recorder = &MockexpandBatchServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexpandBatchServicer) EXPECT() *MockexpandBatchServicerMockRecorder {
+	return m.recorder
+}
+
+// BatchDescribeURL mocks base method.
+func (m *MockexpandBatchServicer) BatchDescribeURL(arg0 context.Context, arg1 []models.ShortURL) ([]models.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDescribeURL", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDescribeURL indicates an expected call of BatchDescribeURL.
+func (mr *MockexpandBatchServicerMockRecorder) BatchDescribeURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDescribeURL", reflect.TypeOf((*MockexpandBatchServicer)(nil).BatchDescribeURL), arg0, arg1)
+}
+
+// MockexpandBatchAuthServicer is a mock of expandBatchAuthServicer interface.
+type MockexpandBatchAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexpandBatchAuthServicerMockRecorder
+}
+
+// MockexpandBatchAuthServicerMockRecorder is the mock recorder for MockexpandBatchAuthServicer.
+type MockexpandBatchAuthServicerMockRecorder struct {
+	mock *MockexpandBatchAuthServicer
+}
+
+// NewMockexpandBatchAuthServicer creates a new mock instance.
+func NewMockexpandBatchAuthServicer(ctrl *gomock.Controller) *MockexpandBatchAuthServicer {
+	mock := &MockexpandBatchAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockexpandBatchAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexpandBatchAuthServicer) EXPECT() *MockexpandBatchAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockexpandBatchAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockexpandBatchAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockexpandBatchAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
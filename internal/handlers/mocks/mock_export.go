@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockexportServicer is a mock of exportServicer interface.
+type MockexportServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexportServicerMockRecorder
+}
+
+// MockexportServicerMockRecorder is the mock recorder for MockexportServicer.
+type MockexportServicerMockRecorder struct {
+	mock *MockexportServicer
+}
+
+// NewMockexportServicer creates a new mock instance.
+func NewMockexportServicer(ctrl *gomock.Controller) *MockexportServicer {
+	mock := &MockexportServicer{ctrl: ctrl}
+	mock.recorder = &MockexportServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexportServicer) EXPECT() *MockexportServicerMockRecorder {
+	return m.recorder
+}
+
+// ExportUserURLs mocks base method.
+func (m *MockexportServicer) ExportUserURLs(arg0 context.Context, arg1 models.UserID, arg2 func(models.URLInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUserURLs indicates an expected call of ExportUserURLs.
+func (mr *MockexportServicerMockRecorder) ExportUserURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserURLs", reflect.TypeOf((*MockexportServicer)(nil).ExportUserURLs), arg0, arg1, arg2)
+}
+
+// MockexportAuthServicer is a mock of exportAuthServicer interface.
+type MockexportAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockexportAuthServicerMockRecorder
+}
+
+// MockexportAuthServicerMockRecorder is the mock recorder for MockexportAuthServicer.
+type MockexportAuthServicerMockRecorder struct {
+	mock *MockexportAuthServicer
+}
+
+// NewMockexportAuthServicer creates a new mock instance.
+func NewMockexportAuthServicer(ctrl *gomock.Controller) *MockexportAuthServicer {
+	mock := &MockexportAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockexportAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexportAuthServicer) EXPECT() *MockexportAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockexportAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockexportAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockexportAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
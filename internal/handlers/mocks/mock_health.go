@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	health "github.com/rycln/shorturl/internal/health"
+)
+
+// MockreadinessChecker is a mock of readinessChecker interface.
+type MockreadinessChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockreadinessCheckerMockRecorder
+}
+
+// MockreadinessCheckerMockRecorder is the mock recorder for MockreadinessChecker.
+type MockreadinessCheckerMockRecorder struct {
+	mock *MockreadinessChecker
+}
+
+// NewMockreadinessChecker creates a new mock instance.
+func NewMockreadinessChecker(ctrl *gomock.Controller) *MockreadinessChecker {
+	mock := &MockreadinessChecker{ctrl: ctrl}
+	mock.recorder = &MockreadinessCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreadinessChecker) EXPECT() *MockreadinessCheckerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockreadinessChecker) Check(arg0 context.Context) *health.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0)
+	ret0, _ := ret[0].(*health.Report)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockreadinessCheckerMockRecorder) Check(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockreadinessChecker)(nil).Check), arg0)
+}
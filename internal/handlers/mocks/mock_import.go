@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockimportServicer is a mock of importServicer interface.
+type MockimportServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockimportServicerMockRecorder
+}
+
+// MockimportServicerMockRecorder is the mock recorder for MockimportServicer.
+type MockimportServicerMockRecorder struct {
+	mock *MockimportServicer
+}
+
+// NewMockimportServicer creates a new mock instance.
+func NewMockimportServicer(ctrl *gomock.Controller) *MockimportServicer {
+	mock := &MockimportServicer{ctrl: ctrl}
+	mock.recorder = &MockimportServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockimportServicer) EXPECT() *MockimportServicerMockRecorder {
+	return m.recorder
+}
+
+// ImportURLs mocks base method.
+func (m *MockimportServicer) ImportURLs(arg0 context.Context, arg1 models.UserID, arg2 []models.ImportRecord) ([]models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportURLs indicates an expected call of ImportURLs.
+func (mr *MockimportServicerMockRecorder) ImportURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportURLs", reflect.TypeOf((*MockimportServicer)(nil).ImportURLs), arg0, arg1, arg2)
+}
+
+// MockimportAuthServicer is a mock of importAuthServicer interface.
+type MockimportAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockimportAuthServicerMockRecorder
+}
+
+// MockimportAuthServicerMockRecorder is the mock recorder for MockimportAuthServicer.
+type MockimportAuthServicerMockRecorder struct {
+	mock *MockimportAuthServicer
+}
+
+// NewMockimportAuthServicer creates a new mock instance.
+func NewMockimportAuthServicer(ctrl *gomock.Controller) *MockimportAuthServicer {
+	mock := &MockimportAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockimportAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockimportAuthServicer) EXPECT() *MockimportAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockimportAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockimportAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockimportAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: qr.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockqrServicer is a mock of qrServicer interface.
+type MockqrServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockqrServicerMockRecorder
+}
+
+// MockqrServicerMockRecorder is the mock recorder for MockqrServicer.
+type MockqrServicerMockRecorder struct {
+	mock *MockqrServicer
+}
+
+// NewMockqrServicer creates a new mock instance.
+func NewMockqrServicer(ctrl *gomock.Controller) *MockqrServicer {
+	mock := &MockqrServicer{ctrl: ctrl}
+	mock.recorder = &MockqrServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqrServicer) EXPECT() *MockqrServicerMockRecorder {
+	return m.recorder
+}
+
+// DescribeURL mocks base method.
+func (m *MockqrServicer) DescribeURL(arg0 context.Context, arg1 models.ShortURL) (*models.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeURL", arg0, arg1)
+	ret0, _ := ret[0].(*models.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeURL indicates an expected call of DescribeURL.
+func (mr *MockqrServicerMockRecorder) DescribeURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeURL", reflect.TypeOf((*MockqrServicer)(nil).DescribeURL), arg0, arg1)
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MockqrServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MockqrServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MockqrServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// MockqrAuthServicer is a mock of qrAuthServicer interface.
+type MockqrAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockqrAuthServicerMockRecorder
+}
+
+// MockqrAuthServicerMockRecorder is the mock recorder for MockqrAuthServicer.
+type MockqrAuthServicerMockRecorder struct {
+	mock *MockqrAuthServicer
+}
+
+// NewMockqrAuthServicer creates a new mock instance.
+func NewMockqrAuthServicer(ctrl *gomock.Controller) *MockqrAuthServicer {
+	mock := &MockqrAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockqrAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqrAuthServicer) EXPECT() *MockqrAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockqrAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockqrAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockqrAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrQRNotExist is a mock of errQRNotExist interface.
+type MockerrQRNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrQRNotExistMockRecorder
+}
+
+// MockerrQRNotExistMockRecorder is the mock recorder for MockerrQRNotExist.
+type MockerrQRNotExistMockRecorder struct {
+	mock *MockerrQRNotExist
+}
+
+// NewMockerrQRNotExist creates a new mock instance.
+func NewMockerrQRNotExist(ctrl *gomock.Controller) *MockerrQRNotExist {
+	mock := &MockerrQRNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrQRNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrQRNotExist) EXPECT() *MockerrQRNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrQRNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrQRNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrQRNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrQRNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrQRNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrQRNotExist)(nil).IsErrNotExist))
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: qrbatch.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockqrBatchServicer is a mock of qrBatchServicer interface.
+type MockqrBatchServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockqrBatchServicerMockRecorder
+}
+
+// MockqrBatchServicerMockRecorder is the mock recorder for MockqrBatchServicer.
+type MockqrBatchServicerMockRecorder struct {
+	mock *MockqrBatchServicer
+}
+
+// NewMockqrBatchServicer creates a new mock instance.
+func NewMockqrBatchServicer(ctrl *gomock.Controller) *MockqrBatchServicer {
+	mock := &MockqrBatchServicer{ctrl: ctrl}
+	mock.recorder = &MockqrBatchServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqrBatchServicer) EXPECT() *MockqrBatchServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserURLs mocks base method.
+func (m *MockqrBatchServicer) GetUserURLs(arg0 context.Context, arg1 models.UserID) ([]models.URLPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserURLs", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserURLs indicates an expected call of GetUserURLs.
+func (mr *MockqrBatchServicerMockRecorder) GetUserURLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserURLs", reflect.TypeOf((*MockqrBatchServicer)(nil).GetUserURLs), arg0, arg1)
+}
+
+// MockqrBatchAuthServicer is a mock of qrBatchAuthServicer interface.
+type MockqrBatchAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockqrBatchAuthServicerMockRecorder
+}
+
+// MockqrBatchAuthServicerMockRecorder is the mock recorder for MockqrBatchAuthServicer.
+type MockqrBatchAuthServicerMockRecorder struct {
+	mock *MockqrBatchAuthServicer
+}
+
+// NewMockqrBatchAuthServicer creates a new mock instance.
+func NewMockqrBatchAuthServicer(ctrl *gomock.Controller) *MockqrBatchAuthServicer {
+	mock := &MockqrBatchAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockqrBatchAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockqrBatchAuthServicer) EXPECT() *MockqrBatchAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockqrBatchAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockqrBatchAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockqrBatchAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrQRBatchNotExist is a mock of errQRBatchNotExist interface.
+type MockerrQRBatchNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrQRBatchNotExistMockRecorder
+}
+
+// MockerrQRBatchNotExistMockRecorder is the mock recorder for MockerrQRBatchNotExist.
+type MockerrQRBatchNotExistMockRecorder struct {
+	mock *MockerrQRBatchNotExist
+}
+
+// NewMockerrQRBatchNotExist creates a new mock instance.
+func NewMockerrQRBatchNotExist(ctrl *gomock.Controller) *MockerrQRBatchNotExist {
+	mock := &MockerrQRBatchNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrQRBatchNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrQRBatchNotExist) EXPECT() *MockerrQRBatchNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrQRBatchNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrQRBatchNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrQRBatchNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrQRBatchNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrQRBatchNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrQRBatchNotExist)(nil).IsErrNotExist))
+}
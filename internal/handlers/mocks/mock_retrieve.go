@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/rycln/shorturl/internal/models"
@@ -115,3 +116,286 @@ func (mr *MockerrRetrieveDeletedURLMockRecorder) IsErrDeletedURL() *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrDeletedURL", reflect.TypeOf((*MockerrRetrieveDeletedURL)(nil).IsErrDeletedURL))
 }
+
+// MockerrRetrieveInvalidPath is a mock of errRetrieveInvalidPath interface.
+type MockerrRetrieveInvalidPath struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrRetrieveInvalidPathMockRecorder
+}
+
+// MockerrRetrieveInvalidPathMockRecorder is the mock recorder for MockerrRetrieveInvalidPath.
+type MockerrRetrieveInvalidPathMockRecorder struct {
+	mock *MockerrRetrieveInvalidPath
+}
+
+// NewMockerrRetrieveInvalidPath creates a new mock instance.
+func NewMockerrRetrieveInvalidPath(ctrl *gomock.Controller) *MockerrRetrieveInvalidPath {
+	mock := &MockerrRetrieveInvalidPath{ctrl: ctrl}
+	mock.recorder = &MockerrRetrieveInvalidPathMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrRetrieveInvalidPath) EXPECT() *MockerrRetrieveInvalidPathMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrRetrieveInvalidPath) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrRetrieveInvalidPathMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrRetrieveInvalidPath)(nil).Error))
+}
+
+// IsErrInvalidPath mocks base method.
+func (m *MockerrRetrieveInvalidPath) IsErrInvalidPath() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrInvalidPath")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrInvalidPath indicates an expected call of IsErrInvalidPath.
+func (mr *MockerrRetrieveInvalidPathMockRecorder) IsErrInvalidPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrInvalidPath", reflect.TypeOf((*MockerrRetrieveInvalidPath)(nil).IsErrInvalidPath))
+}
+
+// MockerrRetrievePasswordRequired is a mock of errRetrievePasswordRequired interface.
+type MockerrRetrievePasswordRequired struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrRetrievePasswordRequiredMockRecorder
+}
+
+// MockerrRetrievePasswordRequiredMockRecorder is the mock recorder for MockerrRetrievePasswordRequired.
+type MockerrRetrievePasswordRequiredMockRecorder struct {
+	mock *MockerrRetrievePasswordRequired
+}
+
+// NewMockerrRetrievePasswordRequired creates a new mock instance.
+func NewMockerrRetrievePasswordRequired(ctrl *gomock.Controller) *MockerrRetrievePasswordRequired {
+	mock := &MockerrRetrievePasswordRequired{ctrl: ctrl}
+	mock.recorder = &MockerrRetrievePasswordRequiredMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrRetrievePasswordRequired) EXPECT() *MockerrRetrievePasswordRequiredMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrRetrievePasswordRequired) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrRetrievePasswordRequiredMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrRetrievePasswordRequired)(nil).Error))
+}
+
+// IsErrPasswordRequired mocks base method.
+func (m *MockerrRetrievePasswordRequired) IsErrPasswordRequired() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrPasswordRequired")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrPasswordRequired indicates an expected call of IsErrPasswordRequired.
+func (mr *MockerrRetrievePasswordRequiredMockRecorder) IsErrPasswordRequired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrPasswordRequired", reflect.TypeOf((*MockerrRetrievePasswordRequired)(nil).IsErrPasswordRequired))
+}
+
+// MockerrRetrieveWrongPassword is a mock of errRetrieveWrongPassword interface.
+type MockerrRetrieveWrongPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrRetrieveWrongPasswordMockRecorder
+}
+
+// MockerrRetrieveWrongPasswordMockRecorder is the mock recorder for MockerrRetrieveWrongPassword.
+type MockerrRetrieveWrongPasswordMockRecorder struct {
+	mock *MockerrRetrieveWrongPassword
+}
+
+// NewMockerrRetrieveWrongPassword creates a new mock instance.
+func NewMockerrRetrieveWrongPassword(ctrl *gomock.Controller) *MockerrRetrieveWrongPassword {
+	mock := &MockerrRetrieveWrongPassword{ctrl: ctrl}
+	mock.recorder = &MockerrRetrieveWrongPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrRetrieveWrongPassword) EXPECT() *MockerrRetrieveWrongPasswordMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrRetrieveWrongPassword) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrRetrieveWrongPasswordMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrRetrieveWrongPassword)(nil).Error))
+}
+
+// IsErrWrongPassword mocks base method.
+func (m *MockerrRetrieveWrongPassword) IsErrWrongPassword() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongPassword")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongPassword indicates an expected call of IsErrWrongPassword.
+func (mr *MockerrRetrieveWrongPasswordMockRecorder) IsErrWrongPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongPassword", reflect.TypeOf((*MockerrRetrieveWrongPassword)(nil).IsErrWrongPassword))
+}
+
+// MockerrRetrieveNotActive is a mock of errRetrieveNotActive interface.
+type MockerrRetrieveNotActive struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrRetrieveNotActiveMockRecorder
+}
+
+// MockerrRetrieveNotActiveMockRecorder is the mock recorder for MockerrRetrieveNotActive.
+type MockerrRetrieveNotActiveMockRecorder struct {
+	mock *MockerrRetrieveNotActive
+}
+
+// NewMockerrRetrieveNotActive creates a new mock instance.
+func NewMockerrRetrieveNotActive(ctrl *gomock.Controller) *MockerrRetrieveNotActive {
+	mock := &MockerrRetrieveNotActive{ctrl: ctrl}
+	mock.recorder = &MockerrRetrieveNotActiveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrRetrieveNotActive) EXPECT() *MockerrRetrieveNotActiveMockRecorder {
+	return m.recorder
+}
+
+// ActiveFrom mocks base method.
+func (m *MockerrRetrieveNotActive) ActiveFrom() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveFrom")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ActiveFrom indicates an expected call of ActiveFrom.
+func (mr *MockerrRetrieveNotActiveMockRecorder) ActiveFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveFrom", reflect.TypeOf((*MockerrRetrieveNotActive)(nil).ActiveFrom))
+}
+
+// Error mocks base method.
+func (m *MockerrRetrieveNotActive) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrRetrieveNotActiveMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrRetrieveNotActive)(nil).Error))
+}
+
+// IsErrNotActive mocks base method.
+func (m *MockerrRetrieveNotActive) IsErrNotActive() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotActive")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotActive indicates an expected call of IsErrNotActive.
+func (mr *MockerrRetrieveNotActiveMockRecorder) IsErrNotActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotActive", reflect.TypeOf((*MockerrRetrieveNotActive)(nil).IsErrNotActive))
+}
+
+// MockerrRetrieveTooManyAttempts is a mock of errRetrieveTooManyAttempts interface.
+type MockerrRetrieveTooManyAttempts struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrRetrieveTooManyAttemptsMockRecorder
+}
+
+// MockerrRetrieveTooManyAttemptsMockRecorder is the mock recorder for MockerrRetrieveTooManyAttempts.
+type MockerrRetrieveTooManyAttemptsMockRecorder struct {
+	mock *MockerrRetrieveTooManyAttempts
+}
+
+// NewMockerrRetrieveTooManyAttempts creates a new mock instance.
+func NewMockerrRetrieveTooManyAttempts(ctrl *gomock.Controller) *MockerrRetrieveTooManyAttempts {
+	mock := &MockerrRetrieveTooManyAttempts{ctrl: ctrl}
+	mock.recorder = &MockerrRetrieveTooManyAttemptsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrRetrieveTooManyAttempts) EXPECT() *MockerrRetrieveTooManyAttemptsMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrRetrieveTooManyAttempts) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrRetrieveTooManyAttemptsMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrRetrieveTooManyAttempts)(nil).Error))
+}
+
+// IsErrTooManyAttempts mocks base method.
+func (m *MockerrRetrieveTooManyAttempts) IsErrTooManyAttempts() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTooManyAttempts")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTooManyAttempts indicates an expected call of IsErrTooManyAttempts.
+func (mr *MockerrRetrieveTooManyAttemptsMockRecorder) IsErrTooManyAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTooManyAttempts", reflect.TypeOf((*MockerrRetrieveTooManyAttempts)(nil).IsErrTooManyAttempts))
+}
+
+// RetryAfter mocks base method.
+func (m *MockerrRetrieveTooManyAttempts) RetryAfter() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryAfter")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RetryAfter indicates an expected call of RetryAfter.
+func (mr *MockerrRetrieveTooManyAttemptsMockRecorder) RetryAfter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryAfter", reflect.TypeOf((*MockerrRetrieveTooManyAttempts)(nil).RetryAfter))
+}
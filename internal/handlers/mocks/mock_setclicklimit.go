@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: setclicklimit.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MocksetClickLimitServicer is a mock of setClickLimitServicer interface.
+type MocksetClickLimitServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetClickLimitServicerMockRecorder
+}
+
+// MocksetClickLimitServicerMockRecorder is the mock recorder for MocksetClickLimitServicer.
+type MocksetClickLimitServicerMockRecorder struct {
+	mock *MocksetClickLimitServicer
+}
+
+// NewMocksetClickLimitServicer creates a new mock instance.
+func NewMocksetClickLimitServicer(ctrl *gomock.Controller) *MocksetClickLimitServicer {
+	mock := &MocksetClickLimitServicer{ctrl: ctrl}
+	mock.recorder = &MocksetClickLimitServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetClickLimitServicer) EXPECT() *MocksetClickLimitServicerMockRecorder {
+	return m.recorder
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MocksetClickLimitServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MocksetClickLimitServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MocksetClickLimitServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// SetClickLimit mocks base method.
+func (m *MocksetClickLimitServicer) SetClickLimit(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClickLimit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClickLimit indicates an expected call of SetClickLimit.
+func (mr *MocksetClickLimitServicerMockRecorder) SetClickLimit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClickLimit", reflect.TypeOf((*MocksetClickLimitServicer)(nil).SetClickLimit), arg0, arg1, arg2, arg3)
+}
+
+// MocksetClickLimitAuthServicer is a mock of setClickLimitAuthServicer interface.
+type MocksetClickLimitAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetClickLimitAuthServicerMockRecorder
+}
+
+// MocksetClickLimitAuthServicerMockRecorder is the mock recorder for MocksetClickLimitAuthServicer.
+type MocksetClickLimitAuthServicerMockRecorder struct {
+	mock *MocksetClickLimitAuthServicer
+}
+
+// NewMocksetClickLimitAuthServicer creates a new mock instance.
+func NewMocksetClickLimitAuthServicer(ctrl *gomock.Controller) *MocksetClickLimitAuthServicer {
+	mock := &MocksetClickLimitAuthServicer{ctrl: ctrl}
+	mock.recorder = &MocksetClickLimitAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetClickLimitAuthServicer) EXPECT() *MocksetClickLimitAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MocksetClickLimitAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MocksetClickLimitAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MocksetClickLimitAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrSetClickLimitNotExist is a mock of errSetClickLimitNotExist interface.
+type MockerrSetClickLimitNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSetClickLimitNotExistMockRecorder
+}
+
+// MockerrSetClickLimitNotExistMockRecorder is the mock recorder for MockerrSetClickLimitNotExist.
+type MockerrSetClickLimitNotExistMockRecorder struct {
+	mock *MockerrSetClickLimitNotExist
+}
+
+// NewMockerrSetClickLimitNotExist creates a new mock instance.
+func NewMockerrSetClickLimitNotExist(ctrl *gomock.Controller) *MockerrSetClickLimitNotExist {
+	mock := &MockerrSetClickLimitNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrSetClickLimitNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSetClickLimitNotExist) EXPECT() *MockerrSetClickLimitNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSetClickLimitNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSetClickLimitNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSetClickLimitNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrSetClickLimitNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrSetClickLimitNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrSetClickLimitNotExist)(nil).IsErrNotExist))
+}
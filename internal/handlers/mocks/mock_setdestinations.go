@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: setdestinations.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MocksetDestinationsServicer is a mock of setDestinationsServicer interface.
+type MocksetDestinationsServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetDestinationsServicerMockRecorder
+}
+
+// MocksetDestinationsServicerMockRecorder is the mock recorder for MocksetDestinationsServicer.
+type MocksetDestinationsServicerMockRecorder struct {
+	mock *MocksetDestinationsServicer
+}
+
+// NewMocksetDestinationsServicer creates a new mock instance.
+func NewMocksetDestinationsServicer(ctrl *gomock.Controller) *MocksetDestinationsServicer {
+	mock := &MocksetDestinationsServicer{ctrl: ctrl}
+	mock.recorder = &MocksetDestinationsServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetDestinationsServicer) EXPECT() *MocksetDestinationsServicerMockRecorder {
+	return m.recorder
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MocksetDestinationsServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MocksetDestinationsServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MocksetDestinationsServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// SetDestinations mocks base method.
+func (m *MocksetDestinationsServicer) SetDestinations(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 []models.Destination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDestinations", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDestinations indicates an expected call of SetDestinations.
+func (mr *MocksetDestinationsServicerMockRecorder) SetDestinations(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDestinations", reflect.TypeOf((*MocksetDestinationsServicer)(nil).SetDestinations), arg0, arg1, arg2, arg3)
+}
+
+// MocksetDestinationsAuthServicer is a mock of setDestinationsAuthServicer interface.
+type MocksetDestinationsAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetDestinationsAuthServicerMockRecorder
+}
+
+// MocksetDestinationsAuthServicerMockRecorder is the mock recorder for MocksetDestinationsAuthServicer.
+type MocksetDestinationsAuthServicerMockRecorder struct {
+	mock *MocksetDestinationsAuthServicer
+}
+
+// NewMocksetDestinationsAuthServicer creates a new mock instance.
+func NewMocksetDestinationsAuthServicer(ctrl *gomock.Controller) *MocksetDestinationsAuthServicer {
+	mock := &MocksetDestinationsAuthServicer{ctrl: ctrl}
+	mock.recorder = &MocksetDestinationsAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetDestinationsAuthServicer) EXPECT() *MocksetDestinationsAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MocksetDestinationsAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MocksetDestinationsAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MocksetDestinationsAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrSetDestinationsNotExist is a mock of errSetDestinationsNotExist interface.
+type MockerrSetDestinationsNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSetDestinationsNotExistMockRecorder
+}
+
+// MockerrSetDestinationsNotExistMockRecorder is the mock recorder for MockerrSetDestinationsNotExist.
+type MockerrSetDestinationsNotExistMockRecorder struct {
+	mock *MockerrSetDestinationsNotExist
+}
+
+// NewMockerrSetDestinationsNotExist creates a new mock instance.
+func NewMockerrSetDestinationsNotExist(ctrl *gomock.Controller) *MockerrSetDestinationsNotExist {
+	mock := &MockerrSetDestinationsNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrSetDestinationsNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSetDestinationsNotExist) EXPECT() *MockerrSetDestinationsNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSetDestinationsNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSetDestinationsNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSetDestinationsNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrSetDestinationsNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrSetDestinationsNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrSetDestinationsNotExist)(nil).IsErrNotExist))
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: setpassword.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MocksetPasswordServicer is a mock of setPasswordServicer interface.
+type MocksetPasswordServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetPasswordServicerMockRecorder
+}
+
+// MocksetPasswordServicerMockRecorder is the mock recorder for MocksetPasswordServicer.
+type MocksetPasswordServicerMockRecorder struct {
+	mock *MocksetPasswordServicer
+}
+
+// NewMocksetPasswordServicer creates a new mock instance.
+func NewMocksetPasswordServicer(ctrl *gomock.Controller) *MocksetPasswordServicer {
+	mock := &MocksetPasswordServicer{ctrl: ctrl}
+	mock.recorder = &MocksetPasswordServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetPasswordServicer) EXPECT() *MocksetPasswordServicerMockRecorder {
+	return m.recorder
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MocksetPasswordServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MocksetPasswordServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MocksetPasswordServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// SetPassword mocks base method.
+func (m *MocksetPasswordServicer) SetPassword(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MocksetPasswordServicerMockRecorder) SetPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MocksetPasswordServicer)(nil).SetPassword), arg0, arg1, arg2, arg3)
+}
+
+// MocksetPasswordAuthServicer is a mock of setPasswordAuthServicer interface.
+type MocksetPasswordAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocksetPasswordAuthServicerMockRecorder
+}
+
+// MocksetPasswordAuthServicerMockRecorder is the mock recorder for MocksetPasswordAuthServicer.
+type MocksetPasswordAuthServicerMockRecorder struct {
+	mock *MocksetPasswordAuthServicer
+}
+
+// NewMocksetPasswordAuthServicer creates a new mock instance.
+func NewMocksetPasswordAuthServicer(ctrl *gomock.Controller) *MocksetPasswordAuthServicer {
+	mock := &MocksetPasswordAuthServicer{ctrl: ctrl}
+	mock.recorder = &MocksetPasswordAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksetPasswordAuthServicer) EXPECT() *MocksetPasswordAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MocksetPasswordAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MocksetPasswordAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MocksetPasswordAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrSetPasswordNotExist is a mock of errSetPasswordNotExist interface.
+type MockerrSetPasswordNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSetPasswordNotExistMockRecorder
+}
+
+// MockerrSetPasswordNotExistMockRecorder is the mock recorder for MockerrSetPasswordNotExist.
+type MockerrSetPasswordNotExistMockRecorder struct {
+	mock *MockerrSetPasswordNotExist
+}
+
+// NewMockerrSetPasswordNotExist creates a new mock instance.
+func NewMockerrSetPasswordNotExist(ctrl *gomock.Controller) *MockerrSetPasswordNotExist {
+	mock := &MockerrSetPasswordNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrSetPasswordNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSetPasswordNotExist) EXPECT() *MockerrSetPasswordNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSetPasswordNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSetPasswordNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSetPasswordNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrSetPasswordNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrSetPasswordNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrSetPasswordNotExist)(nil).IsErrNotExist))
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: testrules.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MocktestRulesServicer is a mock of testRulesServicer interface.
+type MocktestRulesServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocktestRulesServicerMockRecorder
+}
+
+// MocktestRulesServicerMockRecorder is the mock recorder for MocktestRulesServicer.
+type MocktestRulesServicerMockRecorder struct {
+	mock *MocktestRulesServicer
+}
+
+// NewMocktestRulesServicer creates a new mock instance.
+func NewMocktestRulesServicer(ctrl *gomock.Controller) *MocktestRulesServicer {
+	mock := &MocktestRulesServicer{ctrl: ctrl}
+	mock.recorder = &MocktestRulesServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktestRulesServicer) EXPECT() *MocktestRulesServicerMockRecorder {
+	return m.recorder
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MocktestRulesServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MocktestRulesServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MocktestRulesServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// TestRules mocks base method.
+func (m *MocktestRulesServicer) TestRules(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 *models.RuleTest) (*models.RuleTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestRules", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.RuleTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestRules indicates an expected call of TestRules.
+func (mr *MocktestRulesServicerMockRecorder) TestRules(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestRules", reflect.TypeOf((*MocktestRulesServicer)(nil).TestRules), arg0, arg1, arg2, arg3)
+}
+
+// MocktestRulesAuthServicer is a mock of testRulesAuthServicer interface.
+type MocktestRulesAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MocktestRulesAuthServicerMockRecorder
+}
+
+// MocktestRulesAuthServicerMockRecorder is the mock recorder for MocktestRulesAuthServicer.
+type MocktestRulesAuthServicerMockRecorder struct {
+	mock *MocktestRulesAuthServicer
+}
+
+// NewMocktestRulesAuthServicer creates a new mock instance.
+func NewMocktestRulesAuthServicer(ctrl *gomock.Controller) *MocktestRulesAuthServicer {
+	mock := &MocktestRulesAuthServicer{ctrl: ctrl}
+	mock.recorder = &MocktestRulesAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktestRulesAuthServicer) EXPECT() *MocktestRulesAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MocktestRulesAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MocktestRulesAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MocktestRulesAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrTestRulesNotExist is a mock of errTestRulesNotExist interface.
+type MockerrTestRulesNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTestRulesNotExistMockRecorder
+}
+
+// MockerrTestRulesNotExistMockRecorder is the mock recorder for MockerrTestRulesNotExist.
+type MockerrTestRulesNotExistMockRecorder struct {
+	mock *MockerrTestRulesNotExist
+}
+
+// NewMockerrTestRulesNotExist creates a new mock instance.
+func NewMockerrTestRulesNotExist(ctrl *gomock.Controller) *MockerrTestRulesNotExist {
+	mock := &MockerrTestRulesNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrTestRulesNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTestRulesNotExist) EXPECT() *MockerrTestRulesNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTestRulesNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTestRulesNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTestRulesNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrTestRulesNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrTestRulesNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrTestRulesNotExist)(nil).IsErrNotExist))
+}
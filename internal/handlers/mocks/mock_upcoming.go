@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: upcoming.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockupcomingServicer is a mock of upcomingServicer interface.
+type MockupcomingServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockupcomingServicerMockRecorder
+}
+
+// MockupcomingServicerMockRecorder is the mock recorder for MockupcomingServicer.
+type MockupcomingServicerMockRecorder struct {
+	mock *MockupcomingServicer
+}
+
+// NewMockupcomingServicer creates a new mock instance.
+func NewMockupcomingServicer(ctrl *gomock.Controller) *MockupcomingServicer {
+	mock := &MockupcomingServicer{ctrl: ctrl}
+	mock.recorder = &MockupcomingServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockupcomingServicer) EXPECT() *MockupcomingServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUpcomingURLs mocks base method.
+func (m *MockupcomingServicer) GetUpcomingURLs(arg0 context.Context, arg1 models.UserID) ([]models.URLPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingURLs", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingURLs indicates an expected call of GetUpcomingURLs.
+func (mr *MockupcomingServicerMockRecorder) GetUpcomingURLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingURLs", reflect.TypeOf((*MockupcomingServicer)(nil).GetUpcomingURLs), arg0, arg1)
+}
+
+// MockupcomingAuthServicer is a mock of upcomingAuthServicer interface.
+type MockupcomingAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockupcomingAuthServicerMockRecorder
+}
+
+// MockupcomingAuthServicerMockRecorder is the mock recorder for MockupcomingAuthServicer.
+type MockupcomingAuthServicerMockRecorder struct {
+	mock *MockupcomingAuthServicer
+}
+
+// NewMockupcomingAuthServicer creates a new mock instance.
+func NewMockupcomingAuthServicer(ctrl *gomock.Controller) *MockupcomingAuthServicer {
+	mock := &MockupcomingAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockupcomingAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockupcomingAuthServicer) EXPECT() *MockupcomingAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockupcomingAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockupcomingAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockupcomingAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrUpcomingNotExist is a mock of errUpcomingNotExist interface.
+type MockerrUpcomingNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrUpcomingNotExistMockRecorder
+}
+
+// MockerrUpcomingNotExistMockRecorder is the mock recorder for MockerrUpcomingNotExist.
+type MockerrUpcomingNotExistMockRecorder struct {
+	mock *MockerrUpcomingNotExist
+}
+
+// NewMockerrUpcomingNotExist creates a new mock instance.
+func NewMockerrUpcomingNotExist(ctrl *gomock.Controller) *MockerrUpcomingNotExist {
+	mock := &MockerrUpcomingNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrUpcomingNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrUpcomingNotExist) EXPECT() *MockerrUpcomingNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrUpcomingNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrUpcomingNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrUpcomingNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrUpcomingNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrUpcomingNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrUpcomingNotExist)(nil).IsErrNotExist))
+}
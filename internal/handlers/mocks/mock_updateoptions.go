@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: updateoptions.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/shorturl/internal/models"
+)
+
+// MockupdateOptionsServicer is a mock of updateOptionsServicer interface.
+type MockupdateOptionsServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockupdateOptionsServicerMockRecorder
+}
+
+// MockupdateOptionsServicerMockRecorder is the mock recorder for MockupdateOptionsServicer.
+type MockupdateOptionsServicerMockRecorder struct {
+	mock *MockupdateOptionsServicer
+}
+
+// NewMockupdateOptionsServicer creates a new mock instance.
+func NewMockupdateOptionsServicer(ctrl *gomock.Controller) *MockupdateOptionsServicer {
+	mock := &MockupdateOptionsServicer{ctrl: ctrl}
+	mock.recorder = &MockupdateOptionsServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockupdateOptionsServicer) EXPECT() *MockupdateOptionsServicerMockRecorder {
+	return m.recorder
+}
+
+// GetShortURLFromCtx mocks base method.
+func (m *MockupdateOptionsServicer) GetShortURLFromCtx(arg0 context.Context) (models.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURLFromCtx", arg0)
+	ret0, _ := ret[0].(models.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURLFromCtx indicates an expected call of GetShortURLFromCtx.
+func (mr *MockupdateOptionsServicerMockRecorder) GetShortURLFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLFromCtx", reflect.TypeOf((*MockupdateOptionsServicer)(nil).GetShortURLFromCtx), arg0)
+}
+
+// SetURLOptions mocks base method.
+func (m *MockupdateOptionsServicer) SetURLOptions(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 *models.LinkOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetURLOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetURLOptions indicates an expected call of SetURLOptions.
+func (mr *MockupdateOptionsServicerMockRecorder) SetURLOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetURLOptions", reflect.TypeOf((*MockupdateOptionsServicer)(nil).SetURLOptions), arg0, arg1, arg2, arg3)
+}
+
+// MockupdateOptionsAuthServicer is a mock of updateOptionsAuthServicer interface.
+type MockupdateOptionsAuthServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockupdateOptionsAuthServicerMockRecorder
+}
+
+// MockupdateOptionsAuthServicerMockRecorder is the mock recorder for MockupdateOptionsAuthServicer.
+type MockupdateOptionsAuthServicerMockRecorder struct {
+	mock *MockupdateOptionsAuthServicer
+}
+
+// NewMockupdateOptionsAuthServicer creates a new mock instance.
+func NewMockupdateOptionsAuthServicer(ctrl *gomock.Controller) *MockupdateOptionsAuthServicer {
+	mock := &MockupdateOptionsAuthServicer{ctrl: ctrl}
+	mock.recorder = &MockupdateOptionsAuthServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockupdateOptionsAuthServicer) EXPECT() *MockupdateOptionsAuthServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserIDFromCtx mocks base method.
+func (m *MockupdateOptionsAuthServicer) GetUserIDFromCtx(arg0 context.Context) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDFromCtx", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDFromCtx indicates an expected call of GetUserIDFromCtx.
+func (mr *MockupdateOptionsAuthServicerMockRecorder) GetUserIDFromCtx(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromCtx", reflect.TypeOf((*MockupdateOptionsAuthServicer)(nil).GetUserIDFromCtx), arg0)
+}
+
+// MockerrUpdateOptionsNotExist is a mock of errUpdateOptionsNotExist interface.
+type MockerrUpdateOptionsNotExist struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrUpdateOptionsNotExistMockRecorder
+}
+
+// MockerrUpdateOptionsNotExistMockRecorder is the mock recorder for MockerrUpdateOptionsNotExist.
+type MockerrUpdateOptionsNotExistMockRecorder struct {
+	mock *MockerrUpdateOptionsNotExist
+}
+
+// NewMockerrUpdateOptionsNotExist creates a new mock instance.
+func NewMockerrUpdateOptionsNotExist(ctrl *gomock.Controller) *MockerrUpdateOptionsNotExist {
+	mock := &MockerrUpdateOptionsNotExist{ctrl: ctrl}
+	mock.recorder = &MockerrUpdateOptionsNotExistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrUpdateOptionsNotExist) EXPECT() *MockerrUpdateOptionsNotExistMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrUpdateOptionsNotExist) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrUpdateOptionsNotExistMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrUpdateOptionsNotExist)(nil).Error))
+}
+
+// IsErrNotExist mocks base method.
+func (m *MockerrUpdateOptionsNotExist) IsErrNotExist() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotExist")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotExist indicates an expected call of IsErrNotExist.
+func (mr *MockerrUpdateOptionsNotExistMockRecorder) IsErrNotExist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotExist", reflect.TypeOf((*MockerrUpdateOptionsNotExist)(nil).IsErrNotExist))
+}
@@ -42,7 +42,7 @@ func (h *PingHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	err := h.pingService.PingStorage(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 	res.WriteHeader(http.StatusOK)
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/qr"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type qrServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	DescribeURL(context.Context, models.ShortURL) (*models.URLInfo, error)
+}
+
+type qrAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+type errQRNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// QRHandler handles requests for QR codes of user's links.
+//
+// Codes of links which are not live yet or have expired are rendered too,
+// so they can be printed in advance.
+//
+// Response codes:
+//   - 200 OK: QR code image
+//   - 400 Bad Request: invalid image parameters
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type QRHandler struct {
+	qrService   qrServicer
+	authService qrAuthServicer
+	baseAddr    string
+}
+
+// NewQRHandler creates new user QR code handler instance.
+func NewQRHandler(qrService qrServicer, authService qrAuthServicer, baseAddr string) *QRHandler {
+	return &QRHandler{
+		qrService:   qrService,
+		authService: authService,
+		baseAddr:    baseAddr,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for user QR code endpoint.
+//
+// Expected request format:
+//
+//	GET /api/user/urls/{id}/qr[?format=png|svg&size=256&margin=4&level=L|M|Q|H]
+//	Authorization: Bearer <token>
+func (h *QRHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	opts, err := parseQROptions(req.URL.Query(), qr.DefaultOptions())
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.qrService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	info, err := h.qrService.DescribeURL(req.Context(), shortURL)
+	if e, ok := err.(errQRNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if info.UID != uid {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeQR(res, req, h.baseAddr+"/"+string(shortURL), opts, "private, max-age=3600")
+}
+
+// PublicQRHandler handles public requests for QR codes of live links.
+//
+// The image format is fixed by the route, other parameters are taken
+// from the query as for QRHandler.
+//
+// Response codes:
+//   - 200 OK: QR code image
+//   - 400 Bad Request: invalid image parameters
+//   - 404 Not Found: no such short URL
+//   - 410 Gone: URL was deleted or expired
+//   - 500 Internal Server Error: processing failure
+type PublicQRHandler struct {
+	qrService qrServicer
+	baseAddr  string
+	format    string
+}
+
+// NewPublicQRHandler creates new public QR code handler instance
+// rendering images of the given format.
+func NewPublicQRHandler(qrService qrServicer, baseAddr string, format string) *PublicQRHandler {
+	return &PublicQRHandler{
+		qrService: qrService,
+		baseAddr:  baseAddr,
+		format:    format,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for public QR code endpoint.
+//
+// Expected request format:
+//
+//	GET /{id}.png[?size=256&margin=4&level=L|M|Q|H]
+func (h *PublicQRHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	query.Del("format")
+	base := qr.DefaultOptions()
+	base.Format = h.format
+
+	opts, err := parseQROptions(query, base)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.qrService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	info, err := h.qrService.DescribeURL(req.Context(), shortURL)
+	if e, ok := err.(errQRNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if info.Status == models.StatusDeleted || info.Status == models.StatusExpired {
+		res.WriteHeader(http.StatusGone)
+		return
+	}
+
+	writeQR(res, req, h.baseAddr+"/"+string(shortURL), opts, "public, max-age=86400")
+}
+
+// parseQROptions reads image parameters from query over the base options.
+func parseQROptions(query url.Values, base qr.Options) (qr.Options, error) {
+	opts := base
+
+	if v := query.Get("format"); v != "" {
+		opts.Format = strings.ToLower(v)
+	}
+	if v := query.Get("level"); v != "" {
+		opts.Level = strings.ToUpper(v)
+	}
+	if v := query.Get("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Size = size
+	}
+	if v := query.Get("margin"); v != "" {
+		margin, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Margin = margin
+	}
+
+	return opts, opts.Validate()
+}
+
+// writeQR renders QR code of the content into response.
+func writeQR(res http.ResponseWriter, req *http.Request, content string, opts qr.Options, cacheControl string) {
+	img, err := qr.Render(content, opts)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.Header().Set("Content-Type", opts.ContentType())
+	res.Header().Set("Cache-Control", cacheControl)
+	res.WriteHeader(http.StatusOK)
+	_, err = res.Write(img)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+	}
+}
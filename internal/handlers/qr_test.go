@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bytes"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockqrServicer(ctrl)
+	mAuth := mocks.NewMockqrAuthServicer(ctrl)
+
+	qrHandler := NewQRHandler(mServ, mAuth, testBaseAddr)
+
+	info := &models.URLInfo{URLPair: testPair, Status: models.StatusScheduled}
+
+	serve := func(t *testing.T, target string) (*http.Response, []byte) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		qrHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, body
+	}
+
+	t.Run("png", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, body := serve(t, "/?size=128&margin=2&level=h")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+		img, err := png.Decode(bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Equal(t, 128, img.Bounds().Dx())
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, body := serve(t, "/?format=svg")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "image/svg+xml", res.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(string(body), "<svg "))
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, "/?size=big")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("other user link", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID("other"), nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(info, nil)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrQRNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(nil, mErr)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(nil, errTest)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestPublicQRHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockqrServicer(ctrl)
+
+	qrHandler := NewPublicQRHandler(mServ, testBaseAddr, "png")
+
+	serve := func(t *testing.T, target string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		qrHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		require.NoError(t, res.Body.Close())
+		return res
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(&models.URLInfo{URLPair: testPair, Status: models.StatusActive}, nil)
+
+		res := serve(t, "/abc123.png?format=svg")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+		assert.Contains(t, res.Header.Get("Cache-Control"), "public")
+	})
+
+	t.Run("deleted url", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().DescribeURL(gomock.Any(), testShortURL).Return(&models.URLInfo{URLPair: testPair, Status: models.StatusDeleted}, nil)
+
+		res := serve(t, "/abc123.png")
+
+		assert.Equal(t, http.StatusGone, res.StatusCode)
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		res := serve(t, "/abc123.png?margin=100")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/qr"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type qrBatchServicer interface {
+	GetUserURLs(context.Context, models.UserID) ([]models.URLPair, error)
+}
+
+type qrBatchAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+type errQRBatchNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// QRBatchHandler handles requests for a ZIP archive of QR codes
+// of user's links.
+//
+// The archive contains one {id}.png or {id}.svg file per link.
+// Repeated short query parameter limits the archive to the given links.
+//
+// Response codes:
+//   - 200 OK: ZIP archive
+//   - 204 No Content: user has no matching links
+//   - 400 Bad Request: invalid image parameters
+//   - 500 Internal Server Error: processing failure
+type QRBatchHandler struct {
+	qrBatchService qrBatchServicer
+	authService    qrBatchAuthServicer
+	baseAddr       string
+}
+
+// NewQRBatchHandler creates new QR code archive handler instance.
+func NewQRBatchHandler(qrBatchService qrBatchServicer, authService qrBatchAuthServicer, baseAddr string) *QRBatchHandler {
+	return &QRBatchHandler{
+		qrBatchService: qrBatchService,
+		authService:    authService,
+		baseAddr:       baseAddr,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for QR code archive endpoint.
+//
+// Expected request format:
+//
+//	GET /api/user/urls/qr[?short={id}&short={id}&format=png|svg&size=256&margin=4&level=L|M|Q|H]
+//	Authorization: Bearer <token>
+func (h *QRBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	opts, err := parseQROptions(req.URL.Query(), qr.DefaultOptions())
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	pairs, err := h.qrBatchService.GetUserURLs(req.Context(), uid)
+	if e, ok := err.(errQRBatchNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	pairs = filterPairs(pairs, req.URL.Query()["short"])
+	if len(pairs) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/zip")
+	res.Header().Set("Content-Disposition", `attachment; filename="qr.zip"`)
+	res.WriteHeader(http.StatusOK)
+
+	// PNG images are already compressed
+	method := zip.Deflate
+	if opts.Format == qr.FormatPNG {
+		method = zip.Store
+	}
+
+	modified := time.Now()
+	zw := zip.NewWriter(res)
+	for _, pair := range pairs {
+		img, err := qr.Render(h.baseAddr+"/"+string(pair.Short), opts)
+		if err != nil {
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+			return
+		}
+
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     string(pair.Short) + "." + opts.Format,
+			Method:   method,
+			Modified: modified,
+		})
+		if err != nil {
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+			return
+		}
+		if _, err = f.Write(img); err != nil {
+			logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+			return
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+	}
+}
+
+// filterPairs keeps pairs with the given short URLs. Empty filter keeps all.
+func filterPairs(pairs []models.URLPair, shorts []string) []models.URLPair {
+	if len(shorts) == 0 {
+		return pairs
+	}
+
+	wanted := make(map[models.ShortURL]struct{}, len(shorts))
+	for _, short := range shorts {
+		wanted[models.ShortURL(short)] = struct{}{}
+	}
+
+	var filtered []models.URLPair
+	for _, pair := range pairs {
+		if _, ok := wanted[pair.Short]; ok {
+			filtered = append(filtered, pair)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRBatchHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockqrBatchServicer(ctrl)
+	mAuth := mocks.NewMockqrBatchAuthServicer(ctrl)
+
+	qrBatchHandler := NewQRBatchHandler(mServ, mAuth, testBaseAddr)
+
+	otherPair := testPair
+	otherPair.Short = "def456"
+	pairs := []models.URLPair{testPair, otherPair}
+
+	serve := func(t *testing.T, target string) (*http.Response, []byte) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		qrBatchHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res, body
+	}
+
+	zipNames := func(t *testing.T, body []byte) []string {
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+
+		var names []string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetUserURLs(gomock.Any(), testUserID).Return(pairs, nil)
+
+		res, body := serve(t, "/")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/zip", res.Header.Get("Content-Type"))
+		assert.Equal(t, []string{"abc123.png", "def456.png"}, zipNames(t, body))
+	})
+
+	t.Run("filtered svg", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetUserURLs(gomock.Any(), testUserID).Return(pairs, nil)
+
+		res, body := serve(t, "/?short=def456&format=svg")
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, []string{"def456.svg"}, zipNames(t, body))
+	})
+
+	t.Run("no matching links", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetUserURLs(gomock.Any(), testUserID).Return(pairs, nil)
+
+		res, _ := serve(t, "/?short=unknown")
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("no links", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mErr := mocks.NewMockerrQRBatchNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().GetUserURLs(gomock.Any(), testUserID).Return(nil, mErr)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+
+		res, _ := serve(t, "/?level=Z")
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetUserURLs(gomock.Any(), testUserID).Return(nil, errTest)
+
+		res, _ := serve(t, "/")
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
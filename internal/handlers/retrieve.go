@@ -2,15 +2,43 @@ package handlers
 
 import (
 	"context"
+	_ "embed"
+	"html/template"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/rycln/shorturl/internal/contextkeys"
 	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/metrics"
 	"github.com/rycln/shorturl/internal/models"
 	"go.uber.org/zap"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+// passwordHeader carries password of a protected link for non-browser clients.
+const passwordHeader = "X-Link-Password"
+
+//go:embed templates/password.html
+var passwordPage string
+
+var passwordTmpl = template.Must(template.New("password").Parse(passwordPage))
+
+//go:embed templates/notactive.html
+var notActivePage string
+
+var notActiveTmpl = template.Must(template.New("notactive").Parse(notActivePage))
+
+// Visitor cookie parameters.
+const (
+	visitorCookieName   = "visitor_id"
+	visitorCookieMaxAge = 365 * 24 * 60 * 60
+)
+
 type retrieveServicer interface {
 	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
 	GetOrigURLByShort(context.Context, models.ShortURL) (models.OrigURL, error)
@@ -20,15 +48,32 @@ type retrieveServicer interface {
 //
 // Implements HTTP redirection flow:
 // 1. Extracts short URL ID from path parameter
-// 2. Looks up original URL in storage
-// 3. Returns 307 Redirect with original URL
+// 2. Collects visit details (visitor cookie, client headers and address,
+// query, trailing path)
+// 3. Looks up destination URL in storage
+// 4. Returns 307 Redirect with destination URL
+//
+// New visitors get an identifying cookie only when a multi-destination link
+// picks a variant for them, so that they keep getting the same variant.
+//
+// Password protected links show an HTML form posting the password back
+// to the same URL. The password can also be sent in X-Link-Password header.
+// Scheduled links show a "not yet available" page before activation
+// and are gone after expiry.
 //
 // Response codes:
+//   - 303 See Other: successful lookup after password form submission
 //   - 307 Temporary Redirect: successful lookup
-//   - 410 Gone: URL was deleted
+//   - 400 Bad Request: trailing path escapes the destination path
+//   - 401 Unauthorized: link is password protected
+//   - 403 Forbidden: wrong password
+//   - 404 Not Found: link is not active yet
+//   - 410 Gone: URL was deleted or expired
+//   - 429 Too Many Requests: too many wrong passwords for the link
 //   - 500 Internal Server Error: processing failure
 type RetrieveHandler struct {
 	retrieveService retrieveServicer
+	trustedProxy    *net.IPNet
 }
 
 type errRetrieveDeletedURL interface {
@@ -36,10 +81,41 @@ type errRetrieveDeletedURL interface {
 	IsErrDeletedURL() bool
 }
 
+type errRetrieveInvalidPath interface {
+	error
+	IsErrInvalidPath() bool
+}
+
+type errRetrievePasswordRequired interface {
+	error
+	IsErrPasswordRequired() bool
+}
+
+type errRetrieveWrongPassword interface {
+	error
+	IsErrWrongPassword() bool
+}
+
+type errRetrieveNotActive interface {
+	error
+	IsErrNotActive() bool
+	ActiveFrom() time.Time
+}
+
+type errRetrieveTooManyAttempts interface {
+	error
+	IsErrTooManyAttempts() bool
+	RetryAfter() time.Duration
+}
+
 // NewRetrieveHandler creates new redirect handler instance.
-func NewRetrieveHandler(retrieveService retrieveServicer) *RetrieveHandler {
+//
+// X-Real-IP header is taken as the visitor address only for connections
+// from trustedProxy network, nil trustedProxy ignores it.
+func NewRetrieveHandler(retrieveService retrieveServicer, trustedProxy *net.IPNet) *RetrieveHandler {
 	return &RetrieveHandler{
 		retrieveService: retrieveService,
+		trustedProxy:    trustedProxy,
 	}
 }
 
@@ -47,27 +123,181 @@ func NewRetrieveHandler(retrieveService retrieveServicer) *RetrieveHandler {
 //
 // Expected request format:
 //
-//	GET /{id}
+//	GET /{id}[/{path}][?{query}]
 //	Content-Type: text/plain
+//
+// Password form submission:
+//
+//	POST /{id}[/{path}][?{query}]
+//	Content-Type: application/x-www-form-urlencoded
+//
+//	password=<password>
 func (h *RetrieveHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	shortURL, err := h.retrieveService.GetShortURLFromCtx(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
-	origURL, err := h.retrieveService.GetOrigURLByShort(req.Context(), shortURL)
+	visit := newVisit(req, h.trustedProxy)
+	ctx := context.WithValue(req.Context(), contextkeys.Visit, visit)
+
+	origURL, err := h.retrieveService.GetOrigURLByShort(ctx, shortURL)
 	if e, ok := err.(errRetrieveDeletedURL); ok && e.IsErrDeletedURL() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectGone).Inc()
 		res.WriteHeader(http.StatusGone)
 		return
 	}
+	if e, ok := err.(errRetrieveNotActive); ok && e.IsErrNotActive() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectNotActive).Inc()
+		writeNotActivePage(res, req, e.ActiveFrom())
+		return
+	}
+	if e, ok := err.(errRetrieveInvalidPath); ok && e.IsErrInvalidPath() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectInvalidPath).Inc()
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if e, ok := err.(errRetrievePasswordRequired); ok && e.IsErrPasswordRequired() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectPasswordRequired).Inc()
+		writePasswordPage(res, req, http.StatusUnauthorized, "")
+		return
+	}
+	if e, ok := err.(errRetrieveWrongPassword); ok && e.IsErrWrongPassword() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectWrongPassword).Inc()
+		writePasswordPage(res, req, http.StatusForbidden, "Wrong password.")
+		return
+	}
+	if e, ok := err.(errRetrieveTooManyAttempts); ok && e.IsErrTooManyAttempts() {
+		metrics.Redirects.WithLabelValues(metrics.RedirectTooManyAttempts).Inc()
+		res.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter().Seconds()))))
+		writePasswordPage(res, req, http.StatusTooManyRequests, "Too many attempts, try again later.")
+		return
+	}
 	if err != nil {
+		metrics.Redirects.WithLabelValues(metrics.RedirectError).Inc()
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
+	metrics.Redirects.WithLabelValues(metrics.RedirectFollowed).Inc()
+	setVisitorCookie(res, req, visit)
 	res.Header().Set("Location", string(origURL))
+	if req.Method == http.MethodPost && visit.Password != "" {
+		res.WriteHeader(http.StatusSeeOther)
+		return
+	}
 	res.WriteHeader(http.StatusTemporaryRedirect)
 }
+
+// writePasswordPage renders the password form of a protected link.
+func writePasswordPage(res http.ResponseWriter, req *http.Request, status int, errMsg string) {
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(status)
+
+	err := passwordTmpl.Execute(res, struct{ Error string }{Error: errMsg})
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("password page", zap.Error(err))
+	}
+}
+
+// writeNotActivePage renders the page of a scheduled link before its activation.
+func writeNotActivePage(res http.ResponseWriter, req *http.Request, activeFrom time.Time) {
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusNotFound)
+
+	err := notActiveTmpl.Execute(res, struct{ ActiveFrom time.Time }{ActiveFrom: activeFrom.UTC()})
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("not active page", zap.Error(err))
+	}
+}
+
+// newVisit collects redirect request details.
+//
+// New visitors get a fresh ID which is kept only if setVisitorCookie
+// stores it.
+func newVisit(req *http.Request, trustedProxy *net.IPNet) *models.Visit {
+	path, _ := req.Context().Value(contextkeys.PathSuffix).(string)
+
+	visitorID := visitorCookie(req)
+	if visitorID == "" {
+		visitorID = uuid.NewString()
+	}
+
+	return &models.Visit{
+		VisitorID:      visitorID,
+		Query:          req.URL.Query(),
+		Path:           path,
+		UserAgent:      req.UserAgent(),
+		AcceptLanguage: req.Header.Get("Accept-Language"),
+		IP:             visitorIP(req, trustedProxy),
+		Time:           time.Now(),
+		Password:       visitorPassword(req),
+	}
+}
+
+// visitorCookie returns visitor ID sent in the cookie, if any.
+func visitorCookie(req *http.Request) string {
+	cookie, err := req.Cookie(visitorCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// setVisitorCookie remembers a new visitor once a multi-destination
+// variant was picked for them.
+func setVisitorCookie(res http.ResponseWriter, req *http.Request, visit *models.Visit) {
+	if !visit.VariantPicked || visitorCookie(req) != "" {
+		return
+	}
+
+	http.SetCookie(res, &http.Cookie{
+		Name:     visitorCookieName,
+		Value:    visit.VisitorID,
+		Path:     "/",
+		MaxAge:   visitorCookieMaxAge,
+		HttpOnly: true,
+	})
+}
+
+// visitorPassword returns protected link password sent in the header
+// or submitted with the password form.
+func visitorPassword(req *http.Request) string {
+	if password := req.Header.Get(passwordHeader); password != "" {
+		return password
+	}
+	if req.Method == http.MethodPost {
+		return req.PostFormValue("password")
+	}
+	return ""
+}
+
+// visitorIP returns the connection remote address. Connections from
+// trustedProxy network are reverse proxies and the client address set
+// in X-Real-IP header is returned instead.
+func visitorIP(req *http.Request, trustedProxy *net.IPNet) net.IP {
+	ip := remoteIP(req.RemoteAddr)
+	if trustedProxy == nil || ip == nil || !trustedProxy.Contains(ip) {
+		return ip
+	}
+
+	if realIP := net.ParseIP(req.Header.Get("X-Real-IP")); realIP != nil {
+		return realIP
+	}
+	return ip
+}
+
+// remoteIP extracts the IP address from a connection remote address.
+func remoteIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return net.ParseIP(addr)
+	}
+
+	return net.ParseIP(host)
+}
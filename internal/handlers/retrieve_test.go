@@ -1,12 +1,20 @@
 package handlers
 
 import (
+	"context"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rycln/shorturl/internal/contextkeys"
 	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/metrics"
 	"github.com/rycln/shorturl/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,12 +26,15 @@ func TestRetrieveHandler_ServeHTTP(t *testing.T) {
 
 	mServ := mocks.NewMockretrieveServicer(ctrl)
 
-	retrieveHandler := NewRetrieveHandler(mServ)
+	retrieveHandler := NewRetrieveHandler(mServ, nil)
 
 	t.Run("valid test", func(t *testing.T) {
 		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
 		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(testOrigURL, nil)
 
+		redirects := metrics.Redirects.WithLabelValues(metrics.RedirectFollowed)
+		before := testutil.ToFloat64(redirects)
+
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		w := httptest.NewRecorder()
 		retrieveHandler.ServeHTTP(w, req)
@@ -36,6 +47,108 @@ func TestRetrieveHandler_ServeHTTP(t *testing.T) {
 
 		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
 		assert.Equal(t, res.Header.Get("Location"), string(testOrigURL))
+		assert.Equal(t, before+1, testutil.ToFloat64(redirects))
+	})
+
+	t.Run("visit details", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).DoAndReturn(
+			func(ctx context.Context, _ models.ShortURL) (models.OrigURL, error) {
+				visit, ok := ctx.Value(contextkeys.Visit).(*models.Visit)
+				require.True(t, ok)
+				assert.Equal(t, "mail", visit.Query.Get("ref"))
+				assert.Equal(t, "docs/intro", visit.Path)
+				assert.Equal(t, "test-agent", visit.UserAgent)
+				assert.Equal(t, "de-DE,de;q=0.9", visit.AcceptLanguage)
+				assert.Equal(t, "81.2.69.142", visit.IP.String())
+				assert.False(t, visit.Time.IsZero())
+				return testOrigURL, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123/docs/intro?ref=mail", nil)
+		req.Header.Set("User-Agent", "test-agent")
+		req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+		req.Header.Set("X-Real-IP", "81.2.69.142")
+		req = req.WithContext(context.WithValue(req.Context(), contextkeys.PathSuffix, "docs/intro"))
+		w := httptest.NewRecorder()
+		_, proxies, err := net.ParseCIDR("192.0.2.0/24")
+		require.NoError(t, err)
+		NewRetrieveHandler(mServ, proxies).ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	})
+
+	t.Run("visitor cookie", func(t *testing.T) {
+		var firstID string
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil).Times(2)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).DoAndReturn(
+			func(ctx context.Context, _ models.ShortURL) (models.OrigURL, error) {
+				visit := ctx.Value(contextkeys.Visit).(*models.Visit)
+				require.NotEmpty(t, visit.VisitorID)
+				firstID = visit.VisitorID
+				visit.VariantPicked = true
+				return testOrigURL, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		cookies := res.Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, visitorCookieName, cookies[0].Name)
+		assert.Equal(t, firstID, cookies[0].Value)
+
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).DoAndReturn(
+			func(ctx context.Context, _ models.ShortURL) (models.OrigURL, error) {
+				visit := ctx.Value(contextkeys.Visit).(*models.Visit)
+				assert.Equal(t, firstID, visit.VisitorID)
+				visit.VariantPicked = true
+				return testOrigURL, nil
+			})
+
+		req = httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		req.AddCookie(cookies[0])
+		w = httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res2 := w.Result()
+		defer func() {
+			err := res2.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Empty(t, res2.Cookies())
+	})
+
+	t.Run("no visitor cookie for single destination", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(testOrigURL, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+		assert.Empty(t, res.Cookies())
 	})
 
 	t.Run("short url error", func(t *testing.T) {
@@ -60,6 +173,9 @@ func TestRetrieveHandler_ServeHTTP(t *testing.T) {
 		mErr.EXPECT().IsErrDeletedURL().Return(true)
 		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
 
+		redirects := metrics.Redirects.WithLabelValues(metrics.RedirectGone)
+		before := testutil.ToFloat64(redirects)
+
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		w := httptest.NewRecorder()
 		retrieveHandler.ServeHTTP(w, req)
@@ -71,6 +187,162 @@ func TestRetrieveHandler_ServeHTTP(t *testing.T) {
 		}()
 
 		assert.Equal(t, http.StatusGone, res.StatusCode)
+		assert.Equal(t, before+1, testutil.ToFloat64(redirects))
+	})
+
+	t.Run("not active yet", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrRetrieveNotActive(ctrl)
+		mErr.EXPECT().IsErrNotActive().Return(true)
+		mErr.EXPECT().ActiveFrom().Return(time.Date(2030, 1, 1, 9, 30, 0, 0, time.UTC))
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "not yet available")
+		assert.Contains(t, string(body), "2030-01-01T09:30:00Z")
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrRetrieveInvalidPath(ctrl)
+		mErr.EXPECT().IsErrInvalidPath().Return(true)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123/..%2fadmin", nil)
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("password required", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrRetrievePasswordRequired(ctrl)
+		mErr.EXPECT().IsErrPasswordRequired().Return(true)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Content-Type"), "text/html")
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `name="password"`)
+	})
+
+	t.Run("password header", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).DoAndReturn(
+			func(ctx context.Context, _ models.ShortURL) (models.OrigURL, error) {
+				visit := ctx.Value(contextkeys.Visit).(*models.Visit)
+				assert.Equal(t, "secret", visit.Password)
+				return testOrigURL, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		req.Header.Set("X-Link-Password", "secret")
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	})
+
+	t.Run("password form", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).DoAndReturn(
+			func(ctx context.Context, _ models.ShortURL) (models.OrigURL, error) {
+				visit := ctx.Value(contextkeys.Visit).(*models.Visit)
+				assert.Equal(t, "secret", visit.Password)
+				return testOrigURL, nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/abc123", strings.NewReader("password=secret"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Equal(t, string(testOrigURL), res.Header.Get("Location"))
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrRetrieveWrongPassword(ctrl)
+		mErr.EXPECT().IsErrWrongPassword().Return(true)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		req.Header.Set("X-Link-Password", "guess")
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrRetrieveTooManyAttempts(ctrl)
+		mErr.EXPECT().IsErrTooManyAttempts().Return(true)
+		mErr.EXPECT().RetryAfter().Return(1500 * time.Millisecond)
+		mServ.EXPECT().GetOrigURLByShort(gomock.Any(), testShortURL).Return(models.OrigURL(""), mErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+		req.Header.Set("X-Link-Password", "guess")
+		w := httptest.NewRecorder()
+		retrieveHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get("Retry-After"))
 	})
 
 	t.Run("some service error", func(t *testing.T) {
@@ -90,3 +362,64 @@ func TestRetrieveHandler_ServeHTTP(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
 	})
 }
+
+func TestVisitorIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		realIP       string
+		trustedProxy string
+		want         string
+	}{
+		{
+			name:       "no trusted proxy",
+			remoteAddr: "203.0.113.7:4321",
+			realIP:     "81.2.69.142",
+			want:       "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.1.2.3:4321",
+			realIP:       "81.2.69.142",
+			trustedProxy: "10.0.0.0/8",
+			want:         "81.2.69.142",
+		},
+		{
+			name:         "untrusted proxy",
+			remoteAddr:   "203.0.113.7:4321",
+			realIP:       "81.2.69.142",
+			trustedProxy: "10.0.0.0/8",
+			want:         "203.0.113.7",
+		},
+		{
+			name:         "trusted proxy without header",
+			remoteAddr:   "10.1.2.3:4321",
+			trustedProxy: "10.0.0.0/8",
+			want:         "10.1.2.3",
+		},
+		{
+			name:         "invalid header",
+			remoteAddr:   "10.1.2.3:4321",
+			realIP:       "unknown",
+			trustedProxy: "10.0.0.0/8",
+			want:         "10.1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var proxies *net.IPNet
+			if tt.trustedProxy != "" {
+				_, proxies, _ = net.ParseCIDR(tt.trustedProxy)
+			}
+
+			assert.Equal(t, tt.want, visitorIP(req, proxies).String())
+		})
+	}
+}
@@ -67,7 +67,7 @@ func (h *RetrieveBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Requ
 	uid, err := h.authService.GetUserIDFromCtx(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
@@ -78,7 +78,7 @@ func (h *RetrieveBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Requ
 	}
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
@@ -95,7 +95,7 @@ func (h *RetrieveBatchHandler) ServeHTTP(res http.ResponseWriter, req *http.Requ
 	err = json.NewEncoder(res).Encode(&resBatch)
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 }
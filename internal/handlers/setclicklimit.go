@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+var errBadClickLimit = errors.New("click limit must be positive")
+
+type setClickLimitServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	SetClickLimit(context.Context, models.UserID, models.ShortURL, *int64) error
+}
+
+type setClickLimitAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+type setClickLimitReq struct {
+	MaxClicks *int64 `json:"max_clicks"`
+}
+
+// SetClickLimitHandler handles requests to make a link self-destruct
+// after a number of redirects (one-time links, download tokens).
+//
+// Response codes:
+//   - 204 No Content: limit updated
+//   - 400 Bad Request: invalid request body
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type SetClickLimitHandler struct {
+	setClickLimitService setClickLimitServicer
+	authService          setClickLimitAuthServicer
+}
+
+type errSetClickLimitNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewSetClickLimitHandler creates new click limit handler instance.
+func NewSetClickLimitHandler(setClickLimitService setClickLimitServicer, authService setClickLimitAuthServicer) *SetClickLimitHandler {
+	return &SetClickLimitHandler{
+		setClickLimitService: setClickLimitService,
+		authService:          authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for click limit endpoint.
+//
+// The limit replaces the number of redirects left. Null limit makes
+// the link unlimited again.
+//
+// Expected request format:
+//
+//	PUT /api/user/urls/{id}/limit
+//	Content-Type: application/json
+//	Authorization: Bearer <token>
+//
+//	{"max_clicks": 1}
+func (h *SetClickLimitHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.setClickLimitService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var body setClickLimitReq
+	err = json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if body.MaxClicks != nil && *body.MaxClicks <= 0 {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(errBadClickLimit))
+		return
+	}
+
+	err = h.setClickLimitService.SetClickLimit(req.Context(), uid, shortURL, body.MaxClicks)
+	if e, ok := err.(errSetClickLimitNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type setDestinationsServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	SetDestinations(context.Context, models.UserID, models.ShortURL, []models.Destination) error
+}
+
+type setDestinationsAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// SetDestinationsHandler handles requests to split link traffic between
+// several weighted destinations (A/B testing).
+//
+// Response codes:
+//   - 204 No Content: destinations updated
+//   - 400 Bad Request: invalid destinations
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type SetDestinationsHandler struct {
+	setDestinationsService setDestinationsServicer
+	authService            setDestinationsAuthServicer
+}
+
+type errSetDestinationsNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewSetDestinationsHandler creates new destinations handler instance.
+func NewSetDestinationsHandler(setDestinationsService setDestinationsServicer, authService setDestinationsAuthServicer) *SetDestinationsHandler {
+	return &SetDestinationsHandler{
+		setDestinationsService: setDestinationsService,
+		authService:            authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for destinations endpoint.
+//
+// Expected request format:
+//
+//	PUT /api/user/urls/{id}/destinations
+//	Content-Type: application/json
+//	Authorization: Bearer <token>
+//
+//	[{"variant": "a", "url": "https://example.com/a", "weight": 1}]
+func (h *SetDestinationsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.setDestinationsService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var dests []models.Destination
+	err = json.NewDecoder(req.Body).Decode(&dests)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	err = validateDestinations(dests)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	err = h.setDestinationsService.SetDestinations(req.Context(), uid, shortURL, dests)
+	if e, ok := err.(errSetDestinationsNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
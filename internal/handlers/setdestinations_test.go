@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDestinationsHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMocksetDestinationsServicer(ctrl)
+	mAuth := mocks.NewMocksetDestinationsAuthServicer(ctrl)
+
+	handler := NewSetDestinationsHandler(mServ, mAuth)
+
+	testBody := `[{"variant":"a","url":"https://example.com/a","weight":3},{"variant":"b","url":"https://example.com/b","weight":1}]`
+	testDests := []models.Destination{
+		{Variant: "a", URL: "https://example.com/a", Weight: 3},
+		{Variant: "b", URL: "https://example.com/b", Weight: 1},
+	}
+
+	serve := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetDestinations(gomock.Any(), testUserID, testShortURL, testDests).Return(nil)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	invalid := map[string]string{
+		"bad body":          "{",
+		"empty variant":     `[{"url":"https://example.com/a","weight":1}]`,
+		"duplicate variant": `[{"variant":"a","url":"https://example.com/a","weight":1},{"variant":"a","url":"https://example.com/b","weight":1}]`,
+		"zero weight":       `[{"variant":"a","url":"https://example.com/a","weight":0}]`,
+		"bad url":           `[{"variant":"a","url":"example","weight":1}]`,
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+			mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+
+			res := serve(body)
+			defer func() {
+				err := res.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrSetDestinationsNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().SetDestinations(gomock.Any(), testUserID, testShortURL, testDests).Return(mErr)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("some service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetDestinations(gomock.Any(), testUserID, testShortURL, testDests).Return(errTest)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// maxPasswordLength is the longest password bcrypt can hash.
+const maxPasswordLength = 72
+
+var errPasswordTooLong = errors.New("password is too long")
+
+type setPasswordServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	SetPassword(context.Context, models.UserID, models.ShortURL, string) error
+}
+
+type setPasswordAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+type setPasswordReq struct {
+	Password string `json:"password"`
+}
+
+// SetPasswordHandler handles requests to protect a link with password.
+//
+// Response codes:
+//   - 204 No Content: password updated
+//   - 400 Bad Request: invalid request body
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type SetPasswordHandler struct {
+	setPasswordService setPasswordServicer
+	authService        setPasswordAuthServicer
+}
+
+type errSetPasswordNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewSetPasswordHandler creates new link password handler instance.
+func NewSetPasswordHandler(setPasswordService setPasswordServicer, authService setPasswordAuthServicer) *SetPasswordHandler {
+	return &SetPasswordHandler{
+		setPasswordService: setPasswordService,
+		authService:        authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for link password endpoint.
+//
+// Empty password removes protection.
+//
+// Expected request format:
+//
+//	PUT /api/user/urls/{id}/password
+//	Content-Type: application/json
+//	Authorization: Bearer <token>
+//
+//	{"password": "secret"}
+func (h *SetPasswordHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.setPasswordService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var body setPasswordReq
+	err = json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if len(body.Password) > maxPasswordLength {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(errPasswordTooLong))
+		return
+	}
+
+	err = h.setPasswordService.SetPassword(req.Context(), uid, shortURL, body.Password)
+	if e, ok := err.(errSetPasswordNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -63,44 +63,44 @@ func (h *ShortenHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	uid, err := h.authService.GetUserIDFromCtx(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 	_, err = url.ParseRequestURI(string(body))
 	if err != nil {
 		res.WriteHeader(http.StatusBadRequest)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
 	pair, err := h.shortenService.ShortenURL(req.Context(), uid, models.OrigURL(body))
 	if e, ok := err.(errShortenConflict); ok && e.IsErrConflict() {
-		h.sendResponse(res, http.StatusConflict, string(pair.Short))
+		h.sendResponse(res, req, http.StatusConflict, string(pair.Short))
 		return
 	}
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
-	h.sendResponse(res, http.StatusCreated, string(pair.Short))
+	h.sendResponse(res, req, http.StatusCreated, string(pair.Short))
 }
 
-func (h *ShortenHandler) sendResponse(res http.ResponseWriter, code int, shortURL string) {
+func (h *ShortenHandler) sendResponse(res http.ResponseWriter, req *http.Request, code int, shortURL string) {
 	res.Header().Set("Content-Type", "text/plain")
 	res.WriteHeader(code)
 	_, err := res.Write([]byte(h.baseAddr + "/" + shortURL))
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("shorten response write error", zap.Error(err))
+		logger.FromContext(req.Context()).Debug("shorten response write error", zap.Error(err))
 		return
 	}
 }
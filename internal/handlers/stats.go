@@ -36,7 +36,7 @@ func (h *StatsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	stats, err := h.statsService.GetStatsFromStorage(req.Context())
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 		return
 	}
 
@@ -44,6 +44,6 @@ func (h *StatsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	err = json.NewEncoder(res).Encode(stats)
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
-		logger.Log.Debug("path:"+req.URL.Path, zap.Error(err))
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
 	}
 }
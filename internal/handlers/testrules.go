@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+var errBadTestIP = errors.New("bad visitor ip")
+
+type testRulesServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	TestRules(context.Context, models.UserID, models.ShortURL, *models.RuleTest) (*models.RuleTestResult, error)
+}
+
+type testRulesAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// TestRulesHandler handles dry runs of link routing rules.
+//
+// The handler:
+// 1. Extracts user ID from request context (set by auth middleware)
+// 2. Validates the sample visitor and optional rules from request body
+// 3. Reports the rule the visitor would be routed by
+//
+// Response codes:
+//   - 200 OK: rules evaluated
+//   - 400 Bad Request: invalid sample visitor or rules
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type TestRulesHandler struct {
+	testRulesService testRulesServicer
+	authService      testRulesAuthServicer
+}
+
+type errTestRulesNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewTestRulesHandler creates new routing rules dry run handler instance.
+func NewTestRulesHandler(testRulesService testRulesServicer, authService testRulesAuthServicer) *TestRulesHandler {
+	return &TestRulesHandler{
+		testRulesService: testRulesService,
+		authService:      authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for routing rules dry run endpoint.
+//
+// Expected request format:
+//
+//	POST /api/user/urls/{id}/rules/test
+//	Content-Type: application/json
+//	Authorization: Bearer <token>
+//
+//	{"user_agent": "Mozilla/5.0 (iPhone...)", "accept_language": "de-DE", "ip": "81.2.69.142", "time": "2025-06-01T12:00:00Z"}
+func (h *TestRulesHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.testRulesService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var test models.RuleTest
+	err = json.NewDecoder(req.Body).Decode(&test)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	err = validateRuleTest(&test)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	result, err := h.testRulesService.TestRules(req.Context(), uid, shortURL, &test)
+	if e, ok := err.(errTestRulesNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(res).Encode(result)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+}
+
+// validateRuleTest checks the sample visitor and the rules to evaluate.
+func validateRuleTest(test *models.RuleTest) error {
+	if test.IP != "" && net.ParseIP(test.IP) == nil {
+		return errBadTestIP
+	}
+	if test.Country != "" && len(test.Country) != 2 {
+		return fmt.Errorf("bad country code %q", test.Country)
+	}
+
+	return validateRules(test.Rules)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRulesHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMocktestRulesServicer(ctrl)
+	mAuth := mocks.NewMocktestRulesAuthServicer(ctrl)
+
+	handler := NewTestRulesHandler(mServ, mAuth)
+
+	testBody := `{"user_agent":"iPhone","ip":"81.2.69.142"}`
+	testTest := &models.RuleTest{
+		UserAgent: "iPhone",
+		IP:        "81.2.69.142",
+	}
+	rule := 0
+	testResult := &models.RuleTestResult{
+		Platform:  models.PlatformIOS,
+		Languages: []string{},
+		Country:   "GB",
+		Rule:      &rule,
+		URL:       "https://apps.apple.com/app/id1",
+	}
+
+	serve := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().TestRules(gomock.Any(), testUserID, testShortURL, testTest).Return(testResult, nil)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var got models.RuleTestResult
+		err := json.NewDecoder(res.Body).Decode(&got)
+		require.NoError(t, err)
+		assert.Equal(t, testResult, &got)
+	})
+
+	for name, body := range map[string]string{
+		"bad json":    `{`,
+		"bad ip":      `{"ip":"unknown"}`,
+		"bad country": `{"country":"Germany"}`,
+		"bad rule":    `{"rules":[{"platforms":["tv"],"url":"https://example.com"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+			mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+
+			res := serve(body)
+			defer func() {
+				err := res.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrTestRulesNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().TestRules(gomock.Any(), testUserID, testShortURL, testTest).Return(nil, mErr)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().TestRules(gomock.Any(), testUserID, testShortURL, testTest).Return(nil, errTest)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type upcomingServicer interface {
+	GetUpcomingURLs(context.Context, models.UserID) ([]models.URLPair, error)
+}
+
+type upcomingAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// UpcomingHandler handles requests to list user's scheduled links
+// which are not live yet.
+//
+// Response codes:
+//   - 200 OK: links found and returned ordered by activation time
+//   - 204 No Content: user has no upcoming links
+//   - 500 Internal Server Error: processing failure
+type UpcomingHandler struct {
+	upcomingService upcomingServicer
+	authService     upcomingAuthServicer
+	baseAddr        string
+}
+
+type errUpcomingNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewUpcomingHandler creates new upcoming links handler instance.
+func NewUpcomingHandler(upcomingService upcomingServicer, authService upcomingAuthServicer, baseAddr string) *UpcomingHandler {
+	return &UpcomingHandler{
+		upcomingService: upcomingService,
+		authService:     authService,
+		baseAddr:        baseAddr,
+	}
+}
+
+type upcomingRes struct {
+	ShortURL   string     `json:"short_url"`
+	OrigURL    string     `json:"original_url"`
+	ActiveFrom time.Time  `json:"active_from"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// ServeHTTP implements http.Handler interface for upcoming links endpoint.
+//
+// Expected request format:
+//
+//	GET /api/user/urls/upcoming
+//	Authorization: Bearer <token>
+func (h *UpcomingHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	pairs, err := h.upcomingService.GetUpcomingURLs(req.Context(), uid)
+	if e, ok := err.(errUpcomingNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	if len(pairs) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var resBatch = make([]upcomingRes, len(pairs))
+	for i, pair := range pairs {
+		resBatch[i] = upcomingRes{
+			ShortURL:   h.baseAddr + "/" + string(pair.Short),
+			OrigURL:    string(pair.Orig),
+			ActiveFrom: *pair.ActiveFrom,
+			ExpiresAt:  pair.ExpiresAt,
+		}
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(res).Encode(&resBatch)
+	if err != nil {
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpcomingHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockupcomingServicer(ctrl)
+	mAuth := mocks.NewMockupcomingAuthServicer(ctrl)
+
+	upcomingHandler := NewUpcomingHandler(mServ, mAuth, testBaseAddr)
+
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	pair := testPair
+	pair.ActiveFrom = &from
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testPair.UID, nil)
+		mServ.EXPECT().GetUpcomingURLs(gomock.Any(), testPair.UID).Return([]models.URLPair{pair}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		upcomingHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		resBody, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"short_url":"`+testBaseAddr+"/"+string(testPair.Short)+`","original_url":"`+string(testPair.Orig)+`","active_from":"2030-01-01T00:00:00Z"}]`, string(resBody))
+	})
+
+	t.Run("no upcoming links", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testPair.UID, nil)
+		mServ.EXPECT().GetUpcomingURLs(gomock.Any(), testPair.UID).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		upcomingHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		upcomingHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testPair.UID, nil)
+		mServ.EXPECT().GetUpcomingURLs(gomock.Any(), testPair.UID).Return(nil, errTest)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		upcomingHandler.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+var errNoVariantName = errors.New("variant name is empty")
+
+type updateOptionsServicer interface {
+	GetShortURLFromCtx(context.Context) (models.ShortURL, error)
+	SetURLOptions(context.Context, models.UserID, models.ShortURL, *models.LinkOptions) error
+}
+
+type updateOptionsAuthServicer interface {
+	GetUserIDFromCtx(context.Context) (models.UserID, error)
+}
+
+// UpdateOptionsHandler handles requests to change per-link settings.
+//
+// The handler:
+// 1. Extracts user ID from request context (set by auth middleware)
+// 2. Validates link settings from request body
+// 3. Merges settings into the ones of the user's short URL
+//
+// Response codes:
+//   - 204 No Content: settings updated
+//   - 400 Bad Request: invalid settings
+//   - 404 Not Found: user has no such short URL
+//   - 500 Internal Server Error: processing failure
+type UpdateOptionsHandler struct {
+	updateOptionsService updateOptionsServicer
+	authService          updateOptionsAuthServicer
+}
+
+type errUpdateOptionsNotExist interface {
+	error
+	IsErrNotExist() bool
+}
+
+// NewUpdateOptionsHandler creates new link settings handler instance.
+func NewUpdateOptionsHandler(updateOptionsService updateOptionsServicer, authService updateOptionsAuthServicer) *UpdateOptionsHandler {
+	return &UpdateOptionsHandler{
+		updateOptionsService: updateOptionsService,
+		authService:          authService,
+	}
+}
+
+// ServeHTTP implements http.Handler interface for link settings endpoint.
+//
+// Expected request format:
+//
+//	PUT /api/user/urls/{id}/options
+//	Content-Type: application/json
+//	Authorization: Bearer <token>
+//
+//	{"passthrough": {"query": true, "query_conflict": "keep", "path": true, "utm": {"utm_source": "news"}}}
+func (h *UpdateOptionsHandler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	uid, err := h.authService.GetUserIDFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	shortURL, err := h.updateOptionsService.GetShortURLFromCtx(req.Context())
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	var opts *models.LinkOptions
+	err = json.NewDecoder(req.Body).Decode(&opts)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+	err = validateLinkOptions(opts)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	err = h.updateOptionsService.SetURLOptions(req.Context(), uid, shortURL, opts)
+	if e, ok := err.(errUpdateOptionsNotExist); ok && e.IsErrNotExist() {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		logger.FromContext(req.Context()).Debug("path:"+req.URL.Path, zap.Error(err))
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// validateLinkOptions checks owner provided link settings.
+//
+// Nil settings are valid and remove passthrough settings of the link.
+func validateLinkOptions(opts *models.LinkOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	if pt := opts.Passthrough; pt != nil {
+		switch pt.QueryConflict {
+		case "", models.QueryConflictKeep, models.QueryConflictOverride, models.QueryConflictAppend:
+		default:
+			return fmt.Errorf("unknown query conflict policy %q", pt.QueryConflict)
+		}
+	}
+
+	err := validateDestinations(opts.Destinations)
+	if err != nil {
+		return err
+	}
+
+	return validateRules(opts.Rules)
+}
+
+// validateDestinations checks weighted destinations of a multi-destination link.
+func validateDestinations(dests []models.Destination) error {
+	variants := make(map[string]struct{}, len(dests))
+	for _, dest := range dests {
+		if dest.Variant == "" {
+			return errNoVariantName
+		}
+		if _, ok := variants[dest.Variant]; ok {
+			return fmt.Errorf("duplicate variant %q", dest.Variant)
+		}
+		variants[dest.Variant] = struct{}{}
+
+		if dest.Weight <= 0 {
+			return fmt.Errorf("variant %q: weight must be positive", dest.Variant)
+		}
+		if _, err := url.ParseRequestURI(string(dest.URL)); err != nil {
+			return fmt.Errorf("variant %q: %w", dest.Variant, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRules checks conditional routing rules.
+//
+// Broken daily hours and time zones are rejected here, redirects only
+// log them and skip the rule.
+func validateRules(rules []models.Rule) error {
+	for i, rule := range rules {
+		if _, err := url.ParseRequestURI(string(rule.URL)); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		for _, p := range rule.Platforms {
+			switch p {
+			case models.PlatformIOS, models.PlatformAndroid, models.PlatformDesktop:
+			default:
+				return fmt.Errorf("rule %d: unknown platform %q", i, p)
+			}
+		}
+
+		for _, c := range rule.Countries {
+			if len(c) != 2 {
+				return fmt.Errorf("rule %d: bad country code %q", i, c)
+			}
+		}
+
+		if w := rule.Window; w != nil {
+			if w.From != nil && w.To != nil && !w.From.Before(*w.To) {
+				return fmt.Errorf("rule %d: empty date range", i)
+			}
+			for _, clock := range []string{w.DailyFrom, w.DailyTo} {
+				if clock == "" {
+					continue
+				}
+				if _, err := time.Parse("15:04", clock); err != nil {
+					return fmt.Errorf("rule %d: %w", i, err)
+				}
+			}
+			if _, err := time.LoadLocation(w.Timezone); err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/handlers/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateOptionsHandler_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockupdateOptionsServicer(ctrl)
+	mAuth := mocks.NewMockupdateOptionsAuthServicer(ctrl)
+
+	handler := NewUpdateOptionsHandler(mServ, mAuth)
+
+	testBody := `{"passthrough":{"query":true,"query_conflict":"override","path":true}}`
+	testOpts := &models.LinkOptions{
+		Passthrough: &models.Passthrough{
+			Query:         true,
+			QueryConflict: models.QueryConflictOverride,
+			Path:          true,
+		},
+	}
+
+	serve := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetURLOptions(gomock.Any(), testUserID, testShortURL, testOpts).Return(nil)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("reset options", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetURLOptions(gomock.Any(), testUserID, testShortURL, nil).Return(nil)
+
+		res := serve("null")
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("user id error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(models.UserID(""), errTest)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+
+		res := serve("{")
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("unknown conflict policy", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+
+		res := serve(`{"passthrough":{"query":true,"query_conflict":"merge"}}`)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	invalidRules := map[string]string{
+		"rule bad url":       `{"rules":[{"platforms":["ios"],"url":"store"}]}`,
+		"rule platform":      `{"rules":[{"platforms":["windows"],"url":"https://example.com"}]}`,
+		"rule country":       `{"rules":[{"countries":["DEU"],"url":"https://example.com"}]}`,
+		"rule daily hours":   `{"rules":[{"window":{"daily_from":"25:00"},"url":"https://example.com"}]}`,
+		"rule time zone":     `{"rules":[{"window":{"timezone":"Mars/Olympus"},"url":"https://example.com"}]}`,
+		"rule empty dates":   `{"rules":[{"window":{"from":"2025-12-01T00:00:00Z","to":"2025-11-01T00:00:00Z"},"url":"https://example.com"}]}`,
+		"destination weight": `{"destinations":[{"variant":"a","url":"https://example.com","weight":-1}]}`,
+	}
+	for name, body := range invalidRules {
+		t.Run(name, func(t *testing.T) {
+			mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+			mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+
+			res := serve(body)
+			defer func() {
+				err := res.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+
+	t.Run("valid rules", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).Return(nil)
+
+		res := serve(`{"rules":[{"platforms":["ios"],"languages":["en"],"countries":["us"],"window":{"daily_from":"09:00","daily_to":"18:00","timezone":"America/New_York"},"url":"https://apps.apple.com/app/id1"}]}`)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mErr := mocks.NewMockerrUpdateOptionsNotExist(ctrl)
+		mErr.EXPECT().IsErrNotExist().Return(true)
+		mServ.EXPECT().SetURLOptions(gomock.Any(), testUserID, testShortURL, testOpts).Return(mErr)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("some service error", func(t *testing.T) {
+		mAuth.EXPECT().GetUserIDFromCtx(gomock.Any()).Return(testUserID, nil)
+		mServ.EXPECT().GetShortURLFromCtx(gomock.Any()).Return(testShortURL, nil)
+		mServ.EXPECT().SetURLOptions(gomock.Any(), testUserID, testShortURL, testOpts).Return(errTest)
+
+		res := serve(testBody)
+		defer func() {
+			err := res.Body.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,170 @@
+// Package health reports readiness of the application to serve requests
+// based on checks of its components.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rycln/shorturl/internal/worker"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// defaultCheckTimeout limits duration of a single component check.
+const defaultCheckTimeout = 2 * time.Second
+
+// Readiness statuses of the application.
+const (
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Statuses of a component.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckFunc checks a component returning an error if it is not operational.
+type CheckFunc func(context.Context) error
+
+// Component is the result of a component check.
+type Component struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the readiness of the application and its components.
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+// Ready reports whether the application is ready to serve requests.
+func (r *Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker checks readiness of the application.
+//
+// The application is ready while all registered components are up
+// and it is not shutting down.
+type Checker struct {
+	timeout  time.Duration
+	shutdown atomic.Bool
+
+	mu     sync.RWMutex
+	checks []check
+}
+
+type checkerOption func(*Checker)
+
+// WithCheckTimeout limits duration of every component check.
+func WithCheckTimeout(timeout time.Duration) checkerOption {
+	return func(c *Checker) {
+		c.timeout = timeout
+	}
+}
+
+// NewChecker creates a readiness checker without components.
+func NewChecker(opts ...checkerOption) *Checker {
+	c := &Checker{
+		timeout: defaultCheckTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Register adds a component checked by fn.
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Shutdown marks the application as not ready for the rest of its life,
+// so load balancers stop routing requests before listeners close.
+func (c *Checker) Shutdown() {
+	c.shutdown.Store(true)
+}
+
+// Check runs component checks concurrently and reports readiness.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]Component, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results[i] = Component{Status: StatusUp}
+			if err := ch.fn(ctx); err != nil {
+				results[i] = Component{Status: StatusDown, Error: err.Error()}
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{
+		Status:     StatusReady,
+		Components: make(map[string]Component, len(checks)),
+	}
+	for i, ch := range checks {
+		report.Components[ch.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusNotReady
+		}
+	}
+	if c.shutdown.Load() {
+		report.Status = StatusShuttingDown
+	}
+
+	return report
+}
+
+// servingStatusSetter is implemented by the gRPC health server.
+type servingStatusSetter interface {
+	SetServingStatus(string, healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// Schedule registers a job of the scheduler publishing readiness
+// as the serving status of services on the gRPC health server every period.
+// The empty service name stands for the whole server.
+//
+// The status is published as soon as the scheduler starts.
+func (c *Checker) Schedule(s *worker.Scheduler, period time.Duration, srv servingStatusSetter, services ...string) {
+	job := s.Every("health", period, func(ctx context.Context) error {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if c.Check(ctx).Ready() {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+
+		for _, service := range services {
+			srv.SetServingStatus(service, status)
+		}
+		return nil
+	})
+	job.Trigger()
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/health/mocks"
+	"github.com/rycln/shorturl/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var errTest = errors.New("test error")
+
+func up(context.Context) error {
+	return nil
+}
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		c := NewChecker()
+		c.Register("storage", up)
+		c.Register("worker", up)
+
+		report := c.Check(context.Background())
+		assert.True(t, report.Ready())
+		assert.Equal(t, &Report{
+			Status: StatusReady,
+			Components: map[string]Component{
+				"storage": {Status: StatusUp},
+				"worker":  {Status: StatusUp},
+			},
+		}, report)
+	})
+
+	t.Run("component down", func(t *testing.T) {
+		c := NewChecker()
+		c.Register("storage", func(context.Context) error {
+			return errTest
+		})
+		c.Register("worker", up)
+
+		report := c.Check(context.Background())
+		assert.False(t, report.Ready())
+		assert.Equal(t, StatusNotReady, report.Status)
+		assert.Equal(t, Component{Status: StatusDown, Error: errTest.Error()}, report.Components["storage"])
+		assert.Equal(t, Component{Status: StatusUp}, report.Components["worker"])
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := NewChecker(WithCheckTimeout(10 * time.Millisecond))
+		c.Register("storage", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report := c.Check(context.Background())
+		assert.Equal(t, StatusDown, report.Components["storage"].Status)
+	})
+
+	t.Run("shutting down", func(t *testing.T) {
+		c := NewChecker()
+		c.Register("storage", up)
+		c.Shutdown()
+
+		report := c.Check(context.Background())
+		assert.False(t, report.Ready())
+		assert.Equal(t, StatusShuttingDown, report.Status)
+		assert.Equal(t, StatusUp, report.Components["storage"].Status)
+	})
+}
+
+func TestChecker_Schedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		err    error
+		status healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:   "serving",
+			err:    nil,
+			status: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:   "not serving",
+			err:    errTest,
+			status: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mSrv := mocks.NewMockservingStatusSetter(ctrl)
+
+			c := NewChecker()
+			c.Register("storage", func(context.Context) error {
+				return test.err
+			})
+
+			published := make(chan struct{})
+			gomock.InOrder(
+				mSrv.EXPECT().SetServingStatus("", test.status),
+				mSrv.EXPECT().SetServingStatus("test", test.status).Do(func(string, healthpb.HealthCheckResponse_ServingStatus) {
+					close(published)
+				}),
+			)
+
+			s := worker.NewScheduler()
+			c.Schedule(s, time.Hour, mSrv, "", "test")
+
+			// The status is published on start without waiting for the period.
+			s.Start()
+			<-published
+
+			require.NoError(t, s.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// MockservingStatusSetter is a mock of servingStatusSetter interface.
+type MockservingStatusSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockservingStatusSetterMockRecorder
+}
+
+// MockservingStatusSetterMockRecorder is the mock recorder for MockservingStatusSetter.
+type MockservingStatusSetterMockRecorder struct {
+	mock *MockservingStatusSetter
+}
+
+// NewMockservingStatusSetter creates a new mock instance.
+func NewMockservingStatusSetter(ctrl *gomock.Controller) *MockservingStatusSetter {
+	mock := &MockservingStatusSetter{ctrl: ctrl}
+	mock.recorder = &MockservingStatusSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockservingStatusSetter) EXPECT() *MockservingStatusSetterMockRecorder {
+	return m.recorder
+}
+
+// SetServingStatus mocks base method.
+func (m *MockservingStatusSetter) SetServingStatus(arg0 string, arg1 grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetServingStatus", arg0, arg1)
+}
+
+// SetServingStatus indicates an expected call of SetServingStatus.
+func (mr *MockservingStatusSetterMockRecorder) SetServingStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServingStatus", reflect.TypeOf((*MockservingStatusSetter)(nil).SetServingStatus), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"go.uber.org/zap"
+)
+
+// scope holds request-scoped application and access loggers.
+type scope struct {
+	log    *zap.Logger
+	access *zap.Logger
+}
+
+// scopeFrom returns loggers stored in ctx or the global ones.
+func scopeFrom(ctx context.Context) scope {
+	if s, ok := ctx.Value(contextkeys.Logger).(scope); ok {
+		return s
+	}
+	return scope{log: Log, access: Access}
+}
+
+// WithContext returns a copy of ctx carrying l as the request-scoped logger
+// of both application and access entries.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextkeys.Logger, scope{log: l, access: l})
+}
+
+// FromContext returns the request-scoped logger stored in ctx,
+// or the global Log if there is none.
+func FromContext(ctx context.Context) *zap.Logger {
+	return scopeFrom(ctx).log
+}
+
+// AccessFromContext returns the request-scoped access logger stored in ctx,
+// or the global Access if there is none.
+func AccessFromContext(ctx context.Context) *zap.Logger {
+	return scopeFrom(ctx).access
+}
+
+// With returns a copy of ctx whose request-scoped loggers
+// add fields to every entry.
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	s := scopeFrom(ctx)
+	return context.WithValue(ctx, contextkeys.Logger, scope{
+		log:    s.log.With(fields...),
+		access: s.access.With(fields...),
+	})
+}
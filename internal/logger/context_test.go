@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("global logger", func(t *testing.T) {
+		assert.Same(t, Log, FromContext(context.Background()))
+	})
+
+	t.Run("request-scoped logger", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+
+		ctx := WithContext(context.Background(), zap.New(core))
+		ctx = With(ctx, zap.String("request_id", "id"))
+		ctx = With(ctx, zap.String("user_id", "user"))
+		FromContext(ctx).Info("test")
+
+		entries := logs.All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, map[string]any{
+				"request_id": "id",
+				"user_id":    "user",
+			}, entries[0].ContextMap())
+		}
+	})
+}
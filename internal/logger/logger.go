@@ -1,32 +1,196 @@
 // Package logger provides a thread-safe singleton logger instance
 // with centralized configuration for the application.
+//
+// Application entries are written by Log, HTTP requests and gRPC calls are
+// recorded by Access. The level of Log can be changed at runtime through Level.
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"syscall"
+	"time"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Supported log formats.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
 )
 
+// Sampling keeps the first samplingFirst entries with the same level and message
+// every second and then every samplingThereafter-th of them.
+const (
+	samplingFirst      = 100
+	samplingThereafter = 100
+)
+
+var errUnknownFormat = errors.New("unknown log format")
+
 // Log is the global logger instance implementing the Logger interface.
 var Log *zap.Logger = zap.NewNop()
 
-// LogInit configures the global Log instance.
-func LogInit(level string) error {
-	lvl, err := zap.ParseAtomicLevel(level)
-	if err != nil {
-		return err
+// Access is the global logger of HTTP requests and gRPC calls.
+//
+// It always records entries of info level, regardless of Level.
+var Access *zap.Logger = zap.NewNop()
+
+// Level is the level of Log. It can be changed at runtime, its ServeHTTP
+// method reports the level on GET and changes it on PUT requests.
+var Level = zap.NewAtomicLevel()
+
+// files are rotated log files opened by LogInit.
+var files []*lumberjack.Logger
+
+// options holds logger configuration.
+type options struct {
+	format     string
+	sampling   bool
+	file       string
+	accessFile string
+	maxSize    int
+	maxAge     time.Duration
+	maxBackups int
+}
+
+// option configures the logger.
+type option func(*options)
+
+// WithFormat sets the encoding of entries (console|json).
+func WithFormat(format string) option {
+	return func(o *options) {
+		o.format = format
 	}
+}
 
-	cfg := zap.NewDevelopmentConfig()
-	cfg.Level = lvl
-	if cfg.Level.Level() != zap.DebugLevel {
-		cfg.DisableCaller = true
+// WithSampling enables sampling of repeated entries to limit the logging
+// overhead under load.
+func WithSampling(enabled bool) option {
+	return func(o *options) {
+		o.sampling = enabled
 	}
+}
+
+// WithFile writes application entries to the rotated file instead of stderr.
+func WithFile(path string) option {
+	return func(o *options) {
+		o.file = path
+	}
+}
+
+// WithAccessFile writes access entries to the rotated file instead of
+// the application log.
+func WithAccessFile(path string) option {
+	return func(o *options) {
+		o.accessFile = path
+	}
+}
 
-	zl, err := cfg.Build()
+// WithRotation sets limits of log files: a file is rotated once it exceeds
+// maxSize megabytes, rotated files are removed when older than maxAge or
+// exceeding maxBackups. Zero values disable the corresponding limit,
+// zero maxSize uses the default of 100 megabytes.
+func WithRotation(maxSize int, maxAge time.Duration, maxBackups int) option {
+	return func(o *options) {
+		o.maxSize = maxSize
+		o.maxAge = maxAge
+		o.maxBackups = maxBackups
+	}
+}
+
+// LogInit configures the global Log and Access instances.
+//
+// By default entries are written to stderr in human-readable console format.
+func LogInit(level string, opts ...option) error {
+	lvl, err := zapcore.ParseLevel(level)
 	if err != nil {
 		return err
 	}
 
-	Log = zl
+	o := &options{
+		format: FormatConsole,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		encoder  zapcore.Encoder
+		zapOpts  []zap.Option
+		stackLvl = zapcore.ErrorLevel
+	)
+	switch o.format {
+	case FormatConsole, "":
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+		zapOpts = append(zapOpts, zap.Development())
+		stackLvl = zapcore.WarnLevel
+	case FormatJSON:
+		encCfg := zap.NewProductionEncoderConfig()
+		encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encCfg)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownFormat, o.format)
+	}
+	zapOpts = append(zapOpts, zap.AddStacktrace(stackLvl), zap.ErrorOutput(zapcore.Lock(os.Stderr)))
+	if lvl == zapcore.DebugLevel {
+		zapOpts = append(zapOpts, zap.AddCaller())
+	}
+
+	var opened []*lumberjack.Logger
+	sink := func(path string) zapcore.WriteSyncer {
+		if path == "" {
+			return zapcore.Lock(os.Stderr)
+		}
+		f := &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    o.maxSize,
+			MaxAge:     int(math.Ceil(o.maxAge.Hours() / 24)),
+			MaxBackups: o.maxBackups,
+		}
+		opened = append(opened, f)
+		return zapcore.AddSync(f)
+	}
+
+	out := sink(o.file)
+	accessOut := out
+	if o.accessFile != "" {
+		accessOut = sink(o.accessFile)
+	}
+
+	Level.SetLevel(lvl)
+	core := zapcore.NewCore(encoder, out, Level)
+	accessCore := zapcore.NewCore(encoder.Clone(), accessOut, zapcore.InfoLevel)
+	if o.sampling {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, samplingFirst, samplingThereafter)
+		accessCore = zapcore.NewSamplerWithOptions(accessCore, time.Second, samplingFirst, samplingThereafter)
+	}
+
+	Log = zap.New(core, zapOpts...)
+	Access = zap.New(accessCore, zapOpts...).Named("access")
+	files = opened
+
 	return nil
 }
+
+// Sync flushes buffered entries and closes log files.
+func Sync() error {
+	var errs []error
+	for _, l := range []*zap.Logger{Log, Access} {
+		// Syncing stderr fails with EINVAL when it is not a file.
+		if err := l.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) {
+			errs = append(errs, err)
+		}
+	}
+	for _, f := range files {
+		errs = append(errs, f.Close())
+	}
+	files = nil
+
+	return errors.Join(errs...)
+}
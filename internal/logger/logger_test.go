@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogInit(t *testing.T) {
+	t.Cleanup(func() {
+		Log, Access = zap.NewNop(), zap.NewNop()
+		Level.SetLevel(zapcore.InfoLevel)
+	})
+
+	t.Run("json files", func(t *testing.T) {
+		dir := t.TempDir()
+		appFile := filepath.Join(dir, "app.log")
+		accessFile := filepath.Join(dir, "access.log")
+
+		err := LogInit("info",
+			WithFormat(FormatJSON),
+			WithFile(appFile),
+			WithAccessFile(accessFile),
+		)
+		require.NoError(t, err)
+
+		Log.Debug("skipped")
+		Log.Info("app")
+		Access.Info("request")
+		require.NoError(t, Sync())
+
+		entries := readEntries(t, appFile)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "app", entries[0]["msg"])
+
+		entries = readEntries(t, accessFile)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "request", entries[0]["msg"])
+		assert.Equal(t, "access", entries[0]["logger"])
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "app.log")
+
+		err := LogInit("info", WithFormat(FormatJSON), WithFile(file), WithSampling(true))
+		require.NoError(t, err)
+
+		for i := 0; i < samplingFirst+samplingThereafter; i++ {
+			Log.Info("repeated")
+		}
+		require.NoError(t, Sync())
+
+		assert.Len(t, readEntries(t, file), samplingFirst+1)
+	})
+
+	t.Run("runtime level", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "app.log")
+
+		err := LogInit("info", WithFormat(FormatJSON), WithFile(file))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"level":"debug"}`))
+		w := httptest.NewRecorder()
+		Level.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, zapcore.DebugLevel, Level.Level())
+
+		Log.Debug("debug")
+		require.NoError(t, Sync())
+		assert.Len(t, readEntries(t, file), 1)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := LogInit("info", WithFormat("xml"))
+		assert.ErrorIs(t, err, errUnknownFormat)
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		err := LogInit("verbose")
+		assert.Error(t, err)
+	})
+}
+
+func readEntries(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
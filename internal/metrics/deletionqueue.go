@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rycln/shorturl/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type deletionQueueStater interface {
+	Stats() models.DeletionQueueStats
+}
+
+var (
+	deletionQueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "depth"),
+		"Number of queued deletion requests.", nil, nil)
+	deletionQueueCapacityDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "capacity"),
+		"Maximum number of queued deletion requests.", nil, nil)
+	deletionQueueInFlightDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "in_flight"),
+		"Number of deletion requests of the batch being deleted.", nil, nil)
+	deletionQueueDeletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "deleted_total"),
+		"Number of deleted URLs.", nil, nil)
+	deletionQueueRejectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "rejected_total"),
+		"Number of deletion requests rejected because the queue was full.", nil, nil)
+	deletionQueueFailedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "deletion_queue", "failed_batches_total"),
+		"Number of failed deletion batches.", nil, nil)
+)
+
+// DeletionQueueCollector exposes the deletion queue state.
+type DeletionQueueCollector struct {
+	stater deletionQueueStater
+}
+
+// NewDeletionQueueCollector creates new collector of the deletion queue state.
+func NewDeletionQueueCollector(stater deletionQueueStater) *DeletionQueueCollector {
+	return &DeletionQueueCollector{
+		stater: stater,
+	}
+}
+
+// Describe sends descriptors of the deletion queue metrics.
+func (c *DeletionQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- deletionQueueDepthDesc
+	ch <- deletionQueueCapacityDesc
+	ch <- deletionQueueInFlightDesc
+	ch <- deletionQueueDeletedDesc
+	ch <- deletionQueueRejectedDesc
+	ch <- deletionQueueFailedDesc
+}
+
+// Collect sends current values of the deletion queue metrics.
+func (c *DeletionQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stater.Stats()
+
+	ch <- prometheus.MustNewConstMetric(deletionQueueDepthDesc, prometheus.GaugeValue, float64(stats.Depth))
+	ch <- prometheus.MustNewConstMetric(deletionQueueCapacityDesc, prometheus.GaugeValue, float64(stats.Capacity))
+	ch <- prometheus.MustNewConstMetric(deletionQueueInFlightDesc, prometheus.GaugeValue, float64(stats.InFlight))
+	ch <- prometheus.MustNewConstMetric(deletionQueueDeletedDesc, prometheus.CounterValue, float64(stats.Deleted))
+	ch <- prometheus.MustNewConstMetric(deletionQueueRejectedDesc, prometheus.CounterValue, float64(stats.Rejected))
+	ch <- prometheus.MustNewConstMetric(deletionQueueFailedDesc, prometheus.CounterValue, float64(stats.FailedBatches))
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rycln/shorturl/internal/metrics/mocks"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionQueueCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStater := mocks.NewMockdeletionQueueStater(ctrl)
+	mStater.EXPECT().Stats().Return(models.DeletionQueueStats{
+		Depth:         3,
+		Capacity:      10,
+		InFlight:      2,
+		Deleted:       5,
+		Rejected:      1,
+		FailedBatches: 4,
+		RetryIn:       time.Second,
+	})
+
+	expected := `
+# HELP shorturl_deletion_queue_capacity Maximum number of queued deletion requests.
+# TYPE shorturl_deletion_queue_capacity gauge
+shorturl_deletion_queue_capacity 10
+# HELP shorturl_deletion_queue_deleted_total Number of deleted URLs.
+# TYPE shorturl_deletion_queue_deleted_total counter
+shorturl_deletion_queue_deleted_total 5
+# HELP shorturl_deletion_queue_depth Number of queued deletion requests.
+# TYPE shorturl_deletion_queue_depth gauge
+shorturl_deletion_queue_depth 3
+# HELP shorturl_deletion_queue_failed_batches_total Number of failed deletion batches.
+# TYPE shorturl_deletion_queue_failed_batches_total counter
+shorturl_deletion_queue_failed_batches_total 4
+# HELP shorturl_deletion_queue_in_flight Number of deletion requests of the batch being deleted.
+# TYPE shorturl_deletion_queue_in_flight gauge
+shorturl_deletion_queue_in_flight 2
+# HELP shorturl_deletion_queue_rejected_total Number of deletion requests rejected because the queue was full.
+# TYPE shorturl_deletion_queue_rejected_total counter
+shorturl_deletion_queue_rejected_total 1
+`
+	err := testutil.CollectAndCompare(NewDeletionQueueCollector(mStater), strings.NewReader(expected))
+	assert.NoError(t, err)
+}
+
+func TestReplace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := mocks.NewMockdeletionQueueStater(ctrl)
+	second := mocks.NewMockdeletionQueueStater(ctrl)
+	second.EXPECT().Stats().Return(models.DeletionQueueStats{Depth: 7})
+
+	// A new application instance takes the metrics over.
+	require.NoError(t, Replace(NewDeletionQueueCollector(first)))
+	require.NoError(t, Replace(NewDeletionQueueCollector(second)))
+	defer Registry.Unregister(NewDeletionQueueCollector(second))
+
+	expected := `
+# HELP shorturl_deletion_queue_depth Number of queued deletion requests.
+# TYPE shorturl_deletion_queue_depth gauge
+shorturl_deletion_queue_depth 7
+`
+	err := testutil.GatherAndCompare(Registry, strings.NewReader(expected), "shorturl_deletion_queue_depth")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,118 @@
+// Package metrics provides Prometheus metrics of the application
+// collected into a single registry exposed in Prometheus text format.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes names of all application metrics.
+const namespace = "shorturl"
+
+// Registry holds all metrics of the application.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	// HTTPRequests counts handled HTTP requests by method, route pattern and status code.
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of handled HTTP requests.",
+	}, []string{"method", "route", "code"})
+
+	// HTTPDuration observes HTTP request latency by method, route pattern and status code.
+	HTTPDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+
+	// GRPCRequests counts handled gRPC calls by full method name and status code.
+	GRPCRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of handled gRPC calls.",
+	}, []string{"method", "code"})
+
+	// GRPCDuration observes gRPC call latency by full method name and status code.
+	GRPCDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "gRPC call latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// StorageDuration observes storage operation latency by backend and operation.
+	StorageDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "storage",
+		Name:      "operation_duration_seconds",
+		Help:      "Storage operation latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "operation"})
+
+	// StorageErrors counts failed storage operations by backend and operation.
+	StorageErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "storage",
+		Name:      "operation_errors_total",
+		Help:      "Number of failed storage operations.",
+	}, []string{"backend", "operation"})
+
+	// Redirects counts redirect requests by result.
+	Redirects = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redirects_total",
+		Help:      "Number of redirect requests by result.",
+	}, []string{"result"})
+)
+
+// Redirect results.
+const (
+	RedirectFollowed         = "followed"
+	RedirectGone             = "gone"
+	RedirectNotActive        = "not_active"
+	RedirectInvalidPath      = "invalid_path"
+	RedirectPasswordRequired = "password_required"
+	RedirectWrongPassword    = "wrong_password"
+	RedirectTooManyAttempts  = "too_many_attempts"
+	RedirectError            = "error"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Replace registers the collector in Registry replacing the one with
+// the same metrics registered before, e.g. by a previous application instance.
+func Replace(c prometheus.Collector) error {
+	err := Registry.Register(c)
+	var registered prometheus.AlreadyRegisteredError
+	if !errors.As(err, &registered) {
+		return err
+	}
+
+	Registry.Unregister(registered.ExistingCollector)
+	return Registry.Register(c)
+}
+
+// Handler serves the metrics in Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -24,7 +24,7 @@ type authServicer interface {
 // 1. Extracts and validates JWT token from Authorization header
 // 2. If valid:
 //   - Extracts user ID from token claims
-//   - Stores user ID in request context
+//   - Stores user ID in request context and its request-scoped logger
 //
 // 3. If invalid/missing:
 //   - Generates new user ID
@@ -52,7 +52,7 @@ func (m *AuthMiddleware) JWT(h http.Handler) http.Handler {
 		if header := r.Header.Get("Authorization"); header != "" {
 			uid, err := m.authService.ParseIDFromAuthHeader(header)
 			if err != nil {
-				logger.Log.Debug("auth middleware", zap.Error(err))
+				logger.FromContext(r.Context()).Debug("auth middleware", zap.Error(err))
 			} else {
 				userID = uid
 			}
@@ -64,7 +64,7 @@ func (m *AuthMiddleware) JWT(h http.Handler) http.Handler {
 			jwtString, err := m.authService.NewJWTString(userID)
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
-				logger.Log.Debug("auth middleware", zap.Error(err))
+				logger.FromContext(r.Context()).Debug("auth middleware", zap.Error(err))
 				return
 			}
 
@@ -76,7 +76,8 @@ func (m *AuthMiddleware) JWT(h http.Handler) http.Handler {
 			w.Header().Set("Authorization", "Bearer "+jwtString)
 		}
 
-		ctx := context.WithValue(r.Context(), contextkeys.UserID, userID)
+		ctx := logger.With(r.Context(), zap.String("user_id", string(userID)))
+		ctx = context.WithValue(ctx, contextkeys.UserID, userID)
 		h.ServeHTTP(w, r.WithContext(ctx))
 	}
 
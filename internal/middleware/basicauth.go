@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+)
+
+// BasicAuth checks HTTP basic authentication credentials of the request.
+// If user is empty, all requests are allowed.
+//
+// Credentials are compared in constant time.
+func BasicAuth(realm, user, password string) func(http.Handler) http.Handler {
+	wantUser := sha256.Sum256([]byte(user))
+	wantPassword := sha256.Sum256([]byte(password))
+
+	return func(next http.Handler) http.Handler {
+		if user == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotUser, gotPassword, ok := r.BasicAuth()
+			if ok {
+				u := sha256.Sum256([]byte(gotUser))
+				p := sha256.Sum256([]byte(gotPassword))
+				userOK := subtle.ConstantTimeCompare(u[:], wantUser[:]) == 1
+				passwordOK := subtle.ConstantTimeCompare(p[:], wantPassword[:]) == 1
+				ok = userOK && passwordOK
+			}
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
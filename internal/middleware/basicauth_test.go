@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		user           string
+		password       string
+		reqUser        string
+		reqPassword    string
+		noAuth         bool
+		expectedStatus int
+	}{
+		{
+			name:           "Valid credentials",
+			user:           "admin",
+			password:       "secret",
+			reqUser:        "admin",
+			reqPassword:    "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Wrong password",
+			user:           "admin",
+			password:       "secret",
+			reqUser:        "admin",
+			reqPassword:    "wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong user",
+			user:           "admin",
+			password:       "secret",
+			reqUser:        "root",
+			reqPassword:    "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Missing credentials",
+			user:           "admin",
+			password:       "secret",
+			noAuth:         true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Authentication disabled",
+			user:           "",
+			noAuth:         true,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if !tt.noAuth {
+				req.SetBasicAuth(tt.reqUser, tt.reqPassword)
+			}
+			w := httptest.NewRecorder()
+
+			BasicAuth("test", tt.user, tt.password)(ok).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, `Basic realm="test", charset="UTF-8"`, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
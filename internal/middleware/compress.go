@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"compress/gzip"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -45,6 +46,23 @@ func (c *compressWriter) WriteHeader(statusCode int) {
 	c.w.WriteHeader(statusCode)
 }
 
+// Flush writes buffered compressed data to the client.
+func (c *compressWriter) Flush() {
+	if err := c.zw.Flush(); err != nil {
+		logger.Log.Debug("compress middleware writer flush error", zap.Error(err))
+		return
+	}
+	err := http.NewResponseController(c.w).Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Log.Debug("compress middleware writer flush error", zap.Error(err))
+	}
+}
+
+// Unwrap returns the original response writer for http.ResponseController.
+func (c *compressWriter) Unwrap() http.ResponseWriter {
+	return c.w
+}
+
 // Close flushes compressed data and releases resources.
 //
 // Must be called to ensure all data is properly written.
@@ -106,7 +124,7 @@ func Compress(h http.Handler) http.Handler {
 			defer func() {
 				err := cw.Close()
 				if err != nil {
-					logger.Log.Debug("compress middleware writer close error", zap.Error(err))
+					logger.FromContext(r.Context()).Debug("compress middleware writer close error", zap.Error(err))
 				}
 			}()
 		}
@@ -117,14 +135,14 @@ func Compress(h http.Handler) http.Handler {
 			cr, err := newCompressReader(r.Body)
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
-				logger.Log.Debug("compress middleware", zap.Error(err))
+				logger.FromContext(r.Context()).Debug("compress middleware", zap.Error(err))
 				return
 			}
 			r.Body = cr
 			defer func() {
 				err = cr.Close()
 				if err != nil {
-					logger.Log.Debug("compress middleware reader close error", zap.Error(err))
+					logger.FromContext(r.Context()).Debug("compress middleware reader close error", zap.Error(err))
 				}
 			}()
 		}
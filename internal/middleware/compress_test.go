@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompress_Flush(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	h := Logger(Metrics(Compress(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		_, err := res.Write([]byte("partial"))
+		require.NoError(t, err)
+
+		err = http.NewResponseController(res).Flush()
+		require.NoError(t, err)
+		assert.True(t, w.Flushed)
+		assert.NotZero(t, w.Body.Len())
+	}))))
+
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	h.ServeHTTP(w, req)
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "partial", string(body))
+}
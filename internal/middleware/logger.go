@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -33,6 +34,19 @@ func (r *loggingResponseWriter) WriteHeader(statusCode int) {
 	r.responseData.status = statusCode
 }
 
+// Flush sends buffered data to the client if the wrapped writer supports it.
+func (r *loggingResponseWriter) Flush() {
+	err := http.NewResponseController(r.ResponseWriter).Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Log.Debug("logging response writer flush error", zap.Error(err))
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (r *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Logger is middleware that logs HTTP request/response details.
 //
 // Logs the following information for each request:
@@ -41,6 +55,9 @@ func (r *loggingResponseWriter) WriteHeader(statusCode int) {
 // - Response body size (bytes)
 // - Request processing duration
 //
+// Entries are written by the request-scoped access logger, so Logger must be
+// used after RequestID to correlate them with the request.
+//
 // Usage:
 //
 //	r := chi.NewRouter()
@@ -66,7 +83,7 @@ func Logger(h http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
-		logger.Log.Info("Req/Res Log",
+		logger.AccessFromContext(r.Context()).Info("Req/Res Log",
 			zap.String("url", r.RequestURI),
 			zap.String("method", r.Method),
 			zap.Int("status", responseData.status),
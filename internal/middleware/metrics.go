@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rycln/shorturl/internal/metrics"
+)
+
+// unmatchedRoute labels requests which matched no route.
+const unmatchedRoute = "unmatched"
+
+// Metrics is middleware that records HTTP request metrics.
+//
+// Requests are counted and timed by method, route pattern and status code.
+// Route patterns rather than paths keep the number of series bounded.
+// Must be used by the root chi router.
+func Metrics(h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		responseData := &responseData{
+			status: 0,
+			size:   0,
+		}
+		lw := loggingResponseWriter{
+			ResponseWriter: w,
+			responseData:   responseData,
+		}
+		h.ServeHTTP(&lw, r)
+
+		route := routePattern(r)
+
+		status := responseData.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		code := strconv.Itoa(status)
+
+		metrics.HTTPRequests.WithLabelValues(r.Method, route, code).Inc()
+		metrics.HTTPDuration.WithLabelValues(r.Method, route, code).Observe(time.Since(start).Seconds())
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// routePattern returns the pattern of the route which handled the request.
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.RoutePattern() == "" {
+		return unmatchedRoute
+	}
+	return rctx.RoutePattern()
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rycln/shorturl/internal/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Metrics)
+	r.Get("/{short}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+	r.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		route  string
+		status string
+	}{
+		{
+			name:   "route pattern",
+			path:   "/abc",
+			route:  "/{short}",
+			status: "307",
+		},
+		{
+			name:   "implicit status",
+			path:   "/ping",
+			route:  "/ping",
+			status: "200",
+		},
+		{
+			name:   "unmatched",
+			path:   "/a/b",
+			route:  unmatchedRoute,
+			status: "404",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(http.MethodGet, test.route, test.status)
+			before := testutil.ToFloat64(counter)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/rycln/shorturl/internal/requestid"
+)
+
+// RequestID is middleware that assigns an ID to every request.
+//
+// A valid X-Request-ID header of the request is reused, otherwise
+// a new ID is generated. The ID is returned in the X-Request-ID response
+// header and stored in the request context together with a request-scoped
+// logger, see logger.FromContext. Must be used after Tracing, so that
+// log entries carry the trace ID.
+func RequestID(h http.Handler) http.Handler {
+	id := func(w http.ResponseWriter, r *http.Request) {
+		reqID := requestid.Resolve(r.Header.Get(requestid.Header))
+		w.Header().Set(requestid.Header, reqID)
+
+		ctx := requestid.NewContext(r.Context(), reqID)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}
+
+	return http.HandlerFunc(id)
+}
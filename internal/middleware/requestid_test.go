@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rycln/shorturl/internal/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	var gotID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = requestid.FromContext(r.Context())
+	}))
+
+	t.Run("incoming id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestid.Header, "test-id")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(t, "test-id", gotID)
+		assert.Equal(t, "test-id", w.Header().Get(requestid.Header))
+	})
+
+	t.Run("generated id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestid.Header, "bad id")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		_, err := uuid.Parse(gotID)
+		assert.NoError(t, err)
+		assert.Equal(t, gotID, w.Header().Get(requestid.Header))
+	})
+}
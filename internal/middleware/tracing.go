@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing is middleware that traces HTTP requests.
+//
+// A request continues the trace from its W3C traceparent header or starts
+// a new one. The span is named by the method and the route pattern once
+// the request is routed. Must be used by the root chi router.
+func Tracing(h http.Handler) http.Handler {
+	name := func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+
+		route := routePattern(r)
+
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route))
+	}
+
+	return otelhttp.NewHandler(http.HandlerFunc(name), "http",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+	)
+}
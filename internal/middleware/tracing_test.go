@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestTracing(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := chi.NewRouter()
+	r.Use(Tracing)
+	r.Get("/{short}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+
+	t.Run("new trace", func(t *testing.T) {
+		exp.Reset()
+
+		req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GET /{short}", spans[0].Name)
+		assert.False(t, spans[0].Parent.IsValid())
+		assert.Contains(t, spans[0].Attributes, semconv.HTTPRoute("/{short}"))
+	})
+
+	t.Run("propagated trace", func(t *testing.T) {
+		exp.Reset()
+
+		const (
+			traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+			spanID  = "00f067aa0ba902b7"
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+		req.Header.Set("traceparent", "00-"+traceID+"-"+spanID+"-01")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, traceID, spans[0].SpanContext.TraceID().String())
+		assert.Equal(t, spanID, spans[0].Parent.SpanID().String())
+		assert.True(t, spans[0].Parent.IsRemote())
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		exp.Reset()
+
+		req := httptest.NewRequest(http.MethodGet, "/a/b", nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GET "+unmatchedRoute, spans[0].Name)
+	})
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// BackupManifest describes a backup archive.
+type BackupManifest struct {
+	// Version is the archive format version.
+	Version int `json:"version"`
+
+	// CreatedAt is the moment the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Pairs and Tombstones are the numbers of records in the archive.
+	Pairs      int64 `json:"pairs"`
+	Tombstones int64 `json:"tombstones"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ImportRecord is a single link read from an import file or stream.
+type ImportRecord struct {
+	// Row is the 1-based position of the record in the import.
+	Row int
+
+	Orig OrigURL
+
+	// Short is the custom slug. Empty means it is generated from Orig.
+	Short ShortURL
+
+	Tags      []string
+	ExpiresAt *time.Time
+
+	// Err is set when the record could not be parsed.
+	// Such records are reported invalid without being stored.
+	Err error
+}
+
+// ImportStatus is the outcome of importing a single record.
+type ImportStatus string
+
+// Import record outcomes.
+const (
+	ImportCreated  ImportStatus = "created"
+	ImportConflict ImportStatus = "conflict"
+	ImportInvalid  ImportStatus = "invalid"
+)
+
+// ImportResult reports the outcome of importing a single record.
+type ImportResult struct {
+	Row    int
+	Short  ShortURL
+	Orig   OrigURL
+	Status ImportStatus
+
+	// Reason explains why the record was not created.
+	Reason string
+}
@@ -0,0 +1,155 @@
+package models
+
+import "time"
+
+// Query parameter conflict policies for passthrough redirects.
+const (
+	// QueryConflictKeep keeps the destination value when the request repeats a parameter.
+	QueryConflictKeep = "keep"
+
+	// QueryConflictOverride replaces the destination value with the request one.
+	QueryConflictOverride = "override"
+
+	// QueryConflictAppend keeps both values.
+	QueryConflictAppend = "append"
+)
+
+// Visitor platforms recognised by routing rules.
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformDesktop = "desktop"
+)
+
+// LinkOptions holds per-link redirect settings configured by the link owner.
+//
+// A nil *LinkOptions means plain redirect to the stored original URL.
+type LinkOptions struct {
+	Passthrough  *Passthrough  `json:"passthrough,omitempty"`
+	Destinations []Destination `json:"destinations,omitempty"`
+	Rules        []Rule        `json:"rules,omitempty"`
+
+	// PasswordHash is bcrypt hash of the password protecting the link.
+	// It is managed by the service and never accepted from clients.
+	PasswordHash string `json:"password_hash,omitempty"`
+}
+
+// Passthrough describes which parts of the incoming redirect request
+// are carried over to the destination URL.
+type Passthrough struct {
+	// Query enables merging of incoming query parameters into the destination.
+	Query bool `json:"query"`
+
+	// QueryConflict selects how repeated parameters are resolved
+	// (keep|override|append). Empty value means keep.
+	QueryConflict string `json:"query_conflict,omitempty"`
+
+	// Path enables appending trailing path segments after the slug
+	// to the destination path.
+	Path bool `json:"path"`
+
+	// UTM contains tags injected into every destination URL.
+	UTM *UTMTags `json:"utm,omitempty"`
+}
+
+// UTMTags is a set of standard campaign tracking parameters.
+type UTMTags struct {
+	Source   string `json:"utm_source,omitempty"`
+	Medium   string `json:"utm_medium,omitempty"`
+	Campaign string `json:"utm_campaign,omitempty"`
+	Term     string `json:"utm_term,omitempty"`
+	Content  string `json:"utm_content,omitempty"`
+}
+
+// Destination is one of weighted landing pages of a multi-destination link.
+//
+// Visitors are split between destinations proportionally to their weights.
+type Destination struct {
+	// Variant is a unique name of the destination within the link.
+	Variant string `json:"variant"`
+
+	// URL is the landing page of the variant.
+	URL OrigURL `json:"url"`
+
+	// Weight is a positive share of traffic sent to the variant.
+	Weight int `json:"weight"`
+}
+
+// Rule routes visitors matching all of its conditions to a separate URL.
+//
+// Rules are checked in order and the first matching one wins.
+// Empty condition matches any visitor.
+type Rule struct {
+	// Platforms lists visitor platforms (ios|android|desktop).
+	Platforms []string `json:"platforms,omitempty"`
+
+	// Languages lists language tags accepted by the visitor browser.
+	// A primary tag ("en") matches any of its regional variants ("en-GB").
+	Languages []string `json:"languages,omitempty"`
+
+	// Countries lists ISO 3166-1 alpha-2 country codes of the visitor.
+	Countries []string `json:"countries,omitempty"`
+
+	// Window limits the rule to a period of time.
+	Window *TimeWindow `json:"window,omitempty"`
+
+	// URL is the landing page for matching visitors.
+	URL OrigURL `json:"url"`
+}
+
+// TimeWindow describes a date range and/or daily hours.
+type TimeWindow struct {
+	// From is the inclusive start of the date range.
+	From *time.Time `json:"from,omitempty"`
+
+	// To is the exclusive end of the date range.
+	To *time.Time `json:"to,omitempty"`
+
+	// DailyFrom and DailyTo limit time of day (HH:MM). The range
+	// may wrap over midnight, e.g. from 22:00 to 06:00.
+	DailyFrom string `json:"daily_from,omitempty"`
+	DailyTo   string `json:"daily_to,omitempty"`
+
+	// Timezone is an IANA time zone name for daily hours. Empty means UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// RuleTest describes a sample visitor routing rules are evaluated against
+// without following the link.
+type RuleTest struct {
+	// UserAgent is the sample client identification string.
+	UserAgent string `json:"user_agent,omitempty"`
+
+	// AcceptLanguage is the sample list of preferred languages.
+	AcceptLanguage string `json:"accept_language,omitempty"`
+
+	// IP is the sample visitor address used for country lookup.
+	IP string `json:"ip,omitempty"`
+
+	// Country is ISO 3166-1 alpha-2 code overriding the country lookup.
+	Country string `json:"country,omitempty"`
+
+	// Time is the moment of the visit. Nil means now.
+	Time *time.Time `json:"time,omitempty"`
+
+	// Rules are evaluated instead of the stored ones when present.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// RuleTestResult reports how routing rules see a sample visitor.
+type RuleTestResult struct {
+	// Platform is the platform detected from the User-Agent.
+	Platform string `json:"platform"`
+
+	// Languages are accepted language tags ordered by preference.
+	Languages []string `json:"languages"`
+
+	// Country is the visitor country, empty if unknown.
+	Country string `json:"country,omitempty"`
+
+	// Rule is the index of the first matching rule. Nil if none matches.
+	Rule *int `json:"rule"`
+
+	// URL is the landing page of the matching rule or the original URL.
+	URL OrigURL `json:"url"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Stats represents statistics for a URL shortener service.
 // It contains aggregate counts of URLs and users in the system.
 type Stats struct {
@@ -9,3 +11,35 @@ type Stats struct {
 	// Users is the total number of registered users in the service
 	Users int `json:"users"`
 }
+
+// VariantStat represents redirect statistics of a multi-destination link variant.
+type VariantStat struct {
+	Destination
+
+	// Hits is the number of redirects to the variant
+	Hits int64 `json:"hits"`
+}
+
+// DeletionQueueStats represents state of the background deletion queue.
+type DeletionQueueStats struct {
+	// Depth is the number of queued deletion requests including the ones in flight
+	Depth int `json:"depth"`
+
+	// Capacity is the maximum depth, requests beyond it are rejected
+	Capacity int `json:"capacity"`
+
+	// InFlight is the number of requests in the batch being deleted
+	InFlight int `json:"in_flight"`
+
+	// Deleted is the number of requests deleted from storage since start
+	Deleted int64 `json:"deleted"`
+
+	// Rejected is the number of requests rejected because the queue was full
+	Rejected int64 `json:"rejected"`
+
+	// FailedBatches is the number of batch deletion attempts that failed
+	FailedBatches int64 `json:"failed_batches"`
+
+	// RetryIn is the time left until a failed batch is retried, zero if none
+	RetryIn time.Duration `json:"retry_in"`
+}
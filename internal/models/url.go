@@ -1,6 +1,8 @@
 // Package models defines the core data structures used across application layers.
 package models
 
+import "time"
+
 // ShortURL contains hash of original URL.
 //
 // Generated by the service according to implemented hash function.
@@ -21,9 +23,61 @@ type UserID string
 // This structure is used throughout the application from storage layer
 // to API responses.
 type URLPair struct {
-	UID   UserID   `json:"user_id"`
-	Short ShortURL `json:"short_url"`
-	Orig  OrigURL  `json:"original_url"`
+	UID     UserID       `json:"user_id"`
+	Short   ShortURL     `json:"short_url"`
+	Orig    OrigURL      `json:"original_url"`
+	Options *LinkOptions `json:"options,omitempty"`
+
+	// CreatedAt is the moment the link was shortened.
+	// Zero for links created before it was recorded.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Schedule limits the period the link redirects in.
+	Schedule
+
+	// ClicksLeft is the number of redirects left before the link
+	// self-destructs. Nil means unlimited.
+	ClicksLeft *int64 `json:"clicks_left,omitempty"`
+
+	// Tags are free-form labels attached by the owner.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Schedule describes when a link is live.
+//
+// Before ActiveFrom the link shows "not yet available" page,
+// from ExpiresAt on it is gone. Nil bounds are open.
+type Schedule struct {
+	ActiveFrom *time.Time `json:"active_from,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// IsUpcoming reports whether the link is not live yet at the moment.
+func (s Schedule) IsUpcoming(now time.Time) bool {
+	return s.ActiveFrom != nil && now.Before(*s.ActiveFrom)
+}
+
+// IsExpired reports whether the link is no longer live at the moment.
+func (s Schedule) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// URLStatus is the lifecycle state of a short link.
+type URLStatus string
+
+// Short link states.
+const (
+	StatusActive    URLStatus = "active"
+	StatusScheduled URLStatus = "scheduled"
+	StatusExpired   URLStatus = "expired"
+	StatusDeleted   URLStatus = "deleted"
+	StatusNotFound  URLStatus = "not_found"
+)
+
+// URLInfo describes a short link without following it.
+type URLInfo struct {
+	URLPair
+	Status URLStatus
 }
 
 // DelURLReq represents a request to delete a shortened URL.
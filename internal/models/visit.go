@@ -0,0 +1,42 @@
+package models
+
+import (
+	"net"
+	"net/url"
+	"time"
+)
+
+// Visit describes an incoming redirect request.
+//
+// It is passed from transport layer to services through request context
+// and carries everything needed to build the final destination URL.
+type Visit struct {
+	// VisitorID identifies the visitor between requests.
+	// Used to keep multi-destination choice sticky.
+	VisitorID string
+
+	// VariantPicked is set by services when one of multiple destinations
+	// was chosen by VisitorID, so that transport keeps the ID for later visits.
+	VariantPicked bool
+
+	// Query contains parameters of the incoming request.
+	Query url.Values
+
+	// Path contains escaped trailing path segments after the slug.
+	Path string
+
+	// UserAgent is the visitor client identification string.
+	UserAgent string
+
+	// AcceptLanguage is the raw list of languages preferred by the visitor.
+	AcceptLanguage string
+
+	// IP is the visitor address used for country lookup.
+	IP net.IP
+
+	// Time is the moment of the visit.
+	Time time.Time
+
+	// Password is the visitor provided password of a protected link.
+	Password string
+}
@@ -0,0 +1,175 @@
+// Package qr renders QR codes of short links as PNG or SVG images.
+//
+// Encoding is done in pure Go, no external tools are required.
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Supported image formats.
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// Image parameter defaults and limits.
+const (
+	DefaultSize   = 256
+	DefaultMargin = 4
+	DefaultLevel  = "M"
+
+	MinSize   = 64
+	MaxSize   = 2048
+	MaxMargin = 16
+)
+
+var (
+	errUnknownFormat = errors.New("format must be png or svg")
+	errBadSize       = fmt.Errorf("size must be from %d to %d pixels", MinSize, MaxSize)
+	errBadMargin     = fmt.Errorf("margin must be from 0 to %d modules", MaxMargin)
+	errUnknownLevel  = errors.New("error correction level must be L, M, Q or H")
+)
+
+var levels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// Options describes the rendered image.
+type Options struct {
+	// Format is png or svg.
+	Format string
+
+	// Size is the image width and height in pixels.
+	Size int
+
+	// Margin is the quiet zone width in modules.
+	Margin int
+
+	// Level is the error correction level: L (7%), M (15%), Q (25%) or H (30%).
+	Level string
+}
+
+// DefaultOptions returns options of a medium sized PNG code.
+func DefaultOptions() Options {
+	return Options{
+		Format: FormatPNG,
+		Size:   DefaultSize,
+		Margin: DefaultMargin,
+		Level:  DefaultLevel,
+	}
+}
+
+// Validate checks options are within supported limits.
+func (o Options) Validate() error {
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return errUnknownFormat
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return errBadSize
+	}
+	if o.Margin < 0 || o.Margin > MaxMargin {
+		return errBadMargin
+	}
+	if _, ok := levels[o.Level]; !ok {
+		return errUnknownLevel
+	}
+	return nil
+}
+
+// ContentType returns media type of the image format.
+func (o Options) ContentType() string {
+	if o.Format == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Render encodes content into a QR code image.
+//
+// Modules are scaled by a whole number of pixels to keep edges sharp,
+// so the code is centered if the size is not a multiple of its width.
+// Content too long for the size is rendered one pixel per module.
+func Render(content string, opts Options) ([]byte, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	code, err := qrcode.New(content, levels[opts.Level])
+	if err != nil {
+		return nil, err
+	}
+	code.DisableBorder = true
+
+	bitmap := code.Bitmap()
+	if opts.Format == FormatSVG {
+		return renderSVG(bitmap, opts), nil
+	}
+	return renderPNG(bitmap, opts)
+}
+
+func renderPNG(bitmap [][]bool, opts Options) ([]byte, error) {
+	modules := len(bitmap) + 2*opts.Margin
+	size := max(opts.Size, modules)
+	scale := size / modules
+	offset := (size-modules*scale)/2 + opts.Margin*scale
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderSVG(bitmap [][]bool, opts Options) []byte {
+	modules := len(bitmap) + 2*opts.Margin
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", x+opts.Margin, y+opts.Margin, run, run)
+			x += run - 1
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="%s"/></svg>`, path.String())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,103 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContent = "http://localhost:8080/abc123"
+
+func TestRender(t *testing.T) {
+	t.Run("png", func(t *testing.T) {
+		data, err := Render(testContent, DefaultOptions())
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSize, img.Bounds().Dx())
+		assert.Equal(t, DefaultSize, img.Bounds().Dy())
+
+		r, g, b, _ := img.At(0, 0).RGBA()
+		assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b}, "quiet zone must be white")
+	})
+
+	t.Run("png without margin starts with finder pattern", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.Margin = 0
+		opts.Size = 100
+
+		data, err := Render(testContent, opts)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		// the code is centered when size is not a multiple of its width
+		var first int
+		for first = 0; first < opts.Size; first++ {
+			if r, _, _, _ := img.At(first, first).RGBA(); r == 0 {
+				break
+			}
+		}
+		assert.Less(t, first, 10)
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		opts := DefaultOptions()
+		opts.Format = FormatSVG
+		opts.Size = 512
+
+		data, err := Render(testContent, opts)
+		require.NoError(t, err)
+
+		svg := string(data)
+		assert.True(t, strings.HasPrefix(svg, "<svg "))
+		assert.Contains(t, svg, `width="512"`)
+		assert.Contains(t, svg, `d="M4 4h7v1h-7z`, "finder pattern must start after the margin")
+		assert.Equal(t, "image/svg+xml", opts.ContentType())
+	})
+
+	t.Run("higher level makes bigger code", func(t *testing.T) {
+		low := DefaultOptions()
+		low.Format = FormatSVG
+		low.Level = "L"
+		high := low
+		high.Level = "H"
+
+		lowData, err := Render(testContent, low)
+		require.NoError(t, err)
+		highData, err := Render(testContent, high)
+		require.NoError(t, err)
+
+		assert.Greater(t, len(highData), len(lowData))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(*Options)
+		}{
+			{name: "format", modify: func(o *Options) { o.Format = "gif" }},
+			{name: "small size", modify: func(o *Options) { o.Size = MinSize - 1 }},
+			{name: "large size", modify: func(o *Options) { o.Size = MaxSize + 1 }},
+			{name: "negative margin", modify: func(o *Options) { o.Margin = -1 }},
+			{name: "large margin", modify: func(o *Options) { o.Margin = MaxMargin + 1 }},
+			{name: "level", modify: func(o *Options) { o.Level = "X" }},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				opts := DefaultOptions()
+				tt.modify(&opts)
+
+				_, err := Render(testContent, opts)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,64 @@
+// Package requestid provides request IDs correlating log entries
+// of a single HTTP request or gRPC call.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/logger"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Header is the HTTP header carrying the request ID in requests and responses.
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key carrying the request ID in calls and headers.
+const MetadataKey = "x-request-id"
+
+// maxLen limits the length of request IDs accepted from clients.
+const maxLen = 128
+
+// Resolve returns the request ID supplied by a client if it is valid,
+// otherwise a new random one.
+//
+// Valid IDs are up to 128 printable ASCII characters without spaces,
+// so they cannot break log lines or response headers.
+func Resolve(id string) string {
+	if valid(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+func valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of ctx carrying the request ID and a request-scoped
+// logger adding the request ID and the trace ID of the span in ctx to its entries.
+func NewContext(ctx context.Context, id string) context.Context {
+	fields := []zap.Field{zap.String("request_id", id)}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+
+	ctx = context.WithValue(ctx, contextkeys.RequestID, id)
+	return logger.With(ctx, fields...)
+}
+
+// FromContext returns the request ID stored in ctx or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextkeys.RequestID).(string)
+	return id
+}
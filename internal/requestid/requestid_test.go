@@ -0,0 +1,65 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		valid bool
+	}{
+		{"uuid", "0f8fad5b-d9cb-469f-a165-70867728950e", true},
+		{"opaque", "req:42/a_b", true},
+		{"max length", strings.Repeat("a", maxLen), true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxLen+1), false},
+		{"space", "a b", false},
+		{"newline", "a\nb", false},
+		{"non-ascii", "идентификатор", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := Resolve(test.id)
+			if test.valid {
+				assert.Equal(t, test.id, id)
+				return
+			}
+			_, err := uuid.Parse(id)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := logger.WithContext(context.Background(), zap.New(core))
+
+	traceID := trace.TraceID{1}
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+	}))
+
+	ctx = NewContext(ctx, "id")
+	assert.Equal(t, "id", FromContext(ctx))
+
+	logger.FromContext(ctx).Info("test")
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		"request_id": "id",
+		"trace_id":   traceID.String(),
+	}, entries[0].ContextMap())
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
@@ -20,14 +22,32 @@ type batchURLFetcher interface {
 	GetURLPairBatchByUserID(context.Context, models.UserID) ([]models.URLPair, error)
 }
 
+// batchURLImporter defines bulk import storage operations.
+type batchURLImporter interface {
+	// ImportURLPairs stores URL pairs skipping the ones which collide with
+	// existing links and reports for each pair whether it was skipped.
+	ImportURLPairs(context.Context, []models.URLPair) ([]bool, error)
+}
+
+// batchURLIterator defines streaming retrieval of user URLs.
+type batchURLIterator interface {
+	// IterateURLPairsByUserID calls fn for every URL pair of the user
+	// including deleted ones and stops at the first error returned by fn.
+	IterateURLPairsByUserID(context.Context, models.UserID, func(models.URLInfo) error) error
+}
+
 // BatchShortenerStorage combines storage operations needed for batch URL processing.
 //
-// The interface composes two fundamental capabilities required by the BatchShortener service:
+// The interface composes fundamental capabilities required by the BatchShortener service:
 //   - Saving multiple URLs in single operation (batchURLSaver)
 //   - Retrieving user's URLs (batchURLFetcher)
+//   - Importing links skipping existing ones (batchURLImporter)
+//   - Streaming user's URLs including deleted ones (batchURLIterator)
 type BatchShortenerStorage interface {
 	batchURLSaver
 	batchURLFetcher
+	batchURLImporter
+	batchURLIterator
 }
 
 type batchHasher interface {
@@ -41,6 +61,7 @@ type batchHasher interface {
 type BatchShortener struct {
 	strg   BatchShortenerStorage
 	hasher batchHasher
+	now    func() time.Time
 }
 
 // NewBatchShortener creates new batch processor instance.
@@ -48,6 +69,7 @@ func NewBatchShortener(strg BatchShortenerStorage, hasher batchHasher) *BatchSho
 	return &BatchShortener{
 		strg:   strg,
 		hasher: hasher,
+		now:    time.Now,
 	}
 }
 
@@ -57,13 +79,21 @@ func NewBatchShortener(strg BatchShortenerStorage, hasher batchHasher) *BatchSho
 // Returns slice of URLPair structures containing both original
 // and shortened versions, maintaining input order.
 func (s *BatchShortener) BatchShortenURL(ctx context.Context, uid models.UserID, origs []models.OrigURL) ([]models.URLPair, error) {
+	ctx, span := tracing.Start(ctx, "BatchShortener.BatchShortenURL")
+	defer span.End()
+
 	var pairs = make([]models.URLPair, len(origs))
+	now := s.now()
 	for i, orig := range origs {
 		short := s.hasher.GenerateHashFromURL(orig)
+		if isReservedSlug(short) {
+			return nil, errReservedShort
+		}
 		pairs[i] = models.URLPair{
-			UID:   uid,
-			Short: short,
-			Orig:  orig,
+			UID:       uid,
+			Short:     short,
+			Orig:      orig,
+			CreatedAt: now,
 		}
 	}
 	err := s.strg.AddBatchURLPairs(ctx, pairs)
@@ -77,6 +107,9 @@ func (s *BatchShortener) BatchShortenURL(ctx context.Context, uid models.UserID,
 //
 // Returns slice of URLPair structures or empty slice if none found.
 func (s *BatchShortener) GetUserURLs(ctx context.Context, uid models.UserID) ([]models.URLPair, error) {
+	ctx, span := tracing.Start(ctx, "BatchShortener.GetUserURLs")
+	defer span.End()
+
 	pairs, err := s.strg.GetURLPairBatchByUserID(ctx, uid)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/shorturl/internal/models"
@@ -18,6 +19,7 @@ func TestBatchShortener_BatchShortenURL(t *testing.T) {
 	mStrg := mocks.NewMockBatchShortenerStorage(ctrl)
 
 	s := NewBatchShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
 
 	testOrigs := []models.OrigURL{
 		testOrigURL,
@@ -25,15 +27,16 @@ func TestBatchShortener_BatchShortenURL(t *testing.T) {
 
 	testPairs := []models.URLPair{
 		{
-			UID:   testUserID,
-			Short: testShortURL,
-			Orig:  testOrigURL,
+			UID:       testUserID,
+			Short:     testShortURL,
+			Orig:      testOrigURL,
+			CreatedAt: testNow,
 		},
 	}
 
 	t.Run("valid test", func(t *testing.T) {
 		mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL)
-		mStrg.EXPECT().AddBatchURLPairs(context.Background(), testPairs).Return(nil)
+		mStrg.EXPECT().AddBatchURLPairs(gomock.Any(), testPairs).Return(nil)
 
 		pairs, err := s.BatchShortenURL(context.Background(), testUserID, testOrigs)
 		assert.NoError(t, err)
@@ -42,7 +45,7 @@ func TestBatchShortener_BatchShortenURL(t *testing.T) {
 
 	t.Run("some error", func(t *testing.T) {
 		mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL)
-		mStrg.EXPECT().AddBatchURLPairs(context.Background(), testPairs).Return(errTest)
+		mStrg.EXPECT().AddBatchURLPairs(gomock.Any(), testPairs).Return(errTest)
 
 		_, err := s.BatchShortenURL(context.Background(), testUserID, testOrigs)
 		assert.Error(t, err)
@@ -66,7 +69,7 @@ func TestBatchShortener_GetUserURLs(t *testing.T) {
 	}
 
 	t.Run("valid test", func(t *testing.T) {
-		mStrg.EXPECT().GetURLPairBatchByUserID(context.Background(), testUserID).Return(testPairs, nil)
+		mStrg.EXPECT().GetURLPairBatchByUserID(gomock.Any(), testUserID).Return(testPairs, nil)
 
 		pairs, err := s.GetUserURLs(context.Background(), testUserID)
 		assert.NoError(t, err)
@@ -74,7 +77,7 @@ func TestBatchShortener_GetUserURLs(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mStrg.EXPECT().GetURLPairBatchByUserID(context.Background(), testUserID).Return(nil, errTest)
+		mStrg.EXPECT().GetURLPairBatchByUserID(gomock.Any(), testUserID).Return(nil, errTest)
 
 		_, err := s.GetUserURLs(context.Background(), testUserID)
 		assert.Error(t, err)
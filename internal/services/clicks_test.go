@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortener_GetOrigURLByShort_ClickLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	left := int64(1)
+	pair := testPair
+	pair.ClicksLeft = &left
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().ConsumeClick(gomock.Any(), testShortURL).Return(nil)
+
+		orig, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("clicks exhausted", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().ConsumeClick(gomock.Any(), testShortURL).Return(errTest)
+
+		_, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("unlimited link", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&testPair, nil)
+
+		orig, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("wrong password keeps clicks", func(t *testing.T) {
+		protected := pair
+		protected.Options = &models.LinkOptions{PasswordHash: "hash"}
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&protected, nil)
+
+		ctx := context.WithValue(context.Background(), contextkeys.Visit, &models.Visit{Password: "guess"})
+		_, err := s.GetOrigURLByShort(ctx, testShortURL)
+		assert.Error(t, err)
+	})
+}
+
+func TestShortener_SetClickLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	limit := int64(1)
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().SetClickLimit(gomock.Any(), testUserID, testShortURL, &limit).Return(nil)
+
+		err := s.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().SetClickLimit(gomock.Any(), testUserID, testShortURL, &limit).Return(errTest)
+
+		err := s.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/rycln/shorturl/internal/models"
 )
@@ -16,6 +18,8 @@ const (
 var (
 	errTest = errors.New("test error")
 
+	testNow = time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
 	testPair = models.URLPair{
 		UID:   testUserID,
 		Short: testShortURL,
@@ -27,3 +31,12 @@ var (
 		Short: testDeletedShort,
 	}
 )
+
+// updateOptionsOf returns storage mock action applying the options update
+// to stored settings and saving the result into got.
+func updateOptionsOf(stored *models.LinkOptions, got **models.LinkOptions) func(context.Context, models.UserID, models.ShortURL, func(*models.LinkOptions) *models.LinkOptions) error {
+	return func(_ context.Context, _ models.UserID, _ models.ShortURL, update func(*models.LinkOptions) *models.LinkOptions) error {
+		*got = update(stored)
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+)
+
+// DescribeURL returns short link details and its current status
+// without redirecting.
+//
+// Unlike GetOrigURLByShort it neither takes a click of a limited link
+// nor records a variant hit, and it also describes deleted links.
+// Returns not exist error if short URL is unknown.
+func (s *Shortener) DescribeURL(ctx context.Context, short models.ShortURL) (*models.URLInfo, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.DescribeURL")
+	defer span.End()
+
+	pair, deleted, err := s.strg.LookupURLPair(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &models.URLInfo{
+		URLPair: *pair,
+		Status:  models.StatusActive,
+	}
+	if deleted {
+		info.Status = models.StatusDeleted
+	}
+	applyScheduleStatus(info, s.now())
+
+	return info, nil
+}
+
+// BatchDescribeURL describes many short links with a single storage request.
+//
+// Results follow the input order. Unknown short URLs get not found status.
+// Like DescribeURL it neither takes clicks nor records variant hits.
+func (s *Shortener) BatchDescribeURL(ctx context.Context, shorts []models.ShortURL) ([]models.URLInfo, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.BatchDescribeURL")
+	defer span.End()
+
+	found, err := s.strg.LookupURLPairBatch(ctx, shorts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	infos := make([]models.URLInfo, len(shorts))
+	for i, short := range shorts {
+		info, ok := found[short]
+		if !ok {
+			infos[i] = models.URLInfo{
+				URLPair: models.URLPair{Short: short},
+				Status:  models.StatusNotFound,
+			}
+			continue
+		}
+
+		applyScheduleStatus(&info, now)
+		infos[i] = info
+	}
+
+	return infos, nil
+}
+
+// applyScheduleStatus refines status of an active link by its schedule.
+func applyScheduleStatus(info *models.URLInfo, now time.Time) {
+	if info.Status != models.StatusActive {
+		return
+	}
+
+	switch {
+	case info.IsExpired(now):
+		info.Status = models.StatusExpired
+	case info.IsUpcoming(now):
+		info.Status = models.StatusScheduled
+	}
+}
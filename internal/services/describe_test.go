@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortener_DescribeURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
+
+	past := testNow.Add(-time.Hour)
+	future := testNow.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		sched   models.Schedule
+		deleted bool
+		want    models.URLStatus
+	}{
+		{name: "active", want: models.StatusActive},
+		{name: "active within schedule", sched: models.Schedule{ActiveFrom: &past, ExpiresAt: &future}, want: models.StatusActive},
+		{name: "scheduled", sched: models.Schedule{ActiveFrom: &future}, want: models.StatusScheduled},
+		{name: "expired", sched: models.Schedule{ExpiresAt: &past}, want: models.StatusExpired},
+		{name: "deleted", sched: models.Schedule{ExpiresAt: &past}, deleted: true, want: models.StatusDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := testPair
+			pair.Schedule = tt.sched
+			mStrg.EXPECT().LookupURLPair(gomock.Any(), testShortURL).Return(&pair, tt.deleted, nil)
+
+			info, err := s.DescribeURL(context.Background(), testShortURL)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, info.Status)
+			assert.Equal(t, pair, info.URLPair)
+		})
+	}
+
+	t.Run("limited link keeps clicks", func(t *testing.T) {
+		left := int64(1)
+		pair := testPair
+		pair.ClicksLeft = &left
+		mStrg.EXPECT().LookupURLPair(gomock.Any(), testShortURL).Return(&pair, false, nil)
+
+		info, err := s.DescribeURL(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusActive, info.Status)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().LookupURLPair(gomock.Any(), testShortURL).Return(nil, false, errTest)
+
+		_, err := s.DescribeURL(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestShortener_BatchDescribeURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
+
+	past := testNow.Add(-time.Hour)
+
+	expiredPair := testPair
+	expiredPair.Short = "expired"
+	expiredPair.ExpiresAt = &past
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	deletedPair.ExpiresAt = &past
+
+	shorts := []models.ShortURL{"unknown", testShortURL, expiredPair.Short, testDeletedShort}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().LookupURLPairBatch(gomock.Any(), shorts).Return(map[models.ShortURL]models.URLInfo{
+			testShortURL:      {URLPair: testPair, Status: models.StatusActive},
+			expiredPair.Short: {URLPair: expiredPair, Status: models.StatusActive},
+			testDeletedShort:  {URLPair: deletedPair, Status: models.StatusDeleted},
+		}, nil)
+
+		infos, err := s.BatchDescribeURL(context.Background(), shorts)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLInfo{
+			{URLPair: models.URLPair{Short: "unknown"}, Status: models.StatusNotFound},
+			{URLPair: testPair, Status: models.StatusActive},
+			{URLPair: expiredPair, Status: models.StatusExpired},
+			{URLPair: deletedPair, Status: models.StatusDeleted},
+		}, infos)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().LookupURLPairBatch(gomock.Any(), shorts).Return(nil, errTest)
+
+		_, err := s.BatchDescribeURL(context.Background(), shorts)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand/v2"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// pickDestination chooses a weighted destination for the visitor.
+//
+// The same visitor always gets the same variant of the link as long as
+// destinations are not changed. Anonymous visitors get a random variant.
+func pickDestination(dests []models.Destination, short models.ShortURL, visitorID string) models.Destination {
+	var total uint64
+	for _, dest := range dests {
+		total += uint64(dest.Weight)
+	}
+	if total == 0 {
+		return dests[0]
+	}
+
+	var n uint64
+	if visitorID != "" {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(visitorID))
+		_, _ = h.Write([]byte{'/'})
+		_, _ = h.Write([]byte(short))
+		n = h.Sum64() % total
+	} else {
+		n = rand.Uint64N(total)
+	}
+
+	for _, dest := range dests {
+		if n < uint64(dest.Weight) {
+			return dest
+		}
+		n -= uint64(dest.Weight)
+	}
+
+	return dests[len(dests)-1]
+}
+
+// resolveDestination selects the URL the visitor is redirected to
+// and records the chosen variant for multi-destination links.
+func (s *Shortener) resolveDestination(ctx context.Context, pair *models.URLPair, visit *models.Visit) models.OrigURL {
+	if len(pair.Options.Destinations) == 0 {
+		return pair.Orig
+	}
+
+	var visitorID string
+	if visit != nil {
+		visitorID = visit.VisitorID
+		visit.VariantPicked = true
+	}
+
+	dest := pickDestination(pair.Options.Destinations, pair.Short, visitorID)
+
+	err := s.strg.AddVariantHit(ctx, pair.Short, dest.Variant)
+	if err != nil {
+		logger.FromContext(ctx).Warn("cannot record variant hit", zap.String("short", string(pair.Short)), zap.Error(err))
+	}
+
+	return dest.URL
+}
+
+// SetDestinations replaces weighted destinations of the URL owned by user.
+//
+// Other link settings are preserved. Empty destinations turn the link
+// back into a single destination one.
+func (s *Shortener) SetDestinations(ctx context.Context, uid models.UserID, short models.ShortURL, dests []models.Destination) error {
+	ctx, span := tracing.Start(ctx, "Shortener.SetDestinations")
+	defer span.End()
+
+	return s.updateOptions(ctx, uid, short, func(opts *models.LinkOptions) {
+		opts.Destinations = dests
+	})
+}
+
+// GetDestinationStats returns weighted destinations of the URL owned by user
+// together with the number of redirects to each of them.
+func (s *Shortener) GetDestinationStats(ctx context.Context, uid models.UserID, short models.ShortURL) ([]models.VariantStat, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.GetDestinationStats")
+	defer span.End()
+
+	pair, err := s.getUserPair(ctx, uid, short)
+	if err != nil {
+		return nil, err
+	}
+
+	if pair.Options == nil || len(pair.Options.Destinations) == 0 {
+		return nil, nil
+	}
+
+	hits, err := s.strg.GetVariantHits(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]models.VariantStat, len(pair.Options.Destinations))
+	for i, dest := range pair.Options.Destinations {
+		stats[i] = models.VariantStat{
+			Destination: dest,
+			Hits:        hits[dest.Variant],
+		}
+	}
+
+	return stats, nil
+}
+
+// getUserPair retrieves URL pair and verifies it belongs to user.
+func (s *Shortener) getUserPair(ctx context.Context, uid models.UserID, short models.ShortURL) (*models.URLPair, error) {
+	pair, err := s.strg.GetURLPairByShort(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+
+	if pair.UID != uid {
+		return nil, newErrNotOwned(errNotOwned)
+	}
+
+	return pair, nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDestinations = []models.Destination{
+	{Variant: "a", URL: "https://example.com/a", Weight: 3},
+	{Variant: "b", URL: "https://example.com/b", Weight: 1},
+}
+
+func TestPickDestination(t *testing.T) {
+	t.Run("sticky visitor", func(t *testing.T) {
+		first := pickDestination(testDestinations, testShortURL, "visitor")
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, first, pickDestination(testDestinations, testShortURL, "visitor"))
+		}
+	})
+
+	t.Run("weighted split", func(t *testing.T) {
+		counts := make(map[string]int)
+		for i := 0; i < 4000; i++ {
+			dest := pickDestination(testDestinations, testShortURL, fmt.Sprintf("visitor-%d", i))
+			counts[dest.Variant]++
+		}
+		assert.InDelta(t, 3000, counts["a"], 200)
+		assert.InDelta(t, 1000, counts["b"], 200)
+	})
+
+	t.Run("anonymous visitor", func(t *testing.T) {
+		dest := pickDestination(testDestinations, testShortURL, "")
+		assert.Contains(t, testDestinations, dest)
+	})
+
+	t.Run("zero weight is never chosen", func(t *testing.T) {
+		dests := []models.Destination{
+			{Variant: "a", URL: "https://example.com/a", Weight: 0},
+			{Variant: "b", URL: "https://example.com/b", Weight: 1},
+		}
+		for i := 0; i < 100; i++ {
+			dest := pickDestination(dests, testShortURL, fmt.Sprintf("visitor-%d", i))
+			assert.Equal(t, "b", dest.Variant)
+		}
+	})
+}
+
+func TestShortener_GetOrigURLByShort_Destinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	pair := testPair
+	pair.Options = &models.LinkOptions{
+		Destinations: testDestinations,
+	}
+
+	visit := &models.Visit{VisitorID: "visitor"}
+	ctx := context.WithValue(context.Background(), contextkeys.Visit, visit)
+	want := pickDestination(testDestinations, testShortURL, "visitor")
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().AddVariantHit(gomock.Any(), testShortURL, want.Variant).Return(nil)
+
+		orig, err := s.GetOrigURLByShort(ctx, testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, want.URL, orig)
+		assert.True(t, visit.VariantPicked)
+	})
+
+	t.Run("hit record error", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().AddVariantHit(gomock.Any(), testShortURL, want.Variant).Return(errTest)
+
+		orig, err := s.GetOrigURLByShort(ctx, testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, want.URL, orig)
+	})
+}
+
+func TestShortener_SetDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	stored := &models.LinkOptions{
+		Passthrough: &models.Passthrough{Query: true},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		var got *models.LinkOptions
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).DoAndReturn(updateOptionsOf(stored, &got))
+
+		err := s.SetDestinations(context.Background(), testUserID, testShortURL, testDestinations)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.LinkOptions{
+			Passthrough:  stored.Passthrough,
+			Destinations: testDestinations,
+		}, got)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).Return(errTest)
+
+		err := s.SetDestinations(context.Background(), testUserID, testShortURL, testDestinations)
+		assert.Error(t, err)
+	})
+}
+
+func TestShortener_GetDestinationStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	pair := testPair
+	pair.Options = &models.LinkOptions{
+		Destinations: testDestinations,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().GetVariantHits(gomock.Any(), testShortURL).Return(map[string]int64{"a": 5}, nil)
+
+		stats, err := s.GetDestinationStats(context.Background(), testUserID, testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.VariantStat{
+			{Destination: testDestinations[0], Hits: 5},
+			{Destination: testDestinations[1], Hits: 0},
+		}, stats)
+	})
+
+	t.Run("single destination link", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&testPair, nil)
+
+		stats, err := s.GetDestinationStats(context.Background(), testUserID, testShortURL)
+		assert.NoError(t, err)
+		assert.Empty(t, stats)
+	})
+
+	t.Run("hits error", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mStrg.EXPECT().GetVariantHits(gomock.Any(), testShortURL).Return(nil, errTest)
+
+		_, err := s.GetDestinationStats(context.Background(), testUserID, testShortURL)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"errors"
+	"time"
+)
+
+var errNotOwned = errors.New("short URL does not belong to user")
+
+// errReservedShort is returned when a generated short URL collides
+// with a route of the application.
+var errReservedShort = errors.New("short URL is reserved")
+
+// notOwned represents an error when a user accesses someone else's URL.
+//
+// For the caller it is indistinguishable from a missing URL.
+type notOwned struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *notOwned) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *notOwned) Unwrap() error {
+	return err.err
+}
+
+// IsErrNotExist provides type checking capability.
+func (err *notOwned) IsErrNotExist() bool {
+	return true
+}
+
+// newErrNotOwned constructs a new notOwned error.
+func newErrNotOwned(err error) error {
+	return &notOwned{
+		err: err,
+	}
+}
+
+var (
+	errPasswordRequired = errors.New("link is password protected")
+	errWrongPassword    = errors.New("wrong link password")
+	errTooManyAttempts  = errors.New("too many password attempts")
+)
+
+// passwordRequired represents an error when a protected link is opened without password.
+type passwordRequired struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *passwordRequired) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *passwordRequired) Unwrap() error {
+	return err.err
+}
+
+// IsErrPasswordRequired provides type checking capability.
+func (err *passwordRequired) IsErrPasswordRequired() bool {
+	return true
+}
+
+// newErrPasswordRequired constructs a new passwordRequired error.
+func newErrPasswordRequired(err error) error {
+	return &passwordRequired{
+		err: err,
+	}
+}
+
+// wrongPassword represents an error when a protected link is opened with wrong password.
+type wrongPassword struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *wrongPassword) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *wrongPassword) Unwrap() error {
+	return err.err
+}
+
+// IsErrWrongPassword provides type checking capability.
+func (err *wrongPassword) IsErrWrongPassword() bool {
+	return true
+}
+
+// newErrWrongPassword constructs a new wrongPassword error.
+func newErrWrongPassword(err error) error {
+	return &wrongPassword{
+		err: err,
+	}
+}
+
+// tooManyAttempts represents an error when password guessing of a link is throttled.
+type tooManyAttempts struct {
+	err        error
+	retryAfter time.Duration
+}
+
+// Error returns the string representation of the error.
+func (err *tooManyAttempts) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *tooManyAttempts) Unwrap() error {
+	return err.err
+}
+
+// IsErrTooManyAttempts provides type checking capability.
+func (err *tooManyAttempts) IsErrTooManyAttempts() bool {
+	return true
+}
+
+// RetryAfter returns time left until next attempt is allowed.
+func (err *tooManyAttempts) RetryAfter() time.Duration {
+	return err.retryAfter
+}
+
+// newErrTooManyAttempts constructs a new tooManyAttempts error.
+func newErrTooManyAttempts(err error, retryAfter time.Duration) error {
+	return &tooManyAttempts{
+		err:        err,
+		retryAfter: retryAfter,
+	}
+}
+
+var (
+	errNotActive = errors.New("link is not active yet")
+	errExpired   = errors.New("link has expired")
+)
+
+// notActive represents an error when a scheduled link is opened before its activation.
+type notActive struct {
+	err        error
+	activeFrom time.Time
+}
+
+// Error returns the string representation of the error.
+func (err *notActive) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *notActive) Unwrap() error {
+	return err.err
+}
+
+// IsErrNotActive provides type checking capability.
+func (err *notActive) IsErrNotActive() bool {
+	return true
+}
+
+// ActiveFrom returns the moment the link goes live.
+func (err *notActive) ActiveFrom() time.Time {
+	return err.activeFrom
+}
+
+// newErrNotActive constructs a new notActive error.
+func newErrNotActive(err error, activeFrom time.Time) error {
+	return &notActive{
+		err:        err,
+		activeFrom: activeFrom,
+	}
+}
+
+// expired represents an error when a link is opened after its end time.
+//
+// For the caller it is handled the same way as a deleted URL.
+type expired struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *expired) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *expired) Unwrap() error {
+	return err.err
+}
+
+// IsErrExpired provides type checking capability.
+func (err *expired) IsErrExpired() bool {
+	return true
+}
+
+// IsErrDeletedURL provides type checking capability.
+func (err *expired) IsErrDeletedURL() bool {
+	return true
+}
+
+// newErrExpired constructs a new expired error.
+func newErrExpired(err error) error {
+	return &expired{
+		err: err,
+	}
+}
+
+var errInvalidPath = errors.New("path escapes destination")
+
+// invalidPath represents an error when the trailing path of a redirect
+// request climbs above the destination path or is malformed.
+type invalidPath struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *invalidPath) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *invalidPath) Unwrap() error {
+	return err.err
+}
+
+// IsErrInvalidPath provides type checking capability.
+func (err *invalidPath) IsErrInvalidPath() bool {
+	return true
+}
+
+// newErrInvalidPath constructs a new invalidPath error.
+func newErrInvalidPath(err error) error {
+	return &invalidPath{
+		err: err,
+	}
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+)
+
+// ExportUserURLs streams all URLs of the user including deleted ones to fn.
+//
+// Links are passed as storage reads them, nothing is accumulated.
+// Status of each link reflects its deletion and schedule.
+// Export stops at the first error returned by fn.
+func (s *BatchShortener) ExportUserURLs(ctx context.Context, uid models.UserID, fn func(models.URLInfo) error) error {
+	ctx, span := tracing.Start(ctx, "BatchShortener.ExportUserURLs")
+	defer span.End()
+
+	now := s.now()
+	return s.strg.IterateURLPairsByUserID(ctx, uid, func(info models.URLInfo) error {
+		applyScheduleStatus(&info, now)
+		return fn(info)
+	})
+}
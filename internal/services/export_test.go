@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchShortener_ExportUserURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockbatchHasher(ctrl)
+	mStrg := mocks.NewMockBatchShortenerStorage(ctrl)
+
+	s := NewBatchShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
+
+	past := testNow.Add(-time.Hour)
+	expired := testPair
+	expired.ExpiresAt = &past
+
+	stored := []models.URLInfo{
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: expired, Status: models.StatusActive},
+		{URLPair: expired, Status: models.StatusDeleted},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().IterateURLPairsByUserID(gomock.Any(), testUserID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.UserID, fn func(models.URLInfo) error) error {
+				for _, info := range stored {
+					if err := fn(info); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		var statuses []models.URLStatus
+		err := s.ExportUserURLs(context.Background(), testUserID, func(info models.URLInfo) error {
+			statuses = append(statuses, info.Status)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLStatus{models.StatusActive, models.StatusExpired, models.StatusDeleted}, statuses)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().IterateURLPairsByUserID(gomock.Any(), testUserID, gomock.Any()).Return(errTest)
+
+		err := s.ExportUserURLs(context.Background(), testUserID, func(models.URLInfo) error { return nil })
+		assert.ErrorIs(t, err, errTest)
+	})
+}
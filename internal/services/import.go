@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+)
+
+// Limits of imported link attributes.
+const (
+	maxSlugLen   = 64
+	maxTags      = 32
+	maxTagLength = 64
+)
+
+var slugPattern = regexp.MustCompile(fmt.Sprintf(`^[A-Za-z0-9_-]{1,%d}$`, maxSlugLen))
+
+// reservedSlugs are static first path segments of the public HTTP routes.
+var reservedSlugs = map[models.ShortURL]struct{}{
+	"api":     {},
+	"ping":    {},
+	"healthz": {},
+	"readyz":  {},
+}
+
+var (
+	errImportBadURL   = errors.New("original URL must be an absolute http or https URL")
+	errImportBadSlug  = fmt.Errorf("custom slug must be 1 to %d letters, digits, '-' or '_'", maxSlugLen)
+	errImportReserved = errors.New("custom slug is reserved")
+	errImportTags     = fmt.Errorf("at most %d tags of up to %d characters are allowed", maxTags, maxTagLength)
+	errImportExpired  = errors.New("expiry is in the past")
+	errImportConflict = errors.New("short URL is already taken")
+	errImportRepeated = errors.New("short URL repeats an earlier row")
+)
+
+// ImportURLs stores a chunk of imported links of the user.
+//
+// Records are validated one by one, invalid ones are reported and skipped
+// without failing the chunk. Links without a custom slug get a generated one.
+// Links colliding with existing ones or with earlier records of the chunk
+// are reported as conflicts. Returns a result for every record in input order.
+//
+// The caller is expected to split large imports into chunks so that
+// the whole import never has to be held in memory.
+func (s *BatchShortener) ImportURLs(ctx context.Context, uid models.UserID, recs []models.ImportRecord) ([]models.ImportResult, error) {
+	ctx, span := tracing.Start(ctx, "BatchShortener.ImportURLs")
+	defer span.End()
+
+	results := make([]models.ImportResult, len(recs))
+	pairs := make([]models.URLPair, 0, len(recs))
+	stored := make([]int, 0, len(recs))
+	seen := make(map[models.ShortURL]struct{}, len(recs))
+
+	now := s.now()
+	for i, rec := range recs {
+		results[i] = models.ImportResult{
+			Row:   rec.Row,
+			Short: rec.Short,
+			Orig:  rec.Orig,
+		}
+
+		tags, err := validateImportRecord(rec, now)
+		if err != nil {
+			results[i].Status = models.ImportInvalid
+			results[i].Reason = err.Error()
+			continue
+		}
+
+		short := rec.Short
+		if short == "" {
+			short = s.hasher.GenerateHashFromURL(rec.Orig)
+		}
+		results[i].Short = short
+
+		if isReservedSlug(short) {
+			results[i].Status = models.ImportInvalid
+			results[i].Reason = errImportReserved.Error()
+			continue
+		}
+
+		if _, ok := seen[short]; ok {
+			results[i].Status = models.ImportConflict
+			results[i].Reason = errImportRepeated.Error()
+			continue
+		}
+		seen[short] = struct{}{}
+
+		pairs = append(pairs, models.URLPair{
+			UID:       uid,
+			Short:     short,
+			Orig:      rec.Orig,
+			CreatedAt: now,
+			Schedule:  models.Schedule{ExpiresAt: rec.ExpiresAt},
+			Tags:      tags,
+		})
+		stored = append(stored, i)
+	}
+
+	if len(pairs) == 0 {
+		return results, nil
+	}
+
+	conflicts, err := s.strg.ImportURLPairs(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range stored {
+		if conflicts[j] {
+			results[i].Status = models.ImportConflict
+			results[i].Reason = errImportConflict.Error()
+			continue
+		}
+		results[i].Status = models.ImportCreated
+	}
+
+	return results, nil
+}
+
+// validateImportRecord checks the record can be stored.
+//
+// Returns normalized tags: trimmed, without empty and repeated ones.
+func validateImportRecord(rec models.ImportRecord, now time.Time) ([]string, error) {
+	if rec.Err != nil {
+		return nil, rec.Err
+	}
+
+	u, err := url.Parse(string(rec.Orig))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errImportBadURL
+	}
+
+	if rec.Short != "" {
+		if !slugPattern.MatchString(string(rec.Short)) {
+			return nil, errImportBadSlug
+		}
+	}
+
+	if rec.ExpiresAt != nil && !rec.ExpiresAt.After(now) {
+		return nil, errImportExpired
+	}
+
+	return normalizeTags(rec.Tags)
+}
+
+func normalizeTags(raw []string) ([]string, error) {
+	var tags []string
+	seen := make(map[string]struct{}, len(raw))
+	for _, tag := range raw {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, errImportTags
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > maxTags {
+		return nil, errImportTags
+	}
+
+	return tags, nil
+}
+
+// ReservedSlugs returns short URLs shadowed by routes of the public HTTP API.
+func ReservedSlugs() []models.ShortURL {
+	slugs := make([]models.ShortURL, 0, len(reservedSlugs))
+	for slug := range reservedSlugs {
+		slugs = append(slugs, slug)
+	}
+	slices.Sort(slugs)
+
+	return slugs
+}
+
+// isReservedSlug reports whether the short URL is shadowed by a route
+// of the application.
+func isReservedSlug(short models.ShortURL) bool {
+	_, ok := reservedSlugs[models.ShortURL(strings.ToLower(string(short)))]
+	return ok
+}
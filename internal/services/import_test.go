@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchShortener_ImportURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockbatchHasher(ctrl)
+	mStrg := mocks.NewMockBatchShortenerStorage(ctrl)
+
+	s := NewBatchShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
+
+	future := testNow.Add(time.Hour)
+	past := testNow.Add(-time.Hour)
+
+	t.Run("valid test", func(t *testing.T) {
+		recs := []models.ImportRecord{
+			{Row: 1, Orig: testOrigURL},
+			{Row: 2, Orig: "https://example.com/", Short: "promo", Tags: []string{" sale ", "", "sale", "2025"}, ExpiresAt: &future},
+			{Row: 3, Orig: "https://example.org/", Short: "taken"},
+		}
+
+		mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL)
+		mStrg.EXPECT().ImportURLPairs(gomock.Any(), []models.URLPair{
+			{UID: testUserID, Short: testShortURL, Orig: testOrigURL, CreatedAt: testNow},
+			{UID: testUserID, Short: "promo", Orig: "https://example.com/", CreatedAt: testNow, Schedule: models.Schedule{ExpiresAt: &future}, Tags: []string{"sale", "2025"}},
+			{UID: testUserID, Short: "taken", Orig: "https://example.org/", CreatedAt: testNow},
+		}).Return([]bool{false, false, true}, nil)
+
+		results, err := s.ImportURLs(context.Background(), testUserID, recs)
+		require.NoError(t, err)
+		assert.Equal(t, []models.ImportResult{
+			{Row: 1, Short: testShortURL, Orig: testOrigURL, Status: models.ImportCreated},
+			{Row: 2, Short: "promo", Orig: "https://example.com/", Status: models.ImportCreated},
+			{Row: 3, Short: "taken", Orig: "https://example.org/", Status: models.ImportConflict, Reason: errImportConflict.Error()},
+		}, results)
+	})
+
+	t.Run("invalid records", func(t *testing.T) {
+		tooMany := make([]string, maxTags+1)
+		for i := range tooMany {
+			tooMany[i] = strings.Repeat("t", i+1)
+		}
+
+		recs := []models.ImportRecord{
+			{Row: 1, Err: errTest},
+			{Row: 2, Orig: "example.com"},
+			{Row: 3, Orig: "ftp://example.com/"},
+			{Row: 4, Orig: testOrigURL, Short: "bad slug"},
+			{Row: 5, Orig: testOrigURL, Short: models.ShortURL(strings.Repeat("a", maxSlugLen+1))},
+			{Row: 6, Orig: testOrigURL, Short: "API"},
+			{Row: 7, Orig: testOrigURL, ExpiresAt: &past},
+			{Row: 8, Orig: testOrigURL, Tags: tooMany},
+		}
+
+		results, err := s.ImportURLs(context.Background(), testUserID, recs)
+		require.NoError(t, err)
+		require.Len(t, results, len(recs))
+
+		wantReasons := []error{errTest, errImportBadURL, errImportBadURL, errImportBadSlug, errImportBadSlug, errImportReserved, errImportExpired, errImportTags}
+		for i, res := range results {
+			assert.Equal(t, recs[i].Row, res.Row)
+			assert.Equal(t, models.ImportInvalid, res.Status)
+			assert.Equal(t, wantReasons[i].Error(), res.Reason)
+		}
+	})
+
+	t.Run("reserved health check slugs", func(t *testing.T) {
+		recs := []models.ImportRecord{
+			{Row: 1, Orig: testOrigURL, Short: "healthz"},
+			{Row: 2, Orig: testOrigURL, Short: "readyz"},
+			{Row: 3, Orig: "https://example.com/"},
+		}
+
+		mHash.EXPECT().GenerateHashFromURL(models.OrigURL("https://example.com/")).Return(models.ShortURL("healthz"))
+
+		results, err := s.ImportURLs(context.Background(), testUserID, recs)
+		require.NoError(t, err)
+		require.Len(t, results, len(recs))
+		for _, res := range results {
+			assert.Equal(t, models.ImportInvalid, res.Status)
+			assert.Equal(t, errImportReserved.Error(), res.Reason)
+		}
+	})
+
+	t.Run("repeated short in chunk", func(t *testing.T) {
+		recs := []models.ImportRecord{
+			{Row: 1, Orig: testOrigURL},
+			{Row: 2, Orig: testOrigURL},
+		}
+
+		mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL).Times(2)
+		mStrg.EXPECT().ImportURLPairs(gomock.Any(), []models.URLPair{
+			{UID: testUserID, Short: testShortURL, Orig: testOrigURL, CreatedAt: testNow},
+		}).Return([]bool{false}, nil)
+
+		results, err := s.ImportURLs(context.Background(), testUserID, recs)
+		require.NoError(t, err)
+		assert.Equal(t, models.ImportCreated, results[0].Status)
+		assert.Equal(t, models.ImportConflict, results[1].Status)
+		assert.Equal(t, errImportRepeated.Error(), results[1].Reason)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().ImportURLPairs(gomock.Any(), gomock.Any()).Return(nil, errTest)
+
+		_, err := s.ImportURLs(context.Background(), testUserID, []models.ImportRecord{{Row: 1, Orig: testOrigURL, Short: "custom"}})
+		assert.True(t, errors.Is(err, errTest))
+	})
+}
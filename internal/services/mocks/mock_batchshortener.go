@@ -87,6 +87,81 @@ func (mr *MockbatchURLFetcherMockRecorder) GetURLPairBatchByUserID(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLPairBatchByUserID", reflect.TypeOf((*MockbatchURLFetcher)(nil).GetURLPairBatchByUserID), arg0, arg1)
 }
 
+// MockbatchURLImporter is a mock of batchURLImporter interface.
+type MockbatchURLImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockbatchURLImporterMockRecorder
+}
+
+// MockbatchURLImporterMockRecorder is the mock recorder for MockbatchURLImporter.
+type MockbatchURLImporterMockRecorder struct {
+	mock *MockbatchURLImporter
+}
+
+// NewMockbatchURLImporter creates a new mock instance.
+func NewMockbatchURLImporter(ctrl *gomock.Controller) *MockbatchURLImporter {
+	mock := &MockbatchURLImporter{ctrl: ctrl}
+	mock.recorder = &MockbatchURLImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbatchURLImporter) EXPECT() *MockbatchURLImporterMockRecorder {
+	return m.recorder
+}
+
+// ImportURLPairs mocks base method.
+func (m *MockbatchURLImporter) ImportURLPairs(arg0 context.Context, arg1 []models.URLPair) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportURLPairs", arg0, arg1)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportURLPairs indicates an expected call of ImportURLPairs.
+func (mr *MockbatchURLImporterMockRecorder) ImportURLPairs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportURLPairs", reflect.TypeOf((*MockbatchURLImporter)(nil).ImportURLPairs), arg0, arg1)
+}
+
+// MockbatchURLIterator is a mock of batchURLIterator interface.
+type MockbatchURLIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockbatchURLIteratorMockRecorder
+}
+
+// MockbatchURLIteratorMockRecorder is the mock recorder for MockbatchURLIterator.
+type MockbatchURLIteratorMockRecorder struct {
+	mock *MockbatchURLIterator
+}
+
+// NewMockbatchURLIterator creates a new mock instance.
+func NewMockbatchURLIterator(ctrl *gomock.Controller) *MockbatchURLIterator {
+	mock := &MockbatchURLIterator{ctrl: ctrl}
+	mock.recorder = &MockbatchURLIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbatchURLIterator) EXPECT() *MockbatchURLIteratorMockRecorder {
+	return m.recorder
+}
+
+// IterateURLPairsByUserID mocks base method.
+func (m *MockbatchURLIterator) IterateURLPairsByUserID(arg0 context.Context, arg1 models.UserID, arg2 func(models.URLInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateURLPairsByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateURLPairsByUserID indicates an expected call of IterateURLPairsByUserID.
+func (mr *MockbatchURLIteratorMockRecorder) IterateURLPairsByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateURLPairsByUserID", reflect.TypeOf((*MockbatchURLIterator)(nil).IterateURLPairsByUserID), arg0, arg1, arg2)
+}
+
 // MockBatchShortenerStorage is a mock of BatchShortenerStorage interface.
 type MockBatchShortenerStorage struct {
 	ctrl     *gomock.Controller
@@ -139,6 +214,35 @@ func (mr *MockBatchShortenerStorageMockRecorder) GetURLPairBatchByUserID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLPairBatchByUserID", reflect.TypeOf((*MockBatchShortenerStorage)(nil).GetURLPairBatchByUserID), arg0, arg1)
 }
 
+// ImportURLPairs mocks base method.
+func (m *MockBatchShortenerStorage) ImportURLPairs(arg0 context.Context, arg1 []models.URLPair) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportURLPairs", arg0, arg1)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportURLPairs indicates an expected call of ImportURLPairs.
+func (mr *MockBatchShortenerStorageMockRecorder) ImportURLPairs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportURLPairs", reflect.TypeOf((*MockBatchShortenerStorage)(nil).ImportURLPairs), arg0, arg1)
+}
+
+// IterateURLPairsByUserID mocks base method.
+func (m *MockBatchShortenerStorage) IterateURLPairsByUserID(arg0 context.Context, arg1 models.UserID, arg2 func(models.URLInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateURLPairsByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateURLPairsByUserID indicates an expected call of IterateURLPairsByUserID.
+func (mr *MockBatchShortenerStorageMockRecorder) IterateURLPairsByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateURLPairsByUserID", reflect.TypeOf((*MockBatchShortenerStorage)(nil).IterateURLPairsByUserID), arg0, arg1, arg2)
+}
+
 // MockbatchHasher is a mock of batchHasher interface.
 type MockbatchHasher struct {
 	ctrl     *gomock.Controller
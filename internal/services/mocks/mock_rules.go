@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rules.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	net "net"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcountryResolver is a mock of countryResolver interface.
+type MockcountryResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockcountryResolverMockRecorder
+}
+
+// MockcountryResolverMockRecorder is the mock recorder for MockcountryResolver.
+type MockcountryResolverMockRecorder struct {
+	mock *MockcountryResolver
+}
+
+// NewMockcountryResolver creates a new mock instance.
+func NewMockcountryResolver(ctrl *gomock.Controller) *MockcountryResolver {
+	mock := &MockcountryResolver{ctrl: ctrl}
+	mock.recorder = &MockcountryResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcountryResolver) EXPECT() *MockcountryResolverMockRecorder {
+	return m.recorder
+}
+
+// Country mocks base method.
+func (m *MockcountryResolver) Country(arg0 net.IP) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Country", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Country indicates an expected call of Country.
+func (mr *MockcountryResolverMockRecorder) Country(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Country", reflect.TypeOf((*MockcountryResolver)(nil).Country), arg0)
+}
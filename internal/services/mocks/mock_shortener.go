@@ -87,6 +87,200 @@ func (mr *MockurlFetcherMockRecorder) GetURLPairByShort(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLPairByShort", reflect.TypeOf((*MockurlFetcher)(nil).GetURLPairByShort), arg0, arg1)
 }
 
+// MockurlLookuper is a mock of urlLookuper interface.
+type MockurlLookuper struct {
+	ctrl     *gomock.Controller
+	recorder *MockurlLookuperMockRecorder
+}
+
+// MockurlLookuperMockRecorder is the mock recorder for MockurlLookuper.
+type MockurlLookuperMockRecorder struct {
+	mock *MockurlLookuper
+}
+
+// NewMockurlLookuper creates a new mock instance.
+func NewMockurlLookuper(ctrl *gomock.Controller) *MockurlLookuper {
+	mock := &MockurlLookuper{ctrl: ctrl}
+	mock.recorder = &MockurlLookuperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockurlLookuper) EXPECT() *MockurlLookuperMockRecorder {
+	return m.recorder
+}
+
+// LookupURLPair mocks base method.
+func (m *MockurlLookuper) LookupURLPair(arg0 context.Context, arg1 models.ShortURL) (*models.URLPair, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLPair", arg0, arg1)
+	ret0, _ := ret[0].(*models.URLPair)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LookupURLPair indicates an expected call of LookupURLPair.
+func (mr *MockurlLookuperMockRecorder) LookupURLPair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLPair", reflect.TypeOf((*MockurlLookuper)(nil).LookupURLPair), arg0, arg1)
+}
+
+// LookupURLPairBatch mocks base method.
+func (m *MockurlLookuper) LookupURLPairBatch(arg0 context.Context, arg1 []models.ShortURL) (map[models.ShortURL]models.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLPairBatch", arg0, arg1)
+	ret0, _ := ret[0].(map[models.ShortURL]models.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupURLPairBatch indicates an expected call of LookupURLPairBatch.
+func (mr *MockurlLookuperMockRecorder) LookupURLPairBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLPairBatch", reflect.TypeOf((*MockurlLookuper)(nil).LookupURLPairBatch), arg0, arg1)
+}
+
+// MockurlOptionsUpdater is a mock of urlOptionsUpdater interface.
+type MockurlOptionsUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockurlOptionsUpdaterMockRecorder
+}
+
+// MockurlOptionsUpdaterMockRecorder is the mock recorder for MockurlOptionsUpdater.
+type MockurlOptionsUpdaterMockRecorder struct {
+	mock *MockurlOptionsUpdater
+}
+
+// NewMockurlOptionsUpdater creates a new mock instance.
+func NewMockurlOptionsUpdater(ctrl *gomock.Controller) *MockurlOptionsUpdater {
+	mock := &MockurlOptionsUpdater{ctrl: ctrl}
+	mock.recorder = &MockurlOptionsUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockurlOptionsUpdater) EXPECT() *MockurlOptionsUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateURLOptions mocks base method.
+func (m *MockurlOptionsUpdater) UpdateURLOptions(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 func(*models.LinkOptions) *models.LinkOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURLOptions indicates an expected call of UpdateURLOptions.
+func (mr *MockurlOptionsUpdaterMockRecorder) UpdateURLOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLOptions", reflect.TypeOf((*MockurlOptionsUpdater)(nil).UpdateURLOptions), arg0, arg1, arg2, arg3)
+}
+
+// MockvariantHitRecorder is a mock of variantHitRecorder interface.
+type MockvariantHitRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockvariantHitRecorderMockRecorder
+}
+
+// MockvariantHitRecorderMockRecorder is the mock recorder for MockvariantHitRecorder.
+type MockvariantHitRecorderMockRecorder struct {
+	mock *MockvariantHitRecorder
+}
+
+// NewMockvariantHitRecorder creates a new mock instance.
+func NewMockvariantHitRecorder(ctrl *gomock.Controller) *MockvariantHitRecorder {
+	mock := &MockvariantHitRecorder{ctrl: ctrl}
+	mock.recorder = &MockvariantHitRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvariantHitRecorder) EXPECT() *MockvariantHitRecorderMockRecorder {
+	return m.recorder
+}
+
+// AddVariantHit mocks base method.
+func (m *MockvariantHitRecorder) AddVariantHit(arg0 context.Context, arg1 models.ShortURL, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVariantHit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVariantHit indicates an expected call of AddVariantHit.
+func (mr *MockvariantHitRecorderMockRecorder) AddVariantHit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVariantHit", reflect.TypeOf((*MockvariantHitRecorder)(nil).AddVariantHit), arg0, arg1, arg2)
+}
+
+// GetVariantHits mocks base method.
+func (m *MockvariantHitRecorder) GetVariantHits(arg0 context.Context, arg1 models.ShortURL) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantHits", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantHits indicates an expected call of GetVariantHits.
+func (mr *MockvariantHitRecorderMockRecorder) GetVariantHits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantHits", reflect.TypeOf((*MockvariantHitRecorder)(nil).GetVariantHits), arg0, arg1)
+}
+
+// MockclickCounter is a mock of clickCounter interface.
+type MockclickCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockclickCounterMockRecorder
+}
+
+// MockclickCounterMockRecorder is the mock recorder for MockclickCounter.
+type MockclickCounterMockRecorder struct {
+	mock *MockclickCounter
+}
+
+// NewMockclickCounter creates a new mock instance.
+func NewMockclickCounter(ctrl *gomock.Controller) *MockclickCounter {
+	mock := &MockclickCounter{ctrl: ctrl}
+	mock.recorder = &MockclickCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockclickCounter) EXPECT() *MockclickCounterMockRecorder {
+	return m.recorder
+}
+
+// ConsumeClick mocks base method.
+func (m *MockclickCounter) ConsumeClick(arg0 context.Context, arg1 models.ShortURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockclickCounterMockRecorder) ConsumeClick(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockclickCounter)(nil).ConsumeClick), arg0, arg1)
+}
+
+// SetClickLimit mocks base method.
+func (m *MockclickCounter) SetClickLimit(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClickLimit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClickLimit indicates an expected call of SetClickLimit.
+func (mr *MockclickCounterMockRecorder) SetClickLimit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClickLimit", reflect.TypeOf((*MockclickCounter)(nil).SetClickLimit), arg0, arg1, arg2, arg3)
+}
+
 // MockShortenerStorage is a mock of ShortenerStorage interface.
 type MockShortenerStorage struct {
 	ctrl     *gomock.Controller
@@ -124,6 +318,34 @@ func (mr *MockShortenerStorageMockRecorder) AddURLPair(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddURLPair", reflect.TypeOf((*MockShortenerStorage)(nil).AddURLPair), arg0, arg1)
 }
 
+// AddVariantHit mocks base method.
+func (m *MockShortenerStorage) AddVariantHit(arg0 context.Context, arg1 models.ShortURL, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVariantHit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVariantHit indicates an expected call of AddVariantHit.
+func (mr *MockShortenerStorageMockRecorder) AddVariantHit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVariantHit", reflect.TypeOf((*MockShortenerStorage)(nil).AddVariantHit), arg0, arg1, arg2)
+}
+
+// ConsumeClick mocks base method.
+func (m *MockShortenerStorage) ConsumeClick(arg0 context.Context, arg1 models.ShortURL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockShortenerStorageMockRecorder) ConsumeClick(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockShortenerStorage)(nil).ConsumeClick), arg0, arg1)
+}
+
 // GetURLPairByShort mocks base method.
 func (m *MockShortenerStorage) GetURLPairByShort(arg0 context.Context, arg1 models.ShortURL) (*models.URLPair, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +361,80 @@ func (mr *MockShortenerStorageMockRecorder) GetURLPairByShort(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLPairByShort", reflect.TypeOf((*MockShortenerStorage)(nil).GetURLPairByShort), arg0, arg1)
 }
 
+// GetVariantHits mocks base method.
+func (m *MockShortenerStorage) GetVariantHits(arg0 context.Context, arg1 models.ShortURL) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantHits", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantHits indicates an expected call of GetVariantHits.
+func (mr *MockShortenerStorageMockRecorder) GetVariantHits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantHits", reflect.TypeOf((*MockShortenerStorage)(nil).GetVariantHits), arg0, arg1)
+}
+
+// LookupURLPair mocks base method.
+func (m *MockShortenerStorage) LookupURLPair(arg0 context.Context, arg1 models.ShortURL) (*models.URLPair, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLPair", arg0, arg1)
+	ret0, _ := ret[0].(*models.URLPair)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LookupURLPair indicates an expected call of LookupURLPair.
+func (mr *MockShortenerStorageMockRecorder) LookupURLPair(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLPair", reflect.TypeOf((*MockShortenerStorage)(nil).LookupURLPair), arg0, arg1)
+}
+
+// LookupURLPairBatch mocks base method.
+func (m *MockShortenerStorage) LookupURLPairBatch(arg0 context.Context, arg1 []models.ShortURL) (map[models.ShortURL]models.URLInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLPairBatch", arg0, arg1)
+	ret0, _ := ret[0].(map[models.ShortURL]models.URLInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupURLPairBatch indicates an expected call of LookupURLPairBatch.
+func (mr *MockShortenerStorageMockRecorder) LookupURLPairBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLPairBatch", reflect.TypeOf((*MockShortenerStorage)(nil).LookupURLPairBatch), arg0, arg1)
+}
+
+// SetClickLimit mocks base method.
+func (m *MockShortenerStorage) SetClickLimit(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClickLimit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClickLimit indicates an expected call of SetClickLimit.
+func (mr *MockShortenerStorageMockRecorder) SetClickLimit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClickLimit", reflect.TypeOf((*MockShortenerStorage)(nil).SetClickLimit), arg0, arg1, arg2, arg3)
+}
+
+// UpdateURLOptions mocks base method.
+func (m *MockShortenerStorage) UpdateURLOptions(arg0 context.Context, arg1 models.UserID, arg2 models.ShortURL, arg3 func(*models.LinkOptions) *models.LinkOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLOptions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURLOptions indicates an expected call of UpdateURLOptions.
+func (mr *MockShortenerStorageMockRecorder) UpdateURLOptions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLOptions", reflect.TypeOf((*MockShortenerStorage)(nil).UpdateURLOptions), arg0, arg1, arg2, arg3)
+}
+
 // Mockhasher is a mock of hasher interface.
 type Mockhasher struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// visitFromCtx extracts redirect request details stored by the transport layer.
+//
+// Returns nil if the request did not come through the redirect endpoint.
+func visitFromCtx(ctx context.Context) *models.Visit {
+	visit, ok := ctx.Value(contextkeys.Visit).(*models.Visit)
+	if !ok {
+		return nil
+	}
+	return visit
+}
+
+// buildDestination applies link passthrough settings to the destination URL.
+//
+// The destination query is assembled in the following order:
+//  1. parameters already present in the stored URL
+//  2. UTM tags defined on the link (they replace stored values)
+//  3. incoming request parameters merged with the configured conflict policy
+//
+// Trailing path segments are appended to the destination path,
+// see passthroughPath.
+func buildDestination(orig models.OrigURL, pt *models.Passthrough, visit *models.Visit) (models.OrigURL, error) {
+	if pt == nil {
+		return orig, nil
+	}
+
+	suffix, err := passthroughPath(pt, visit)
+	if err != nil {
+		return "", err
+	}
+
+	dest, err := url.Parse(string(orig))
+	if err != nil {
+		return "", err
+	}
+
+	query := dest.Query()
+	injectUTM(query, pt.UTM)
+
+	if visit != nil && pt.Query {
+		mergeQuery(query, visit.Query, pt.QueryConflict)
+	}
+	if suffix != "" {
+		dest.Path = strings.TrimSuffix(dest.Path, "/") + "/" + suffix
+		dest.RawPath = ""
+	}
+
+	dest.RawQuery = query.Encode()
+
+	return models.OrigURL(dest.String()), nil
+}
+
+// passthroughPath returns the trailing path of the visit appended to
+// the destination path, or empty string if nothing is appended.
+//
+// The escaped path is decoded and cleaned of dot segments. Returns invalid
+// path error if it climbs above the destination path or is malformed.
+func passthroughPath(pt *models.Passthrough, visit *models.Visit) (string, error) {
+	if pt == nil || !pt.Path || visit == nil || visit.Path == "" {
+		return "", nil
+	}
+
+	p, err := url.PathUnescape(visit.Path)
+	if err != nil {
+		return "", newErrInvalidPath(errInvalidPath)
+	}
+
+	cleaned := path.Clean(strings.TrimLeft(p, "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", newErrInvalidPath(errInvalidPath)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	if strings.HasSuffix(p, "/") {
+		cleaned += "/"
+	}
+
+	return cleaned, nil
+}
+
+func injectUTM(query url.Values, utm *models.UTMTags) {
+	if utm == nil {
+		return
+	}
+
+	tags := map[string]string{
+		"utm_source":   utm.Source,
+		"utm_medium":   utm.Medium,
+		"utm_campaign": utm.Campaign,
+		"utm_term":     utm.Term,
+		"utm_content":  utm.Content,
+	}
+	for key, value := range tags {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+}
+
+func mergeQuery(dst url.Values, src url.Values, policy string) {
+	for key, values := range src {
+		_, exists := dst[key]
+		switch {
+		case !exists:
+			dst[key] = append([]string(nil), values...)
+		case policy == models.QueryConflictOverride:
+			dst[key] = append([]string(nil), values...)
+		case policy == models.QueryConflictAppend:
+			dst[key] = append(dst[key], values...)
+		}
+	}
+}
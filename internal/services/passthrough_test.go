@@ -0,0 +1,162 @@
+package services
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDestination(t *testing.T) {
+	tests := []struct {
+		name  string
+		orig  models.OrigURL
+		pt    *models.Passthrough
+		visit *models.Visit
+		want  models.OrigURL
+	}{
+		{
+			name: "no passthrough",
+			orig: "https://example.com/a?x=1",
+			visit: &models.Visit{
+				Query: url.Values{"y": {"2"}},
+				Path:  "b",
+			},
+			want: "https://example.com/a?x=1",
+		},
+		{
+			name: "query disabled",
+			orig: "https://example.com/a?x=1",
+			pt:   &models.Passthrough{},
+			visit: &models.Visit{
+				Query: url.Values{"y": {"2"}},
+			},
+			want: "https://example.com/a?x=1",
+		},
+		{
+			name: "query merge",
+			orig: "https://example.com/a?x=1",
+			pt:   &models.Passthrough{Query: true},
+			visit: &models.Visit{
+				Query: url.Values{"y": {"2"}},
+			},
+			want: "https://example.com/a?x=1&y=2",
+		},
+		{
+			name: "query conflict keep",
+			orig: "https://example.com/a?x=1",
+			pt:   &models.Passthrough{Query: true, QueryConflict: models.QueryConflictKeep},
+			visit: &models.Visit{
+				Query: url.Values{"x": {"2"}},
+			},
+			want: "https://example.com/a?x=1",
+		},
+		{
+			name: "query conflict override",
+			orig: "https://example.com/a?x=1",
+			pt:   &models.Passthrough{Query: true, QueryConflict: models.QueryConflictOverride},
+			visit: &models.Visit{
+				Query: url.Values{"x": {"2"}},
+			},
+			want: "https://example.com/a?x=2",
+		},
+		{
+			name: "query conflict append",
+			orig: "https://example.com/a?x=1",
+			pt:   &models.Passthrough{Query: true, QueryConflict: models.QueryConflictAppend},
+			visit: &models.Visit{
+				Query: url.Values{"x": {"2"}},
+			},
+			want: "https://example.com/a?x=1&x=2",
+		},
+		{
+			name: "path append",
+			orig: "https://example.com/docs/",
+			pt:   &models.Passthrough{Path: true},
+			visit: &models.Visit{
+				Path: "guide/intro",
+			},
+			want: "https://example.com/docs/guide/intro",
+		},
+		{
+			name: "path append to root",
+			orig: "https://example.com",
+			pt:   &models.Passthrough{Path: true},
+			visit: &models.Visit{
+				Path: "guide",
+			},
+			want: "https://example.com/guide",
+		},
+		{
+			name: "path dot segments",
+			orig: "https://example.com/docs/",
+			pt:   &models.Passthrough{Path: true},
+			visit: &models.Visit{
+				Path: "guide/../faq/./intro/",
+			},
+			want: "https://example.com/docs/faq/intro/",
+		},
+		{
+			name: "path escaped",
+			orig: "https://example.com/docs",
+			pt:   &models.Passthrough{Path: true},
+			visit: &models.Visit{
+				Path: "a%20b/%2e%2e/c",
+			},
+			want: "https://example.com/docs/c",
+		},
+		{
+			name: "path disabled",
+			orig: "https://example.com/docs",
+			pt:   &models.Passthrough{},
+			visit: &models.Visit{
+				Path: "../../admin",
+			},
+			want: "https://example.com/docs",
+		},
+		{
+			name: "utm injection",
+			orig: "https://example.com/?utm_source=old",
+			pt: &models.Passthrough{
+				UTM: &models.UTMTags{Source: "news", Campaign: "spring"},
+			},
+			want: "https://example.com/?utm_campaign=spring&utm_source=news",
+		},
+		{
+			name: "utm with request override",
+			orig: "https://example.com/",
+			pt: &models.Passthrough{
+				Query:         true,
+				QueryConflict: models.QueryConflictOverride,
+				UTM:           &models.UTMTags{Source: "news"},
+			},
+			visit: &models.Visit{
+				Query: url.Values{"utm_source": {"x"}},
+			},
+			want: "https://example.com/?utm_source=x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildDestination(tt.orig, tt.pt, tt.visit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("path escapes destination", func(t *testing.T) {
+		for _, p := range []string{"..", "../../admin", "a/../../admin", "%2e%2e/admin", "%2E%2E%2fadmin", "bad%zz"} {
+			_, err := buildDestination("https://example.com/docs/", &models.Passthrough{Path: true}, &models.Visit{Path: p})
+			var e *invalidPath
+			assert.ErrorAs(t, err, &e, p)
+		}
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := buildDestination(":bad", &models.Passthrough{}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password brute-force protection parameters.
+const (
+	// maxPasswordFailures is the number of wrong passwords allowed per link
+	// within passwordFailureWindow.
+	maxPasswordFailures = 5
+
+	// passwordFailureWindow is the period failures are counted in.
+	passwordFailureWindow = time.Minute
+
+	// passwordLimiterPurgeSize is the number of tracked links
+	// above which expired entries are removed.
+	passwordLimiterPurgeSize = 10000
+)
+
+// attemptLimiter throttles password guessing per short URL.
+//
+// Wrong passwords are counted in a fixed window. After reaching the limit
+// further attempts are rejected until the window ends.
+type attemptLimiter struct {
+	mu       sync.Mutex
+	failures map[models.ShortURL]*failures
+	max      int
+	window   time.Duration
+	now      func() time.Time
+}
+
+type failures struct {
+	count int
+	start time.Time
+}
+
+func newAttemptLimiter(max int, window time.Duration) *attemptLimiter {
+	return &attemptLimiter{
+		failures: make(map[models.ShortURL]*failures),
+		max:      max,
+		window:   window,
+		now:      time.Now,
+	}
+}
+
+// allow reports whether password check of the link may be performed.
+// Otherwise returns time left until the limit is lifted.
+func (l *attemptLimiter) allow(short models.ShortURL) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, ok := l.failures[short]
+	if !ok || f.count < l.max {
+		return 0, true
+	}
+
+	left := f.start.Add(l.window).Sub(l.now())
+	if left <= 0 {
+		delete(l.failures, short)
+		return 0, true
+	}
+
+	return left, false
+}
+
+// fail records a wrong password attempt.
+func (l *attemptLimiter) fail(short models.ShortURL) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if len(l.failures) >= passwordLimiterPurgeSize {
+		for k, f := range l.failures {
+			if now.Sub(f.start) >= l.window {
+				delete(l.failures, k)
+			}
+		}
+	}
+
+	f, ok := l.failures[short]
+	if !ok || now.Sub(f.start) >= l.window {
+		l.failures[short] = &failures{count: 1, start: now}
+		return
+	}
+	f.count++
+}
+
+// checkPassword verifies visitor password of a protected link.
+func (s *Shortener) checkPassword(short models.ShortURL, hash string, visit *models.Visit) error {
+	if visit == nil || visit.Password == "" {
+		return newErrPasswordRequired(errPasswordRequired)
+	}
+
+	if left, ok := s.limiter.allow(short); !ok {
+		return newErrTooManyAttempts(errTooManyAttempts, left)
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(visit.Password))
+	if err != nil {
+		s.limiter.fail(short)
+		return newErrWrongPassword(errWrongPassword)
+	}
+
+	return nil
+}
+
+// SetPassword protects the URL owned by user with password.
+//
+// Only bcrypt hash of the password is stored. Empty password removes protection.
+// Other link settings are preserved.
+func (s *Shortener) SetPassword(ctx context.Context, uid models.UserID, short models.ShortURL, password string) error {
+	ctx, span := tracing.Start(ctx, "Shortener.SetPassword")
+	defer span.End()
+
+	var hash string
+	if password != "" {
+		generated, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		hash = string(generated)
+	}
+
+	return s.updateOptions(ctx, uid, short, func(opts *models.LinkOptions) {
+		opts.PasswordHash = hash
+	})
+}
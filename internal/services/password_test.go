@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "secret"
+
+func TestAttemptLimiter(t *testing.T) {
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	l := newAttemptLimiter(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	_, ok := l.allow(testShortURL)
+	assert.True(t, ok)
+
+	l.fail(testShortURL)
+	_, ok = l.allow(testShortURL)
+	assert.True(t, ok)
+
+	l.fail(testShortURL)
+	now = now.Add(20 * time.Second)
+	left, ok := l.allow(testShortURL)
+	assert.False(t, ok)
+	assert.Equal(t, 40*time.Second, left)
+
+	_, ok = l.allow(testDeletedShort)
+	assert.True(t, ok, "other links are not affected")
+
+	now = now.Add(40 * time.Second)
+	_, ok = l.allow(testShortURL)
+	assert.True(t, ok)
+}
+
+func TestShortener_GetOrigURLByShort_Password(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	pair := testPair
+	pair.Options = &models.LinkOptions{
+		PasswordHash: string(hash),
+	}
+
+	get := func(password string) (models.OrigURL, error) {
+		ctx := context.WithValue(context.Background(), contextkeys.Visit, &models.Visit{Password: password})
+		return s.GetOrigURLByShort(ctx, testShortURL)
+	}
+
+	t.Run("valid password", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		orig, err := get(testPassword)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("password required", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := get("")
+		var e interface{ IsErrPasswordRequired() bool }
+		require.ErrorAs(t, err, &e)
+	})
+
+	t.Run("wrong password and throttling", func(t *testing.T) {
+		for i := 0; i < maxPasswordFailures; i++ {
+			mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+			_, err := get("guess")
+			var e interface{ IsErrWrongPassword() bool }
+			require.ErrorAs(t, err, &e)
+		}
+
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := get(testPassword)
+		var e interface {
+			IsErrTooManyAttempts() bool
+			RetryAfter() time.Duration
+		}
+		require.ErrorAs(t, err, &e)
+		assert.Positive(t, e.RetryAfter())
+	})
+}
+
+func TestShortener_SetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	stored := &models.LinkOptions{
+		Passthrough: &models.Passthrough{Query: true},
+	}
+
+	t.Run("set password", func(t *testing.T) {
+		var got *models.LinkOptions
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).DoAndReturn(updateOptionsOf(stored, &got))
+
+		err := s.SetPassword(context.Background(), testUserID, testShortURL, testPassword)
+		require.NoError(t, err)
+		assert.Equal(t, stored.Passthrough, got.Passthrough)
+		err = bcrypt.CompareHashAndPassword([]byte(got.PasswordHash), []byte(testPassword))
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove password", func(t *testing.T) {
+		protected := &models.LinkOptions{
+			Passthrough:  stored.Passthrough,
+			PasswordHash: "hash",
+		}
+		var got *models.LinkOptions
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).DoAndReturn(updateOptionsOf(protected, &got))
+
+		err := s.SetPassword(context.Background(), testUserID, testShortURL, "")
+		assert.NoError(t, err)
+		assert.Equal(t, stored, got)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).Return(errTest)
+
+		err := s.SetPassword(context.Background(), testUserID, testShortURL, testPassword)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// countryResolver defines visitor country lookup.
+type countryResolver interface {
+	// Country returns ISO 3166-1 alpha-2 code of the country the address belongs to.
+	Country(net.IP) (string, error)
+}
+
+// ruleMatcher evaluates routing rules against a single visit.
+//
+// Visitor attributes are computed lazily and only once.
+type ruleMatcher struct {
+	visit *models.Visit
+	geo   countryResolver
+	log   *zap.Logger
+
+	platform  string
+	languages []string
+	country   string
+	parsed    bool
+	located   bool
+}
+
+// matchRule returns the first rule matching the visit.
+//
+// Returns nil if there is no visit details or no rule matches.
+func (s *Shortener) matchRule(ctx context.Context, rules []models.Rule, visit *models.Visit) *models.Rule {
+	if visit == nil || len(rules) == 0 {
+		return nil
+	}
+
+	m := &ruleMatcher{
+		visit: visit,
+		geo:   s.geo,
+		log:   logger.FromContext(ctx),
+	}
+	for i := range rules {
+		if m.match(&rules[i]) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+// TestRules evaluates routing rules of the URL owned by user against
+// a sample visitor. Rules of the test replace the stored ones if present.
+//
+// Nothing is recorded, so it is safe to use on live links.
+func (s *Shortener) TestRules(ctx context.Context, uid models.UserID, short models.ShortURL, test *models.RuleTest) (*models.RuleTestResult, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.TestRules")
+	defer span.End()
+
+	pair, err := s.getUserPair(ctx, uid, short)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := test.Rules
+	if rules == nil && pair.Options != nil {
+		rules = pair.Options.Rules
+	}
+
+	visit := &models.Visit{
+		UserAgent:      test.UserAgent,
+		AcceptLanguage: test.AcceptLanguage,
+		IP:             net.ParseIP(test.IP),
+	}
+	if test.Time != nil {
+		visit.Time = *test.Time
+	}
+
+	m := &ruleMatcher{
+		visit: visit,
+		geo:   s.geo,
+		log:   logger.FromContext(ctx),
+	}
+	if test.Country != "" {
+		m.country = strings.ToUpper(test.Country)
+		m.located = true
+	}
+
+	res := &models.RuleTestResult{
+		URL: pair.Orig,
+	}
+	for i := range rules {
+		if m.match(&rules[i]) {
+			res.Rule = &i
+			res.URL = rules[i].URL
+			break
+		}
+	}
+
+	m.parseHeaders()
+	res.Platform = m.platform
+	res.Languages = m.languages
+	res.Country = m.lookupCountry()
+
+	return res, nil
+}
+
+func (m *ruleMatcher) match(rule *models.Rule) bool {
+	if len(rule.Platforms) > 0 || len(rule.Languages) > 0 {
+		m.parseHeaders()
+	}
+
+	if len(rule.Platforms) > 0 && !containsFold(rule.Platforms, m.platform) {
+		return false
+	}
+	if len(rule.Languages) > 0 && !matchLanguages(rule.Languages, m.languages) {
+		return false
+	}
+	if len(rule.Countries) > 0 && !containsFold(rule.Countries, m.lookupCountry()) {
+		return false
+	}
+	if rule.Window != nil {
+		ok, err := inWindow(rule.Window, m.visitTime())
+		if err != nil {
+			m.log.Warn("invalid rule time window", zap.Error(err))
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *ruleMatcher) parseHeaders() {
+	if m.parsed {
+		return
+	}
+	m.platform = detectPlatform(m.visit.UserAgent)
+	m.languages = parseAcceptLanguage(m.visit.AcceptLanguage)
+	m.parsed = true
+}
+
+func (m *ruleMatcher) lookupCountry() string {
+	if m.located {
+		return m.country
+	}
+	m.located = true
+
+	if m.geo == nil || m.visit.IP == nil {
+		return ""
+	}
+
+	country, err := m.geo.Country(m.visit.IP)
+	if err != nil {
+		m.log.Debug("country lookup failed", zap.String("ip", m.visit.IP.String()), zap.Error(err))
+		return ""
+	}
+	m.country = country
+
+	return m.country
+}
+
+func (m *ruleMatcher) visitTime() time.Time {
+	if m.visit.Time.IsZero() {
+		return time.Now()
+	}
+	return m.visit.Time
+}
+
+// detectPlatform guesses visitor platform from the User-Agent header.
+func detectPlatform(ua string) string {
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"), strings.Contains(ua, "iPod"):
+		return models.PlatformIOS
+	case strings.Contains(ua, "Android"):
+		return models.PlatformAndroid
+	default:
+		return models.PlatformDesktop
+	}
+}
+
+// parseAcceptLanguage returns lowercased language tags of the Accept-Language
+// header ordered by preference. Tags with zero quality are dropped.
+func parseAcceptLanguage(header string) []string {
+	type tag struct {
+		name string
+		q    float64
+	}
+
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, tag{name: name, q: q})
+	}
+
+	// stable insertion sort keeps header order for equal weights
+	for i := 1; i < len(tags); i++ {
+		for j := i; j > 0 && tags[j].q > tags[j-1].q; j-- {
+			tags[j], tags[j-1] = tags[j-1], tags[j]
+		}
+	}
+
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.name
+	}
+
+	return names
+}
+
+// matchLanguages reports whether any accepted language satisfies rule languages.
+func matchLanguages(want, accepted []string) bool {
+	for _, a := range accepted {
+		for _, w := range want {
+			w = strings.ToLower(w)
+			if a == w || strings.HasPrefix(a, w+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// locations caches loaded time zones by name.
+var locations sync.Map
+
+// loadLocation returns the time zone with the given IANA name.
+// Empty name means UTC.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// inWindow reports whether the moment falls into the time window.
+//
+// Windows are validated when rules are saved, so an error means
+// the stored rule is broken and never matches.
+func inWindow(w *models.TimeWindow, t time.Time) (bool, error) {
+	if w.From != nil && t.Before(*w.From) {
+		return false, nil
+	}
+	if w.To != nil && !t.Before(*w.To) {
+		return false, nil
+	}
+	if w.DailyFrom == "" && w.DailyTo == "" {
+		return true, nil
+	}
+
+	loc, err := loadLocation(w.Timezone)
+	if err != nil {
+		return false, err
+	}
+	t = t.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+
+	from, to := 0, 24*60
+	if w.DailyFrom != "" {
+		from = clockMinutes(w.DailyFrom)
+	}
+	if w.DailyTo != "" {
+		to = clockMinutes(w.DailyTo)
+	}
+	if from < 0 || to < 0 {
+		return false, fmt.Errorf("bad daily hours %q-%q", w.DailyFrom, w.DailyTo)
+	}
+
+	if from <= to {
+		return from <= minute && minute < to, nil
+	}
+	return minute >= from || minute < to, nil
+}
+
+// clockMinutes converts HH:MM time of day into minutes since midnight.
+// Returns -1 for malformed values.
+func clockMinutes(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return -1
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+func containsFold(list []string, v string) bool {
+	if v == "" {
+		return false
+	}
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/contextkeys"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUAiPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15"
+	testUAAndroid = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36"
+	testUADesktop = "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	assert.Equal(t, models.PlatformIOS, detectPlatform(testUAiPhone))
+	assert.Equal(t, models.PlatformAndroid, detectPlatform(testUAAndroid))
+	assert.Equal(t, models.PlatformDesktop, detectPlatform(testUADesktop))
+	assert.Equal(t, models.PlatformDesktop, detectPlatform(""))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "de-DE", want: []string{"de-de"}},
+		{header: "fr;q=0.5, en-US, de;q=0.8", want: []string{"en-us", "de", "fr"}},
+		{header: "ru, *;q=0.1, en;q=0", want: []string{"ru"}},
+		{header: "en;q=bad, es", want: []string{"es"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMatchLanguages(t *testing.T) {
+	assert.True(t, matchLanguages([]string{"en"}, []string{"en-gb"}))
+	assert.True(t, matchLanguages([]string{"EN-GB"}, []string{"en-gb"}))
+	assert.False(t, matchLanguages([]string{"en-us"}, []string{"en-gb"}))
+	assert.False(t, matchLanguages([]string{"e"}, []string{"en"}))
+	assert.False(t, matchLanguages([]string{"de"}, nil))
+}
+
+func TestInWindow(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	ptr := func(s string) *time.Time {
+		tm := at(s)
+		return &tm
+	}
+
+	tests := []struct {
+		name    string
+		window  models.TimeWindow
+		now     string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "inside date range",
+			window: models.TimeWindow{From: ptr("2025-11-01T00:00:00Z"), To: ptr("2025-12-01T00:00:00Z")},
+			now:    "2025-11-15T12:00:00Z",
+			want:   true,
+		},
+		{
+			name:   "before date range",
+			window: models.TimeWindow{From: ptr("2025-11-01T00:00:00Z")},
+			now:    "2025-10-31T23:59:59Z",
+			want:   false,
+		},
+		{
+			name:   "end is exclusive",
+			window: models.TimeWindow{To: ptr("2025-12-01T00:00:00Z")},
+			now:    "2025-12-01T00:00:00Z",
+			want:   false,
+		},
+		{
+			name:   "daily hours",
+			window: models.TimeWindow{DailyFrom: "09:00", DailyTo: "18:00"},
+			now:    "2025-11-15T09:00:00Z",
+			want:   true,
+		},
+		{
+			name:   "outside daily hours",
+			window: models.TimeWindow{DailyFrom: "09:00", DailyTo: "18:00"},
+			now:    "2025-11-15T18:00:00Z",
+			want:   false,
+		},
+		{
+			name:   "overnight hours",
+			window: models.TimeWindow{DailyFrom: "22:00", DailyTo: "06:00"},
+			now:    "2025-11-15T02:30:00Z",
+			want:   true,
+		},
+		{
+			name:   "daily hours in time zone",
+			window: models.TimeWindow{DailyFrom: "09:00", DailyTo: "18:00", Timezone: "Europe/Moscow"},
+			now:    "2025-11-15T07:00:00Z",
+			want:   true,
+		},
+		{
+			name:   "only start of day",
+			window: models.TimeWindow{DailyFrom: "12:00"},
+			now:    "2025-11-15T23:59:00Z",
+			want:   true,
+		},
+		{
+			name:    "bad time zone",
+			window:  models.TimeWindow{DailyFrom: "00:00", Timezone: "Mars/Olympus"},
+			now:     "2025-11-15T12:00:00Z",
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name:    "bad daily hours",
+			window:  models.TimeWindow{DailyFrom: "25:00"},
+			now:     "2025-11-15T12:00:00Z",
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := inWindow(&tt.window, at(tt.now))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestShortener_GetOrigURLByShort_Rules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+	mGeo := mocks.NewMockcountryResolver(ctrl)
+
+	s := NewShortener(mStrg, mHash, WithCountryResolver(mGeo))
+
+	pair := testPair
+	pair.Options = &models.LinkOptions{
+		Rules: []models.Rule{
+			{Platforms: []string{models.PlatformIOS}, URL: "https://apps.apple.com/app/id1"},
+			{Platforms: []string{models.PlatformAndroid}, URL: "https://play.google.com/store/apps/details?id=app"},
+			{Countries: []string{"DE"}, URL: "https://example.de/"},
+			{Languages: []string{"fr"}, URL: "https://example.fr/"},
+		},
+	}
+
+	get := func(visit *models.Visit) (models.OrigURL, error) {
+		ctx := context.Background()
+		if visit != nil {
+			ctx = context.WithValue(ctx, contextkeys.Visit, visit)
+		}
+		return s.GetOrigURLByShort(ctx, testShortURL)
+	}
+
+	t.Run("platform rule", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		orig, err := get(&models.Visit{UserAgent: testUAiPhone})
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrigURL("https://apps.apple.com/app/id1"), orig)
+	})
+
+	t.Run("country rule", func(t *testing.T) {
+		ip := net.ParseIP("81.2.69.142")
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mGeo.EXPECT().Country(ip).Return("DE", nil)
+
+		orig, err := get(&models.Visit{UserAgent: testUADesktop, AcceptLanguage: "fr", IP: ip})
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrigURL("https://example.de/"), orig)
+	})
+
+	t.Run("country lookup error", func(t *testing.T) {
+		ip := net.ParseIP("10.0.0.1")
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mGeo.EXPECT().Country(ip).Return("", errTest)
+
+		orig, err := get(&models.Visit{UserAgent: testUADesktop, AcceptLanguage: "fr-CA", IP: ip})
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrigURL("https://example.fr/"), orig)
+	})
+
+	t.Run("no rule matches", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		orig, err := get(&models.Visit{UserAgent: testUADesktop, AcceptLanguage: "en"})
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("no visit details", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		orig, err := get(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("rules take precedence over destinations", func(t *testing.T) {
+		withDests := pair
+		opts := *pair.Options
+		opts.Destinations = testDestinations
+		withDests.Options = &opts
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&withDests, nil)
+
+		orig, err := get(&models.Visit{VisitorID: "visitor", UserAgent: testUAAndroid})
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrigURL("https://play.google.com/store/apps/details?id=app"), orig)
+	})
+}
+
+func TestShortener_TestRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+	mGeo := mocks.NewMockcountryResolver(ctrl)
+
+	s := NewShortener(mStrg, mHash, WithCountryResolver(mGeo))
+
+	pair := testPair
+	pair.Options = &models.LinkOptions{
+		Rules: []models.Rule{
+			{Platforms: []string{models.PlatformIOS}, URL: "https://apps.apple.com/app/id1"},
+			{Countries: []string{"DE"}, URL: "https://example.de/"},
+		},
+	}
+
+	t.Run("stored rules", func(t *testing.T) {
+		ip := net.ParseIP("81.2.69.142")
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+		mGeo.EXPECT().Country(ip).Return("DE", nil)
+
+		res, err := s.TestRules(context.Background(), testUserID, testShortURL, &models.RuleTest{
+			UserAgent:      testUADesktop,
+			AcceptLanguage: "de-DE,en;q=0.5",
+			IP:             ip.String(),
+		})
+		assert.NoError(t, err)
+		rule := 1
+		assert.Equal(t, &models.RuleTestResult{
+			Platform:  models.PlatformDesktop,
+			Languages: []string{"de-de", "en"},
+			Country:   "DE",
+			Rule:      &rule,
+			URL:       "https://example.de/",
+		}, res)
+	})
+
+	t.Run("country override", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		res, err := s.TestRules(context.Background(), testUserID, testShortURL, &models.RuleTest{
+			IP:      "81.2.69.142",
+			Country: "fr",
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, res.Rule)
+		assert.Equal(t, "FR", res.Country)
+		assert.Equal(t, testOrigURL, res.URL)
+	})
+
+	t.Run("draft rules", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		res, err := s.TestRules(context.Background(), testUserID, testShortURL, &models.RuleTest{
+			UserAgent: testUAAndroid,
+			Rules: []models.Rule{
+				{Platforms: []string{models.PlatformAndroid}, URL: "https://play.google.com/store/apps/details?id=app"},
+			},
+		})
+		assert.NoError(t, err)
+		rule := 0
+		assert.Equal(t, &rule, res.Rule)
+		assert.Equal(t, models.OrigURL("https://play.google.com/store/apps/details?id=app"), res.URL)
+	})
+
+	t.Run("not owned", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := s.TestRules(context.Background(), "other", testShortURL, &models.RuleTest{})
+		var e interface{ IsErrNotExist() bool }
+		assert.ErrorAs(t, err, &e)
+	})
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+)
+
+// checkSchedule verifies the link is live at the moment.
+func (s *Shortener) checkSchedule(sched models.Schedule) error {
+	now := s.now()
+	if sched.IsUpcoming(now) {
+		return newErrNotActive(errNotActive, *sched.ActiveFrom)
+	}
+	if sched.IsExpired(now) {
+		return newErrExpired(errExpired)
+	}
+	return nil
+}
+
+// GetUpcomingURLs retrieves user URLs which are not live yet.
+//
+// Returns URL pairs ordered by activation time or empty slice if none found.
+func (s *BatchShortener) GetUpcomingURLs(ctx context.Context, uid models.UserID) ([]models.URLPair, error) {
+	ctx, span := tracing.Start(ctx, "BatchShortener.GetUpcomingURLs")
+	defer span.End()
+
+	pairs, err := s.strg.GetURLPairBatchByUserID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	var upcoming []models.URLPair
+	for _, pair := range pairs {
+		if pair.IsUpcoming(now) {
+			upcoming = append(upcoming, pair)
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].ActiveFrom.Before(*upcoming[j].ActiveFrom)
+	})
+
+	return upcoming, nil
+}
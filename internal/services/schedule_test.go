@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortener_ShortenScheduledURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	sched := models.Schedule{ActiveFrom: &from, ExpiresAt: &to}
+
+	mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL)
+	mStrg.EXPECT().AddURLPair(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, pair *models.URLPair) error {
+			assert.Equal(t, sched, pair.Schedule)
+			return nil
+		})
+
+	pair, err := s.ShortenScheduledURL(context.Background(), testUserID, testOrigURL, sched)
+	assert.NoError(t, err)
+	assert.Equal(t, testShortURL, pair.Short)
+}
+
+func TestShortener_GetOrigURLByShort_Schedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewShortener(mStrg, mHash)
+	s.now = func() time.Time { return now }
+
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	t.Run("active link", func(t *testing.T) {
+		pair := testPair
+		pair.Schedule = models.Schedule{ActiveFrom: &past, ExpiresAt: &future}
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		orig, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrigURL, orig)
+	})
+
+	t.Run("not active yet", func(t *testing.T) {
+		pair := testPair
+		pair.ActiveFrom = &future
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errNotActive)
+		e, ok := err.(*notActive)
+		require.True(t, ok)
+		assert.Equal(t, future, e.ActiveFrom())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		pair := testPair
+		pair.ExpiresAt = &now
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errExpired)
+	})
+
+	t.Run("limited link keeps clicks before activation", func(t *testing.T) {
+		left := int64(1)
+		pair := testPair
+		pair.ActiveFrom = &future
+		pair.ClicksLeft = &left
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		_, err := s.GetOrigURLByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errNotActive)
+	})
+}
+
+func TestBatchShortener_GetUpcomingURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockbatchHasher(ctrl)
+	mStrg := mocks.NewMockBatchShortenerStorage(ctrl)
+
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewBatchShortener(mStrg, mHash)
+	s.now = func() time.Time { return now }
+
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	first := models.URLPair{UID: testUserID, Short: "first", Orig: testOrigURL, Schedule: models.Schedule{ActiveFrom: &soon}}
+	second := models.URLPair{UID: testUserID, Short: "second", Orig: testOrigURL, Schedule: models.Schedule{ActiveFrom: &later}}
+	live := models.URLPair{UID: testUserID, Short: "live", Orig: testOrigURL, Schedule: models.Schedule{ActiveFrom: &past}}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairBatchByUserID(gomock.Any(), testUserID).Return([]models.URLPair{second, testPair, live, first}, nil)
+
+		pairs, err := s.GetUpcomingURLs(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLPair{first, second}, pairs)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mStrg.EXPECT().GetURLPairBatchByUserID(gomock.Any(), testUserID).Return(nil, errTest)
+
+		_, err := s.GetUpcomingURLs(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -3,9 +3,12 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/rycln/shorturl/internal/contextkeys"
 	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
@@ -24,14 +27,60 @@ type urlFetcher interface {
 	GetURLPairByShort(context.Context, models.ShortURL) (*models.URLPair, error)
 }
 
+// urlLookuper defines URL inspection operations.
+type urlLookuper interface {
+	// LookupURLPair retrieves URL pair by short URL including deleted ones
+	// and reports whether it was deleted.
+	LookupURLPair(context.Context, models.ShortURL) (*models.URLPair, bool, error)
+
+	// LookupURLPairBatch retrieves URL pairs by short URLs including deleted ones
+	// in a single storage request. Unknown short URLs are absent from the result.
+	LookupURLPairBatch(context.Context, []models.ShortURL) (map[models.ShortURL]models.URLInfo, error)
+}
+
+// urlOptionsUpdater defines per-link settings operations.
+type urlOptionsUpdater interface {
+	// UpdateURLOptions atomically replaces settings of the URL pair owned
+	// by user with the result of update applied to the stored ones.
+	UpdateURLOptions(context.Context, models.UserID, models.ShortURL, func(*models.LinkOptions) *models.LinkOptions) error
+}
+
+// variantHitRecorder defines multi-destination link statistics operations.
+type variantHitRecorder interface {
+	// AddVariantHit increments redirect counter of the link variant.
+	AddVariantHit(context.Context, models.ShortURL, string) error
+
+	// GetVariantHits returns redirect counters of all link variants.
+	GetVariantHits(context.Context, models.ShortURL) (map[string]int64, error)
+}
+
+// clickCounter defines operations of links with limited number of redirects.
+type clickCounter interface {
+	// SetClickLimit sets number of redirects left for the URL pair owned by user.
+	// Nil limit makes the link unlimited.
+	SetClickLimit(context.Context, models.UserID, models.ShortURL, *int64) error
+
+	// ConsumeClick atomically takes one redirect of the limited link.
+	// The link is deleted when the last redirect is taken.
+	ConsumeClick(context.Context, models.ShortURL) error
+}
+
 // ShortenerStorage combines storage operations needed for URL processing.
 //
-// The interface composes two fundamental capabilities required by the Shortener service:
+// The interface composes fundamental capabilities required by the Shortener service:
 //   - Saving URLs
 //   - Retrieving URLs
+//   - Inspecting URLs without following them
+//   - Updating per-link settings
+//   - Counting multi-destination variant redirects
+//   - Counting redirects of limited links
 type ShortenerStorage interface {
 	urlSaver
 	urlFetcher
+	urlLookuper
+	urlOptionsUpdater
+	variantHitRecorder
+	clickCounter
 }
 
 type hasher interface {
@@ -50,27 +99,71 @@ type errConflict interface {
 // - Retrieving original URLs from shortened versions
 // - Context-based URL resolution for HTTP handlers
 type Shortener struct {
-	strg   ShortenerStorage
-	hasher hasher
+	strg    ShortenerStorage
+	hasher  hasher
+	geo     countryResolver
+	limiter *attemptLimiter
+	now     func() time.Time
+}
+
+type shortenerOption func(*Shortener)
+
+// WithCountryResolver enables country conditions of routing rules.
+func WithCountryResolver(geo countryResolver) shortenerOption {
+	return func(s *Shortener) {
+		s.geo = geo
+	}
 }
 
 // NewShortener creates a new Shortener service instance.
-func NewShortener(strg ShortenerStorage, hasher hasher) *Shortener {
-	return &Shortener{
-		strg:   strg,
-		hasher: hasher,
+func NewShortener(strg ShortenerStorage, hasher hasher, opts ...shortenerOption) *Shortener {
+	s := &Shortener{
+		strg:    strg,
+		hasher:  hasher,
+		limiter: newAttemptLimiter(maxPasswordFailures, passwordFailureWindow),
+		now:     time.Now,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // Shorten creates a URLpair instance from original URL and user id.
 //
 // Returns the shortened URL pair or error if operation fails.
 func (s *Shortener) ShortenURL(ctx context.Context, uid models.UserID, orig models.OrigURL) (*models.URLPair, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.ShortenURL")
+	defer span.End()
+
+	return s.shorten(ctx, uid, orig, models.Schedule{})
+}
+
+// ShortenScheduledURL creates a URLpair instance that redirects only
+// within the schedule period.
+//
+// Returns the shortened URL pair or error if operation fails.
+func (s *Shortener) ShortenScheduledURL(ctx context.Context, uid models.UserID, orig models.OrigURL, sched models.Schedule) (*models.URLPair, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.ShortenScheduledURL")
+	defer span.End()
+
+	return s.shorten(ctx, uid, orig, sched)
+}
+
+// shorten stores a new URL pair within the span of the calling method.
+func (s *Shortener) shorten(ctx context.Context, uid models.UserID, orig models.OrigURL, sched models.Schedule) (*models.URLPair, error) {
 	short := s.hasher.GenerateHashFromURL(orig)
+	if isReservedSlug(short) {
+		return nil, errReservedShort
+	}
 	pair := &models.URLPair{
-		UID:   uid,
-		Short: short,
-		Orig:  orig,
+		UID:       uid,
+		Short:     short,
+		Orig:      orig,
+		CreatedAt: s.now(),
+		Schedule:  sched,
 	}
 	err := s.strg.AddURLPair(ctx, pair)
 	if e, ok := err.(errConflict); ok && e.IsErrConflict() {
@@ -82,16 +175,122 @@ func (s *Shortener) ShortenURL(ctx context.Context, uid models.UserID, orig mode
 	return pair, nil
 }
 
-// GetOrigURLByShort retrieves the original URL from a shortened version.
+// GetOrigURLByShort retrieves the destination URL from a shortened version.
 //
-// Returns the original URL if found in storage.
+// Scheduled links redirect only within their schedule period.
+// Password protected links require the password in visit details.
+// Each redirect of a limited link takes one of its remaining clicks,
+// the link is deleted after the last one.
+// If the request context carries visit details (see contextkeys.Visit),
+// routing rules of the link are checked first. Otherwise for multi-destination
+// links a weighted variant is chosen and recorded, the choice is sticky
+// per visitor. Link passthrough settings are applied to the destination URL,
+// a trailing path climbing above the destination path is rejected.
 // Returns error if short URL is invalid or not found.
 func (s *Shortener) GetOrigURLByShort(ctx context.Context, short models.ShortURL) (models.OrigURL, error) {
+	ctx, span := tracing.Start(ctx, "Shortener.GetOrigURLByShort", attribute.String("short_url", string(short)))
+	defer span.End()
+
 	pair, err := s.strg.GetURLPairByShort(ctx, short)
 	if err != nil {
 		return "", err
 	}
-	return pair.Orig, nil
+
+	err = s.checkSchedule(pair.Schedule)
+	if err != nil {
+		return "", err
+	}
+
+	visit := visitFromCtx(ctx)
+
+	// Malformed paths are rejected before a click of a limited link is taken.
+	if pair.Options != nil {
+		_, err = passthroughPath(pair.Options.Passthrough, visit)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if pair.Options != nil && pair.Options.PasswordHash != "" {
+		err = s.checkPassword(short, pair.Options.PasswordHash, visit)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if pair.ClicksLeft != nil {
+		err = s.strg.ConsumeClick(ctx, short)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if pair.Options == nil {
+		return pair.Orig, nil
+	}
+
+	var dest models.OrigURL
+	if rule := s.matchRule(ctx, pair.Options.Rules, visit); rule != nil {
+		dest = rule.URL
+	} else {
+		dest = s.resolveDestination(ctx, pair, visit)
+	}
+
+	return buildDestination(dest, pair.Options.Passthrough, visit)
+}
+
+// SetURLOptions merges per-link settings into the ones of the URL owned by user.
+//
+// Passthrough settings are replaced, nil settings remove them. Destinations
+// and rules are replaced only if they are present, so settings managed by
+// other requests are kept. Link password is kept as is, see SetPassword.
+// Returns not exist error if user has no such short URL.
+func (s *Shortener) SetURLOptions(ctx context.Context, uid models.UserID, short models.ShortURL, opts *models.LinkOptions) error {
+	ctx, span := tracing.Start(ctx, "Shortener.SetURLOptions")
+	defer span.End()
+
+	if opts == nil {
+		opts = &models.LinkOptions{}
+	}
+
+	return s.updateOptions(ctx, uid, short, func(stored *models.LinkOptions) {
+		stored.Passthrough = opts.Passthrough
+		if opts.Destinations != nil {
+			stored.Destinations = opts.Destinations
+		}
+		if opts.Rules != nil {
+			stored.Rules = opts.Rules
+		}
+	})
+}
+
+// updateOptions atomically applies modify to settings of the URL owned by user.
+//
+// Settings left empty are stored as nil, so the link becomes a plain redirect.
+func (s *Shortener) updateOptions(ctx context.Context, uid models.UserID, short models.ShortURL, modify func(*models.LinkOptions)) error {
+	return s.strg.UpdateURLOptions(ctx, uid, short, func(stored *models.LinkOptions) *models.LinkOptions {
+		var opts models.LinkOptions
+		if stored != nil {
+			opts = *stored
+		}
+		modify(&opts)
+
+		if opts.Passthrough == nil && len(opts.Destinations) == 0 && len(opts.Rules) == 0 && opts.PasswordHash == "" {
+			return nil
+		}
+		return &opts
+	})
+}
+
+// SetClickLimit makes the URL owned by user self-destruct after
+// the given number of redirects. Nil limit makes the link unlimited.
+//
+// Returns not exist error if user has no such short URL.
+func (s *Shortener) SetClickLimit(ctx context.Context, uid models.UserID, short models.ShortURL, limit *int64) error {
+	ctx, span := tracing.Start(ctx, "Shortener.SetClickLimit")
+	defer span.End()
+
+	return s.strg.SetClickLimit(ctx, uid, short, limit)
 }
 
 // GetShortURLFromCtx extracts shortened URL from request context.
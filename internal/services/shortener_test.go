@@ -2,13 +2,20 @@ package services
 
 import (
 	"context"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/shorturl/internal/contextkeys"
 	"github.com/rycln/shorturl/internal/models"
 	"github.com/rycln/shorturl/internal/services/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestShortener_ShortenURL(t *testing.T) {
@@ -20,16 +27,18 @@ func TestShortener_ShortenURL(t *testing.T) {
 	mStrg := mocks.NewMockShortenerStorage(ctrl)
 
 	s := NewShortener(mStrg, mHash)
+	s.now = func() time.Time { return testNow }
 
 	wantPair := models.URLPair{
-		UID:   testUserID,
-		Short: testShortURL,
-		Orig:  testOrigURL,
+		UID:       testUserID,
+		Short:     testShortURL,
+		Orig:      testOrigURL,
+		CreatedAt: testNow,
 	}
 
 	t.Run("valid test", func(t *testing.T) {
 		mHash.EXPECT().GenerateHashFromURL(wantPair.Orig).Return(wantPair.Short)
-		mStrg.EXPECT().AddURLPair(context.Background(), &wantPair).Return(nil)
+		mStrg.EXPECT().AddURLPair(gomock.Any(), &wantPair).Return(nil)
 
 		pair, err := s.ShortenURL(context.Background(), testUserID, wantPair.Orig)
 		assert.NoError(t, err)
@@ -39,7 +48,7 @@ func TestShortener_ShortenURL(t *testing.T) {
 	t.Run("conflict error", func(t *testing.T) {
 		mErr.EXPECT().IsErrConflict().Return(true)
 		mHash.EXPECT().GenerateHashFromURL(wantPair.Orig).Return(wantPair.Short)
-		mStrg.EXPECT().AddURLPair(context.Background(), &wantPair).Return(mErr)
+		mStrg.EXPECT().AddURLPair(gomock.Any(), &wantPair).Return(mErr)
 
 		pair, err := s.ShortenURL(context.Background(), testUserID, wantPair.Orig)
 		assert.Error(t, err)
@@ -48,11 +57,18 @@ func TestShortener_ShortenURL(t *testing.T) {
 
 	t.Run("some error", func(t *testing.T) {
 		mHash.EXPECT().GenerateHashFromURL(wantPair.Orig).Return(wantPair.Short)
-		mStrg.EXPECT().AddURLPair(context.Background(), &wantPair).Return(errTest)
+		mStrg.EXPECT().AddURLPair(gomock.Any(), &wantPair).Return(errTest)
 
 		_, err := s.ShortenURL(context.Background(), testUserID, wantPair.Orig)
 		assert.Error(t, err)
 	})
+
+	t.Run("reserved short", func(t *testing.T) {
+		mHash.EXPECT().GenerateHashFromURL(wantPair.Orig).Return(models.ShortURL("readyz"))
+
+		_, err := s.ShortenURL(context.Background(), testUserID, wantPair.Orig)
+		assert.ErrorIs(t, err, errReservedShort)
+	})
 }
 
 func TestShortener_GetOrigURLByShort(t *testing.T) {
@@ -65,21 +81,175 @@ func TestShortener_GetOrigURLByShort(t *testing.T) {
 	s := NewShortener(mStrg, mHash)
 
 	t.Run("valid test", func(t *testing.T) {
-		mStrg.EXPECT().GetURLPairByShort(context.Background(), testShortURL).Return(&testPair, nil)
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&testPair, nil)
 
 		orig, err := s.GetOrigURLByShort(context.Background(), testShortURL)
 		assert.NoError(t, err)
 		assert.Equal(t, testPair.Orig, orig)
 	})
 
+	t.Run("passthrough", func(t *testing.T) {
+		pair := testPair
+		pair.Options = &models.LinkOptions{
+			Passthrough: &models.Passthrough{Query: true, Path: true},
+		}
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		ctx := context.WithValue(context.Background(), contextkeys.Visit, &models.Visit{
+			Query: url.Values{"ref": {"mail"}},
+			Path:  "docs",
+		})
+		orig, err := s.GetOrigURLByShort(ctx, testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, models.OrigURL("https://practicum.yandex.ru/docs?ref=mail"), orig)
+	})
+
+	t.Run("path escapes destination", func(t *testing.T) {
+		limit := int64(1)
+		pair := testPair
+		pair.ClicksLeft = &limit
+		pair.Options = &models.LinkOptions{
+			Passthrough: &models.Passthrough{Path: true},
+		}
+		// The click of a limited link is not taken.
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(&pair, nil)
+
+		ctx := context.WithValue(context.Background(), contextkeys.Visit, &models.Visit{
+			Path: "%2e%2e/admin",
+		})
+		_, err := s.GetOrigURLByShort(ctx, testShortURL)
+		assert.ErrorIs(t, err, errInvalidPath)
+	})
+
 	t.Run("some error", func(t *testing.T) {
-		mStrg.EXPECT().GetURLPairByShort(context.Background(), testShortURL).Return(nil, errTest)
+		mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).Return(nil, errTest)
 
 		_, err := s.GetOrigURLByShort(context.Background(), testShortURL)
 		assert.Error(t, err)
 	})
 }
 
+func TestShortener_Tracing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+
+	// Storage is called within the service span.
+	var storageSpan trace.SpanContext
+	mStrg.EXPECT().GetURLPairByShort(gomock.Any(), testShortURL).DoAndReturn(func(ctx context.Context, _ models.ShortURL) (*models.URLPair, error) {
+		storageSpan = trace.SpanContextFromContext(ctx)
+		return &testPair, nil
+	})
+
+	_, err := s.GetOrigURLByShort(ctx, testShortURL)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "Shortener.GetOrigURLByShort", spans[0].Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), storageSpan.SpanID())
+
+	t.Run("shorten", func(t *testing.T) {
+		exp.Reset()
+		mHash.EXPECT().GenerateHashFromURL(testOrigURL).Return(testShortURL)
+		mStrg.EXPECT().AddURLPair(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := s.ShortenURL(context.Background(), testUserID, testOrigURL)
+		require.NoError(t, err)
+
+		// Shortening gets a single span, not nested ones.
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "Shortener.ShortenURL", spans[0].Name)
+	})
+}
+
+func TestShortener_SetURLOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHash := mocks.NewMockhasher(ctrl)
+	mStrg := mocks.NewMockShortenerStorage(ctrl)
+
+	s := NewShortener(mStrg, mHash)
+
+	opts := &models.LinkOptions{
+		Passthrough: &models.Passthrough{Query: true},
+	}
+	stored := &models.LinkOptions{
+		Passthrough:  &models.Passthrough{Path: true},
+		Destinations: testDestinations,
+		Rules:        []models.Rule{{Platforms: []string{models.PlatformIOS}, URL: "https://apps.apple.com/app/id1"}},
+		PasswordHash: "hash",
+	}
+
+	set := func(stored, opts *models.LinkOptions) *models.LinkOptions {
+		var got *models.LinkOptions
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).DoAndReturn(updateOptionsOf(stored, &got))
+
+		err := s.SetURLOptions(context.Background(), testUserID, testShortURL, opts)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		assert.Equal(t, opts, set(nil, opts))
+	})
+
+	t.Run("other settings are kept", func(t *testing.T) {
+		assert.Equal(t, &models.LinkOptions{
+			Passthrough:  opts.Passthrough,
+			Destinations: stored.Destinations,
+			Rules:        stored.Rules,
+			PasswordHash: "hash",
+		}, set(stored, opts))
+
+		assert.Equal(t, &models.LinkOptions{
+			Destinations: stored.Destinations,
+			Rules:        stored.Rules,
+			PasswordHash: "hash",
+		}, set(stored, nil))
+	})
+
+	t.Run("present rules and destinations are replaced", func(t *testing.T) {
+		got := set(stored, &models.LinkOptions{
+			Destinations: []models.Destination{},
+			Rules:        []models.Rule{},
+		})
+		assert.Equal(t, &models.LinkOptions{
+			Destinations: []models.Destination{},
+			Rules:        []models.Rule{},
+			PasswordHash: "hash",
+		}, got)
+	})
+
+	t.Run("reset to plain redirect", func(t *testing.T) {
+		assert.Nil(t, set(opts, nil))
+	})
+
+	t.Run("client password hash is ignored", func(t *testing.T) {
+		assert.Nil(t, set(nil, &models.LinkOptions{PasswordHash: "forged"}))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().UpdateURLOptions(gomock.Any(), testUserID, testShortURL, gomock.Any()).Return(errTest)
+
+		err := s.SetURLOptions(context.Background(), testUserID, testShortURL, opts)
+		assert.Error(t, err)
+	})
+}
+
 func TestShortener_GetShortURLFromCtx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
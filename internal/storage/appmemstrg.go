@@ -2,97 +2,236 @@ package storage
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/rycln/shorturl/internal/models"
 )
 
+// memShardCount is the number of lock shards of AppMemStorage.
+// Must be a power of two.
+const memShardCount = 64
+
+// memLinkShard holds links whose short URL hashes into the shard.
+type memLinkShard struct {
+	mu      sync.RWMutex
+	pairs   map[models.ShortURL]models.URLPair
+	deleted map[models.ShortURL]struct{}
+	hits    map[models.ShortURL]map[string]int64
+}
+
+// memUserShard indexes short URLs of users whose ID hashes into the shard.
+type memUserShard struct {
+	mu    sync.RWMutex
+	users map[models.UserID]map[models.ShortURL]struct{}
+}
+
 // AppMemStorage is an in-memory implementation of a URL shortener storage.
 //
-// Note: All data will be lost on application restart.
+// Links are indexed globally by short URL and per user by user ID. Both
+// indexes are split into shards with their own locks, so that redirects
+// do not contend with writes to unrelated links. A link shard lock may be
+// held while taking a user shard lock, never the other way round. Operations
+// spanning the whole storage lock the shards in index order.
+//
+// Note: All data will be lost on application restart unless it is saved
+// with SaveSnapshot and loaded back with LoadSnapshot.
 type AppMemStorage struct {
-	pairs   map[models.UserID]map[models.ShortURL]models.OrigURL
-	deleted map[models.ShortURL]struct{}
-	mu      sync.RWMutex
+	links [memShardCount]memLinkShard
+	users [memShardCount]memUserShard
 }
 
 // NewAppMemStorage creates a new AppMemStorage instance.
 func NewAppMemStorage() *AppMemStorage {
-	return &AppMemStorage{
-		pairs:   make(map[models.UserID]map[models.ShortURL]models.OrigURL),
-		deleted: make(map[models.ShortURL]struct{}),
+	s := &AppMemStorage{}
+	for i := range s.links {
+		s.links[i].pairs = make(map[models.ShortURL]models.URLPair)
+		s.links[i].deleted = make(map[models.ShortURL]struct{})
+		s.links[i].hits = make(map[models.ShortURL]map[string]int64)
+	}
+	for i := range s.users {
+		s.users[i].users = make(map[models.UserID]map[models.ShortURL]struct{})
+	}
+	return s
+}
+
+// shardIndex maps the key onto a shard using 32-bit FNV-1a hash.
+func shardIndex(key string) int {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return int(h & (memShardCount - 1))
+}
+
+func (s *AppMemStorage) linkShard(short models.ShortURL) *memLinkShard {
+	return &s.links[shardIndex(string(short))]
+}
+
+func (s *AppMemStorage) userShard(uid models.UserID) *memUserShard {
+	return &s.users[shardIndex(string(uid))]
+}
+
+// rlockLinks read-locks all link shards in index order.
+func (s *AppMemStorage) rlockLinks() {
+	for i := range s.links {
+		s.links[i].mu.RLock()
+	}
+}
+
+func (s *AppMemStorage) runlockLinks() {
+	for i := range s.links {
+		s.links[i].mu.RUnlock()
+	}
+}
+
+// put stores the pair overwriting the one with the same short URL
+// and keeps the user index consistent. The caller must hold the link shard lock.
+func (s *AppMemStorage) put(ls *memLinkShard, pair models.URLPair) {
+	if old, ok := ls.pairs[pair.Short]; ok && old.UID != pair.UID {
+		s.unindexUserShort(old.UID, pair.Short)
+	}
+	ls.pairs[pair.Short] = pair
+	s.indexUserShort(pair.UID, pair.Short)
+}
+
+func (s *AppMemStorage) indexUserShort(uid models.UserID, short models.ShortURL) {
+	us := s.userShard(uid)
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	shorts, ok := us.users[uid]
+	if !ok {
+		shorts = make(map[models.ShortURL]struct{})
+		us.users[uid] = shorts
+	}
+	shorts[short] = struct{}{}
+}
+
+func (s *AppMemStorage) unindexUserShort(uid models.UserID, short models.ShortURL) {
+	us := s.userShard(uid)
+	us.mu.Lock()
+	defer us.mu.Unlock()
+
+	delete(us.users[uid], short)
+	if len(us.users[uid]) == 0 {
+		delete(us.users, uid)
 	}
 }
 
+// userShorts returns a copy of short URLs owned by the user.
+func (s *AppMemStorage) userShorts(uid models.UserID) []models.ShortURL {
+	us := s.userShard(uid)
+	us.mu.RLock()
+	defer us.mu.RUnlock()
+
+	shorts := make([]models.ShortURL, 0, len(us.users[uid]))
+	for short := range us.users[uid] {
+		shorts = append(shorts, short)
+	}
+	return shorts
+}
+
+// lookup returns the pair and its deletion state.
+func (s *AppMemStorage) lookup(short models.ShortURL) (models.URLPair, bool, bool) {
+	ls := s.linkShard(short)
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	pair, ok := ls.pairs[short]
+	_, deleted := ls.deleted[short]
+	return pair, deleted, ok
+}
+
 // AddURLPair stores a new URL pair in memory.
 func (s *AppMemStorage) AddURLPair(ctx context.Context, pair *models.URLPair) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
 
-	if userpairs, exists := s.pairs[pair.UID]; exists {
-		if _, ok := userpairs[pair.Short]; ok {
-			return newErrConflict(errConflict)
-		}
-
-		userpairs[pair.Short] = pair.Orig
-		return nil
-	}
+	ls := s.linkShard(pair.Short)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
 
-	for _, userpairs := range s.pairs {
-		if _, ok := userpairs[pair.Short]; ok {
-			return newErrConflict(errConflict)
-		}
+	if _, ok := ls.pairs[pair.Short]; ok {
+		return newErrConflict(errConflict)
 	}
 
-	s.pairs[pair.UID] = map[models.ShortURL]models.OrigURL{
-		pair.Short: pair.Orig,
-	}
+	s.put(ls, *pair)
 
 	return nil
 }
 
 // GetURLPairByShort retrieves a URL pair by its short URL.
 func (s *AppMemStorage) GetURLPairByShort(ctx context.Context, short models.ShortURL) (*models.URLPair, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
 
-	_, ok := s.deleted[short]
-	if ok {
+	pair, deleted, ok := s.lookup(short)
+	if deleted {
 		return nil, newErrDeletedURL(errDeletedURL)
 	}
+	if !ok {
+		return nil, newErrNotExist(errNotExist)
+	}
+
+	return &pair, nil
+}
+
+// LookupURLPair retrieves a URL pair by its short URL including deleted ones.
+//
+// Reports whether the pair was deleted.
+func (s *AppMemStorage) LookupURLPair(ctx context.Context, short models.ShortURL) (*models.URLPair, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	default:
+	}
+
+	pair, deleted, ok := s.lookup(short)
+	if !ok {
+		return nil, false, newErrNotExist(errNotExist)
+	}
 
-	for uid, userpairs := range s.pairs {
+	return &pair, deleted, nil
+}
+
+// LookupURLPairBatch retrieves URL pairs by their short URLs including deleted ones.
+//
+// Unknown short URLs are absent from the result. Status of the found pairs
+// is either active or deleted, schedule is not taken into account.
+func (s *AppMemStorage) LookupURLPairBatch(ctx context.Context, shorts []models.ShortURL) (map[models.ShortURL]models.URLInfo, error) {
+	infos := make(map[models.ShortURL]models.URLInfo, len(shorts))
+	for _, short := range shorts {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		_, ok := userpairs[short]
-		if ok {
-			var pair = &models.URLPair{
-				UID:   uid,
-				Short: short,
-				Orig:  userpairs[short],
-			}
-			return pair, nil
+		pair, deleted, ok := s.lookup(short)
+		if !ok {
+			continue
+		}
+
+		info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+		if deleted {
+			info.Status = models.StatusDeleted
 		}
+		infos[short] = info
 	}
 
-	return nil, newErrNotExist(errNotExist)
+	return infos, nil
 }
 
 // AddBatchURLPairs stores multiple URL pairs.
 func (s *AppMemStorage) AddBatchURLPairs(ctx context.Context, pairs []models.URLPair) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	for _, pair := range pairs {
 		select {
 		case <-ctx.Done():
@@ -100,56 +239,366 @@ func (s *AppMemStorage) AddBatchURLPairs(ctx context.Context, pairs []models.URL
 		default:
 		}
 
-		_, ok := s.pairs[pair.UID]
-		if ok {
-			s.pairs[pair.UID][pair.Short] = pair.Orig
-			continue
+		ls := s.linkShard(pair.Short)
+		ls.mu.Lock()
+		s.put(ls, pair)
+		ls.mu.Unlock()
+	}
+
+	return nil
+}
+
+// ImportURLPairs stores multiple URL pairs skipping the ones whose
+// short URL is already taken.
+//
+// Reports for each pair whether it was skipped as a conflict.
+func (s *AppMemStorage) ImportURLPairs(ctx context.Context, pairs []models.URLPair) ([]bool, error) {
+	conflicts := make([]bool, len(pairs))
+	for i, pair := range pairs {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
 		}
 
-		var userpair = make(map[models.ShortURL]models.OrigURL)
-		userpair[pair.Short] = pair.Orig
-		s.pairs[pair.UID] = userpair
+		ls := s.linkShard(pair.Short)
+		ls.mu.Lock()
+		if _, ok := ls.pairs[pair.Short]; ok {
+			conflicts[i] = true
+		} else {
+			s.put(ls, pair)
+		}
+		ls.mu.Unlock()
 	}
 
-	return nil
+	return conflicts, nil
+}
+
+// userInfos collects URL pairs of the user with their deletion state.
+func (s *AppMemStorage) userInfos(uid models.UserID) []models.URLInfo {
+	shorts := s.userShorts(uid)
+	infos := make([]models.URLInfo, 0, len(shorts))
+	for _, short := range shorts {
+		pair, deleted, ok := s.lookup(short)
+		// The pair may have been taken over between the lookups.
+		if !ok || pair.UID != uid {
+			continue
+		}
+
+		info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+		if deleted {
+			info.Status = models.StatusDeleted
+		}
+		infos = append(infos, info)
+	}
+	return infos
 }
 
 // GetURLPairBatchByUserID retrieves all URL pairs created by a specific user.
 func (s *AppMemStorage) GetURLPairBatchByUserID(ctx context.Context, uid models.UserID) ([]models.URLPair, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
 
-	_, ok := s.pairs[uid]
-	if !ok {
+	infos := s.userInfos(uid)
+	if len(infos) == 0 {
 		return nil, newErrNotExist(errNotExist)
 	}
 
+	pairs := make([]models.URLPair, 0, len(infos))
+	for _, info := range infos {
+		pairs = append(pairs, info.URLPair)
+	}
+
+	return pairs, nil
+}
+
+// IterateURLPairsByUserID calls fn for every URL pair of the user including
+// deleted ones, oldest first. Iteration stops at the first error returned by fn.
+//
+// The user's pairs are copied under the locks so that fn may take its time.
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *AppMemStorage) IterateURLPairsByUserID(ctx context.Context, uid models.UserID, fn func(models.URLInfo) error) error {
+	infos := s.userInfos(uid)
+
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].CreatedAt.Equal(infos[j].CreatedAt) {
+			return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+		}
+		return infos[i].Short < infos[j].Short
+	})
+
+	for _, info := range infos {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		err := fn(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IterateURLPairs calls fn for every URL pair of all users including
+// deleted ones, ordered by short URL. Iteration stops at the first error
+// returned by fn.
+//
+// All pairs are copied under the locks so that fn may take its time.
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *AppMemStorage) IterateURLPairs(ctx context.Context, fn func(models.URLInfo) error) error {
+	s.rlockLinks()
+	var infos []models.URLInfo
+	for i := range s.links {
+		ls := &s.links[i]
+		for short, pair := range ls.pairs {
+			info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+			if _, ok := ls.deleted[short]; ok {
+				info.Status = models.StatusDeleted
+			}
+			infos = append(infos, info)
+		}
+	}
+	s.runlockLinks()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Short < infos[j].Short
+	})
+
+	for _, info := range infos {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		err := fn(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SnapshotURLPairs calls pairFn for every URL pair and then tombstoneFn for
+// every deletion mark, both ordered by short URL.
+//
+// The content is copied while all shards are locked, so it reflects a single
+// point in time and the callbacks may take their time.
+func (s *AppMemStorage) SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) error {
+	s.rlockLinks()
 	var pairs []models.URLPair
+	var tombstones []models.DelURLReq
+	for i := range s.links {
+		ls := &s.links[i]
+		for _, pair := range ls.pairs {
+			pairs = append(pairs, pair)
+		}
+		for short := range ls.deleted {
+			tombstones = append(tombstones, models.DelURLReq{UID: ls.pairs[short].UID, Short: short})
+		}
+	}
+	s.runlockLinks()
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Short < pairs[j].Short
+	})
+	sort.Slice(tombstones, func(i, j int) bool {
+		return tombstones[i].Short < tombstones[j].Short
+	})
+
+	for _, pair := range pairs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		err := pairFn(pair)
+		if err != nil {
+			return err
+		}
+	}
 
-	for short, orig := range s.pairs[uid] {
+	for _, tombstone := range tombstones {
+		err := tombstoneFn(tombstone)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreURLPairs stores URL pairs with all their attributes and deletion
+// state skipping the ones which collide with existing links.
+//
+// Reports for each pair whether it was skipped as a conflict.
+func (s *AppMemStorage) RestoreURLPairs(ctx context.Context, infos []models.URLInfo) ([]bool, error) {
+	conflicts := make([]bool, len(infos))
+	for i, info := range infos {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		pair := models.URLPair{
-			UID:   uid,
-			Short: short,
-			Orig:  orig,
+		ls := s.linkShard(info.Short)
+		ls.mu.Lock()
+		if _, ok := ls.pairs[info.Short]; ok {
+			conflicts[i] = true
+		} else {
+			s.put(ls, info.URLPair)
+			if info.Status == models.StatusDeleted {
+				ls.deleted[info.Short] = struct{}{}
+			}
 		}
-		pairs = append(pairs, pair)
+		ls.mu.Unlock()
 	}
 
-	return pairs, nil
+	return conflicts, nil
+}
+
+// UpdateURLOptions replaces settings of the URL pair owned by user
+// with the result of update applied to the stored ones.
+//
+// The link shard stays locked while update runs.
+func (s *AppMemStorage) UpdateURLOptions(ctx context.Context, uid models.UserID, short models.ShortURL, update func(*models.LinkOptions) *models.LinkOptions) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	ls := s.linkShard(short)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	pair, ok := ls.pairs[short]
+	if !ok || pair.UID != uid {
+		return newErrNotExist(errNotExist)
+	}
+
+	pair.Options = update(pair.Options)
+	ls.pairs[short] = pair
+
+	return nil
+}
+
+// SetClickLimit sets number of redirects left for the URL pair owned by user.
+func (s *AppMemStorage) SetClickLimit(ctx context.Context, uid models.UserID, short models.ShortURL, limit *int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	ls := s.linkShard(short)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	pair, ok := ls.pairs[short]
+	if !ok || pair.UID != uid {
+		return newErrNotExist(errNotExist)
+	}
+
+	pair.ClicksLeft = limit
+	ls.pairs[short] = pair
+
+	return nil
+}
+
+// ConsumeClick atomically takes one redirect of the limited link.
+//
+// The link is marked as deleted when the last redirect is taken.
+func (s *AppMemStorage) ConsumeClick(ctx context.Context, short models.ShortURL) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	ls := s.linkShard(short)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if _, ok := ls.deleted[short]; ok {
+		return newErrDeletedURL(errDeletedURL)
+	}
+
+	pair, ok := ls.pairs[short]
+	if !ok {
+		return newErrNotExist(errNotExist)
+	}
+
+	if pair.ClicksLeft == nil {
+		return nil
+	}
+	if *pair.ClicksLeft <= 0 {
+		return newErrDeletedURL(errClicksExhausted)
+	}
+
+	left := *pair.ClicksLeft - 1
+	pair.ClicksLeft = &left
+	ls.pairs[short] = pair
+
+	if left == 0 {
+		ls.deleted[short] = struct{}{}
+	}
+
+	return nil
+}
+
+// AddVariantHit increments redirect counter of the link variant.
+func (s *AppMemStorage) AddVariantHit(ctx context.Context, short models.ShortURL, variant string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	ls := s.linkShard(short)
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if _, ok := ls.hits[short]; !ok {
+		ls.hits[short] = make(map[string]int64)
+	}
+	ls.hits[short][variant]++
+
+	return nil
+}
+
+// GetVariantHits returns redirect counters of all link variants.
+func (s *AppMemStorage) GetVariantHits(ctx context.Context, short models.ShortURL) (map[string]int64, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	ls := s.linkShard(short)
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	hits := make(map[string]int64, len(ls.hits[short]))
+	for variant, n := range ls.hits[short] {
+		hits[variant] = n
+	}
+
+	return hits, nil
 }
 
 // DeleteRequestedURLs marks URLs as deleted in a batch operation.
 // Implements soft deletion - URLs remain in storage but are marked as deleted.
 func (s *AppMemStorage) DeleteRequestedURLs(ctx context.Context, delurls []*models.DelURLReq) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	for _, delurl := range delurls {
 		select {
 		case <-ctx.Done():
@@ -157,7 +606,10 @@ func (s *AppMemStorage) DeleteRequestedURLs(ctx context.Context, delurls []*mode
 		default:
 		}
 
-		s.deleted[delurl.Short] = struct{}{}
+		ls := s.linkShard(delurl.Short)
+		ls.mu.Lock()
+		ls.deleted[delurl.Short] = struct{}{}
+		ls.mu.Unlock()
 	}
 
 	return nil
@@ -165,21 +617,26 @@ func (s *AppMemStorage) DeleteRequestedURLs(ctx context.Context, delurls []*mode
 
 // GetStats retrieves and calculates service statistics from memory storage.
 func (s *AppMemStorage) GetStats(ctx context.Context) (*models.Stats, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	var urls, users int
 
-	users = len(s.pairs)
-
-	for _, userURLs := range s.pairs {
+	for i := range s.links {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
 		}
 
-		urls += len(userURLs)
+		ls := &s.links[i]
+		ls.mu.RLock()
+		urls += len(ls.pairs)
+		ls.mu.RUnlock()
+	}
+
+	for i := range s.users {
+		us := &s.users[i]
+		us.mu.RLock()
+		users += len(us.users)
+		us.mu.RUnlock()
 	}
 
 	return &models.Stats{
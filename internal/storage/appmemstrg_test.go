@@ -3,19 +3,49 @@ package storage
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/rycln/shorturl/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// fillAppMemStorage stores pairs overwriting existing ones and marks
+// the given short URLs as deleted.
+func fillAppMemStorage(t testing.TB, strg *AppMemStorage, pairs []models.URLPair, deleted ...models.ShortURL) {
+	t.Helper()
+
+	err := strg.AddBatchURLPairs(context.Background(), pairs)
+	require.NoError(t, err)
+
+	delurls := make([]*models.DelURLReq, 0, len(deleted))
+	for _, short := range deleted {
+		delurls = append(delurls, &models.DelURLReq{Short: short})
+	}
+	err = strg.DeleteRequestedURLs(context.Background(), delurls)
+	require.NoError(t, err)
+}
+
+// dumpAppMemStorage returns all URL pairs of the storage ordered by short URL.
+func dumpAppMemStorage(t testing.TB, strg *AppMemStorage) []models.URLInfo {
+	t.Helper()
+
+	var infos []models.URLInfo
+	err := strg.IterateURLPairs(context.Background(), func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return infos
+}
+
 func TestAppMemStorage_AddURLPair(t *testing.T) {
 	strg := NewAppMemStorage()
 
-	umap := make(map[models.ShortURL]models.OrigURL)
-	umap[testShortURL] = testOrigURL
-	strg.pairs[testUserID] = umap
+	fillAppMemStorage(t, strg, []models.URLPair{testPair})
 
 	t.Run("valid test", func(t *testing.T) {
 		pair := models.URLPair{
@@ -143,10 +173,7 @@ func BenchmarkAppMemStorage_AddURLPair(b *testing.B) {
 func TestAppMemStorage_GetURLPairByShort(t *testing.T) {
 	strg := NewAppMemStorage()
 
-	umap := make(map[models.ShortURL]models.OrigURL)
-	umap[testShortURL] = testOrigURL
-	strg.pairs[testUserID] = umap
-	strg.deleted[testDeletedShort] = struct{}{}
+	fillAppMemStorage(t, strg, []models.URLPair{testPair}, testDeletedShort)
 
 	t.Run("valid test", func(t *testing.T) {
 		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
@@ -186,6 +213,62 @@ func BenchmarkAppMemStorage_GetURLPairByShort(b *testing.B) {
 	})
 }
 
+func TestAppMemStorage_LookupURLPair(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	fillAppMemStorage(t, strg, []models.URLPair{testPair, deletedPair}, testDeletedShort)
+
+	t.Run("valid test", func(t *testing.T) {
+		pair, deleted, err := strg.LookupURLPair(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Equal(t, testPair, *pair)
+	})
+
+	t.Run("deleted url", func(t *testing.T) {
+		pair, deleted, err := strg.LookupURLPair(context.Background(), testDeletedShort)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, deletedPair, *pair)
+	})
+
+	t.Run("not exist error", func(t *testing.T) {
+		_, _, err := strg.LookupURLPair(context.Background(), models.ShortURL("not exist"))
+		assert.ErrorIs(t, err, errNotExist)
+	})
+}
+
+func TestAppMemStorage_LookupURLPairBatch(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := testPair
+	otherPair.UID = testOtherUserID
+	otherPair.Short = "other"
+	fillAppMemStorage(t, strg, []models.URLPair{testPair, deletedPair, otherPair}, testDeletedShort)
+
+	t.Run("valid test", func(t *testing.T) {
+		infos, err := strg.LookupURLPairBatch(context.Background(), []models.ShortURL{testShortURL, testDeletedShort, otherPair.Short, "not exist"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[models.ShortURL]models.URLInfo{
+			testShortURL:     {URLPair: testPair, Status: models.StatusActive},
+			testDeletedShort: {URLPair: deletedPair, Status: models.StatusDeleted},
+			otherPair.Short:  {URLPair: otherPair, Status: models.StatusActive},
+		}, infos)
+	})
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := strg.LookupURLPairBatch(ctx, []models.ShortURL{testShortURL})
+		assert.Error(t, err)
+	})
+}
+
 func TestAppMemStorage_AddBatchURLPairs(t *testing.T) {
 	strg := NewAppMemStorage()
 
@@ -214,6 +297,25 @@ func TestAppMemStorage_AddBatchURLPairs(t *testing.T) {
 		err := strg.AddBatchURLPairs(ctx, pairs)
 		assert.Error(t, err)
 	})
+
+	t.Run("owner changed", func(t *testing.T) {
+		moved := pairs[1]
+		moved.UID = testOtherUserID
+		err := strg.AddBatchURLPairs(context.Background(), []models.URLPair{moved})
+		require.NoError(t, err)
+
+		userPairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, pairs[:1], userPairs)
+
+		otherPairs, err := strg.GetURLPairBatchByUserID(context.Background(), testOtherUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLPair{moved}, otherPairs)
+
+		stats, err := strg.GetStats(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Stats{URLs: 2, Users: 2}, stats)
+	})
 }
 
 func BenchmarkAppMemStorage_AddBatchURLPairs(b *testing.B) {
@@ -268,13 +370,39 @@ func BenchmarkAppMemStorage_AddBatchURLPairs(b *testing.B) {
 	})
 }
 
+func TestAppMemStorage_ImportURLPairs(t *testing.T) {
+	strg := NewAppMemStorage()
+	existing := testPair
+	existing.UID = testOtherUserID
+	fillAppMemStorage(t, strg, []models.URLPair{existing})
+
+	taken := testPair
+	taken.UID = testUserID
+	custom := taken
+	custom.Short = "custom"
+	custom.Tags = []string{"promo"}
+
+	conflicts, err := strg.ImportURLPairs(context.Background(), []models.URLPair{taken, custom, custom})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, conflicts)
+	pairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLPair{custom}, pairs)
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := strg.ImportURLPairs(ctx, []models.URLPair{custom})
+		assert.Error(t, err)
+	})
+}
+
 func TestAppMemStorage_GetURLPairBatchByUserID(t *testing.T) {
 	strg := NewAppMemStorage()
 
 	t.Run("valid test", func(t *testing.T) {
-		umap := make(map[models.ShortURL]models.OrigURL)
-		umap[testShortURL] = testOrigURL
-		strg.pairs[testUserID] = umap
+		fillAppMemStorage(t, strg, []models.URLPair{testPair})
 
 		pairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
 		assert.NoError(t, err)
@@ -298,9 +426,7 @@ func TestAppMemStorage_GetURLPairBatchByUserID(t *testing.T) {
 func BenchmarkAppMemStorage_GetURLPairBatchByUserID(b *testing.B) {
 	b.Run("get url", func(b *testing.B) {
 		storage := NewAppMemStorage()
-		userPairs := make(map[models.ShortURL]models.OrigURL)
-		userPairs[testShortURL] = testOrigURL
-		storage.pairs[testUserID] = userPairs
+		fillAppMemStorage(b, storage, []models.URLPair{testPair})
 		b.ResetTimer()
 
 		for i := 0; i < b.N; i++ {
@@ -311,11 +437,15 @@ func BenchmarkAppMemStorage_GetURLPairBatchByUserID(b *testing.B) {
 
 	b.Run("get 100 urls", func(b *testing.B) {
 		storage := NewAppMemStorage()
-		userPairs := make(map[models.ShortURL]models.OrigURL)
+		userPairs := make([]models.URLPair, 0, 100)
 		for i := 0; i < 100; i++ {
-			userPairs[models.ShortURL(fmt.Sprintf("hash-%d", i))] = models.OrigURL(fmt.Sprintf("https://site.com/page%d", i))
+			userPairs = append(userPairs, models.URLPair{
+				UID:   testUserID,
+				Short: models.ShortURL(fmt.Sprintf("hash-%d", i)),
+				Orig:  models.OrigURL(fmt.Sprintf("https://site.com/page%d", i)),
+			})
 		}
-		storage.pairs[testUserID] = userPairs
+		fillAppMemStorage(b, storage, userPairs)
 		b.ResetTimer()
 
 		for i := 0; i < b.N; i++ {
@@ -326,11 +456,15 @@ func BenchmarkAppMemStorage_GetURLPairBatchByUserID(b *testing.B) {
 
 	b.Run("get 1000 urls", func(b *testing.B) {
 		storage := NewAppMemStorage()
-		userPairs := make(map[models.ShortURL]models.OrigURL)
+		userPairs := make([]models.URLPair, 0, 1000)
 		for i := 0; i < 1000; i++ {
-			userPairs[models.ShortURL(fmt.Sprintf("hash-%d", i))] = models.OrigURL(fmt.Sprintf("https://site.com/page%d", i))
+			userPairs = append(userPairs, models.URLPair{
+				UID:   testUserID,
+				Short: models.ShortURL(fmt.Sprintf("hash-%d", i)),
+				Orig:  models.OrigURL(fmt.Sprintf("https://site.com/page%d", i)),
+			})
 		}
-		storage.pairs[testUserID] = userPairs
+		fillAppMemStorage(b, storage, userPairs)
 		b.ResetTimer()
 
 		for i := 0; i < b.N; i++ {
@@ -340,6 +474,250 @@ func BenchmarkAppMemStorage_GetURLPairBatchByUserID(b *testing.B) {
 	})
 }
 
+func TestAppMemStorage_IterateURLPairsByUserID(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	older := testPair
+	older.Short = testDeletedShort
+	older.CreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := testPair
+	newer.CreatedAt = older.CreatedAt.Add(time.Hour)
+
+	fillAppMemStorage(t, strg, []models.URLPair{
+		newer,
+		older,
+		{UID: testOtherUserID, Short: "other", Orig: testOrigURL},
+	}, testDeletedShort)
+
+	var infos []models.URLInfo
+	err := strg.IterateURLPairsByUserID(context.Background(), testUserID, func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLInfo{
+		{URLPair: older, Status: models.StatusDeleted},
+		{URLPair: newer, Status: models.StatusActive},
+	}, infos)
+
+	t.Run("callback error", func(t *testing.T) {
+		err := strg.IterateURLPairsByUserID(context.Background(), testUserID, func(models.URLInfo) error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		err := strg.IterateURLPairsByUserID(context.Background(), "unknown", func(models.URLInfo) error {
+			return errTest
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestAppMemStorage_IterateURLPairs(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := models.URLPair{UID: testOtherUserID, Short: "other", Orig: testOrigURL}
+
+	fillAppMemStorage(t, strg, []models.URLPair{testPair, deletedPair, otherPair}, testDeletedShort)
+
+	var infos []models.URLInfo
+	err := strg.IterateURLPairs(context.Background(), func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLInfo{
+		{URLPair: deletedPair, Status: models.StatusDeleted},
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: otherPair, Status: models.StatusActive},
+	}, infos)
+
+	t.Run("callback error", func(t *testing.T) {
+		err := strg.IterateURLPairs(context.Background(), func(models.URLInfo) error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestAppMemStorage_SnapshotURLPairs(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := models.URLPair{UID: testOtherUserID, Short: "other", Orig: testOrigURL}
+
+	err := strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, deletedPair, otherPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	var pairs []models.URLPair
+	var tombstones []models.DelURLReq
+	err = strg.SnapshotURLPairs(context.Background(),
+		func(pair models.URLPair) error {
+			// the storage stays writable while the snapshot is in progress
+			pairs = append(pairs, pair)
+			return strg.AddURLPair(context.Background(), &models.URLPair{UID: testUserID, Short: "new" + pair.Short, Orig: testOrigURL})
+		},
+		func(req models.DelURLReq) error {
+			tombstones = append(tombstones, req)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLPair{deletedPair, testPair, otherPair}, pairs)
+	assert.Equal(t, []models.DelURLReq{testDelReq}, tombstones)
+
+	t.Run("callback error", func(t *testing.T) {
+		err := strg.SnapshotURLPairs(context.Background(),
+			func(models.URLPair) error { return nil },
+			func(models.DelURLReq) error { return errTest })
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestAppMemStorage_RestoreURLPairs(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	fillAppMemStorage(t, strg, []models.URLPair{testPair})
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := models.URLPair{UID: testOtherUserID, Short: "other", Orig: testOrigURL}
+
+	conflicts, err := strg.RestoreURLPairs(context.Background(), []models.URLInfo{
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: deletedPair, Status: models.StatusDeleted},
+		{URLPair: otherPair, Status: models.StatusActive},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, conflicts)
+
+	infos, err := strg.LookupURLPairBatch(context.Background(), []models.ShortURL{testDeletedShort, "other"})
+	require.NoError(t, err)
+	assert.Equal(t, models.URLInfo{URLPair: deletedPair, Status: models.StatusDeleted}, infos[testDeletedShort])
+	assert.Equal(t, models.URLInfo{URLPair: otherPair, Status: models.StatusActive}, infos["other"])
+}
+
+func TestAppMemStorage_UpdateURLOptions(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	fillAppMemStorage(t, strg, []models.URLPair{testPair})
+
+	opts := &models.LinkOptions{
+		Passthrough: &models.Passthrough{Query: true},
+	}
+
+	set := func(*models.LinkOptions) *models.LinkOptions {
+		return opts
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, set)
+		assert.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		assert.Equal(t, opts, pair.Options)
+	})
+
+	t.Run("update sees stored settings", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, func(stored *models.LinkOptions) *models.LinkOptions {
+			assert.Equal(t, opts, stored)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		assert.Nil(t, pair.Options)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testOtherUserID, testShortURL, set)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := strg.UpdateURLOptions(ctx, testUserID, testShortURL, set)
+		assert.Error(t, err)
+	})
+}
+
+func TestAppMemStorage_ClickLimit(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	fillAppMemStorage(t, strg, []models.URLPair{testPair})
+
+	limit := int64(10)
+
+	t.Run("other user", func(t *testing.T) {
+		err := strg.SetClickLimit(context.Background(), testOtherUserID, testShortURL, &limit)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+
+	t.Run("unlimited link", func(t *testing.T) {
+		err := strg.ConsumeClick(context.Background(), testShortURL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrent burst", func(t *testing.T) {
+		err := strg.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		require.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		require.NotNil(t, pair.ClicksLeft)
+		assert.Equal(t, limit, *pair.ClicksLeft)
+
+		assert.Equal(t, limit, consumeClicksConcurrently(strg.ConsumeClick, 50))
+
+		_, err = strg.GetURLPairByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errDeletedURL)
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		err := strg.ConsumeClick(context.Background(), testDeletedShort)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+}
+
+func TestAppMemStorage_VariantHits(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	t.Run("valid test", func(t *testing.T) {
+		for _, variant := range []string{"a", "b", "a"} {
+			err := strg.AddVariantHit(context.Background(), testShortURL, variant)
+			require.NoError(t, err)
+		}
+
+		hits, err := strg.GetVariantHits(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"a": 2, "b": 1}, hits)
+	})
+
+	t.Run("no hits", func(t *testing.T) {
+		hits, err := strg.GetVariantHits(context.Background(), "not exist")
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := strg.AddVariantHit(ctx, testShortURL, "a")
+		assert.Error(t, err)
+	})
+}
+
 func TestAppMemStorage_DeleteRequestedURLs(t *testing.T) {
 	strg := NewAppMemStorage()
 
@@ -421,6 +799,52 @@ func BenchmarkAppMemStorage_DeleteRequestedURLs(b *testing.B) {
 	})
 }
 
+func TestAppMemStorage_Concurrent(t *testing.T) {
+	strg := NewAppMemStorage()
+
+	const writers, perWriter = 8, 100
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uid := models.UserID(fmt.Sprintf("user-%d", w))
+			for i := 0; i < perWriter; i++ {
+				pair := models.URLPair{
+					UID:   uid,
+					Short: models.ShortURL(fmt.Sprintf("hash-%d", i)),
+					Orig:  testOrigURL,
+				}
+				// Writers race for the same short URLs, only one of them wins each.
+				err := strg.AddURLPair(context.Background(), &pair)
+				if err != nil {
+					assert.ErrorIs(t, err, errConflict)
+					continue
+				}
+
+				got, err := strg.GetURLPairByShort(context.Background(), pair.Short)
+				assert.NoError(t, err)
+				assert.Equal(t, pair, *got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var owned int
+	for w := 0; w < writers; w++ {
+		pairs, err := strg.GetURLPairBatchByUserID(context.Background(), models.UserID(fmt.Sprintf("user-%d", w)))
+		if err == nil {
+			owned += len(pairs)
+		}
+	}
+	assert.Equal(t, perWriter, owned)
+
+	stats, err := strg.GetStats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, perWriter, stats.URLs)
+}
+
 func TestAppMemStorage_Ping(t *testing.T) {
 	strg := NewAppMemStorage()
 
@@ -445,9 +869,7 @@ func BenchmarkAppMemStorage_Ping(b *testing.B) {
 func TestAppMemStorage_GetStat(t *testing.T) {
 	strg := NewAppMemStorage()
 
-	umap := make(map[models.ShortURL]models.OrigURL)
-	umap[testShortURL] = testOrigURL
-	strg.pairs[testUserID] = umap
+	fillAppMemStorage(t, strg, []models.URLPair{testPair})
 
 	users := 1
 	urls := 1
@@ -466,3 +888,91 @@ func TestAppMemStorage_GetStat(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+// benchLinks is the number of links the storage is filled with
+// in scale benchmarks, benchLinksPerUser of them per user.
+const (
+	benchLinks        = 1_000_000
+	benchLinksPerUser = 10
+)
+
+func newBenchAppMemStorage(b *testing.B) *AppMemStorage {
+	b.Helper()
+
+	strg := NewAppMemStorage()
+	pairs := make([]models.URLPair, 0, benchLinksPerUser)
+	for i := 0; i < benchLinks; i++ {
+		pairs = append(pairs, models.URLPair{
+			UID:   models.UserID(fmt.Sprintf("user-%d", i/benchLinksPerUser)),
+			Short: models.ShortURL(fmt.Sprintf("hash-%d", i)),
+			Orig:  models.OrigURL(fmt.Sprintf("https://site.com/page%d", i)),
+		})
+		if len(pairs) == benchLinksPerUser {
+			err := strg.AddBatchURLPairs(context.Background(), pairs)
+			require.NoError(b, err)
+			pairs = pairs[:0]
+		}
+	}
+
+	return strg
+}
+
+func BenchmarkAppMemStorage_1MLinks(b *testing.B) {
+	strg := newBenchAppMemStorage(b)
+	// seq keeps new short URLs unique across repeated runs of a sub-benchmark.
+	var seq int
+
+	b.Run("get pair", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			short := models.ShortURL(fmt.Sprintf("hash-%d", i%benchLinks))
+			_, err := strg.GetURLPairByShort(context.Background(), short)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("add pair of new user", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			seq++
+			err := strg.AddURLPair(context.Background(), &models.URLPair{
+				UID:   models.UserID(fmt.Sprintf("new-user-%d", seq)),
+				Short: models.ShortURL(fmt.Sprintf("new-hash-%d", seq)),
+				Orig:  testOrigURL,
+			})
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("parallel get pair with concurrent writes", func(b *testing.B) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for ctx.Err() == nil {
+				seq++
+				_ = strg.AddURLPair(ctx, &models.URLPair{
+					UID:   models.UserID(fmt.Sprintf("writer-%d", seq%1000)),
+					Short: models.ShortURL(fmt.Sprintf("writer-hash-%d", seq)),
+					Orig:  testOrigURL,
+				})
+			}
+		}()
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			var i int
+			for pb.Next() {
+				short := models.ShortURL(fmt.Sprintf("hash-%d", i%benchLinks))
+				_, err := strg.GetURLPairByShort(context.Background(), short)
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				i += 7919
+			}
+		})
+		b.StopTimer()
+
+		cancel()
+		<-done
+	})
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic replaces the named file with the content produced by write.
+//
+// The content is written into a temporary file in the same directory which is
+// synced and then renamed over the target, so readers see either the old or
+// the new file. If write fails the target is left untouched.
+func writeFileAtomic(name string, write func(io.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if tmpCloseErr := tmp.Close(); tmpCloseErr != nil && !errors.Is(tmpCloseErr, os.ErrClosed) {
+			err = fmt.Errorf("%v; temp file close failed: %w", err, tmpCloseErr)
+		}
+		if removeErr := os.Remove(tmp.Name()); removeErr != nil {
+			err = fmt.Errorf("%v; temp file remove failed: %w", err, removeErr)
+		}
+	}()
+
+	err = write(tmp)
+	if err != nil {
+		return err
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rycln/shorturl/internal/models"
 )
@@ -29,3 +32,28 @@ var (
 		Short: testDeletedShort,
 	}
 )
+
+// consumeClicksConcurrently takes clicks of the test link from n goroutines
+// at once and returns the number of successful ones.
+func consumeClicksConcurrently(consume func(context.Context, models.ShortURL) error, n int) int64 {
+	var (
+		wg      sync.WaitGroup
+		success atomic.Int64
+		start   = make(chan struct{})
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if consume(context.Background(), testShortURL) == nil {
+				success.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return success.Load()
+}
@@ -5,6 +5,8 @@ type StorageConfig struct {
 	strgType    string
 	filePath    string
 	databaseDsn string
+
+	memSnapshotPath string
 }
 
 type option func(*StorageConfig)
@@ -40,3 +42,10 @@ func WithDatabaseDsn(dsn string) option {
 		cfg.databaseDsn = dsn
 	}
 }
+
+// WithMemSnapshotPath sets the snapshot file in-memory storage is loaded from.
+func WithMemSnapshotPath(path string) option {
+	return func(cfg *StorageConfig) {
+		cfg.memSnapshotPath = path
+	}
+}
@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Connection pool parameters
@@ -15,9 +20,21 @@ const (
 	maxConnLifetime = 0 //unlimited
 )
 
-// NewDB creates a new database connection pool with configured settings
+// NewDB creates a new database connection pool with configured settings.
+//
+// SQL statements are traced as children of the span in their context.
 func NewDB(dsn string) (*sql.DB, error) {
-	database, err := sql.Open("pgx", dsn)
+	database, err := otelsql.Open("pgx", dsn,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+			// Statements of background jobs outside any trace are not worth a trace of their own.
+			SpanFilter: func(ctx context.Context, _ otelsql.Method, _ string, _ []driver.NamedValue) bool {
+				return trace.SpanContextFromContext(ctx).IsValid()
+			},
+		}),
+	)
 	if err != nil {
 		return nil, err
 	}
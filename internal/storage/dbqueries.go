@@ -2,28 +2,155 @@ package storage
 
 const sqlAddURLPair = `
 	INSERT INTO urls 
-	(user_id, short_url, original_url) 
-	VALUES ($1, $2, $3)
+	(user_id, short_url, original_url, options, active_from, expires_at, created_at) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+const sqlImportURLPair = `
+	INSERT INTO urls 
+	(user_id, short_url, original_url, expires_at, created_at, tags) 
+	VALUES ($1, $2, $3, $4, $5, $6) 
+	ON CONFLICT DO NOTHING
 `
 
 const sqlGetURLPairByShort = `
 	SELECT 
 		user_id,
 		original_url, 
+		options, 
+		clicks_left, 
+		active_from, 
+		expires_at, 
+		created_at, 
 		is_deleted 
 	FROM urls 
 	WHERE short_url = $1
 `
 
+const sqlLookupURLPairBatch = `
+	SELECT 
+		short_url, 
+		user_id, 
+		original_url, 
+		options, 
+		clicks_left, 
+		active_from, 
+		expires_at, 
+		created_at, 
+		is_deleted 
+	FROM urls 
+	WHERE short_url = ANY($1)
+`
+
 const sqlGetURLPairBatchByUserID = `
 	SELECT 
 		user_id, 
 		short_url, 
-		original_url 
+		original_url, 
+		options, 
+		active_from, 
+		expires_at, 
+		created_at, 
+		tags 
 	FROM urls 
 	WHERE user_id = $1
 `
 
+const sqlIterateURLPairsByUserID = `
+	SELECT 
+		user_id, 
+		short_url, 
+		original_url, 
+		options, 
+		clicks_left, 
+		active_from, 
+		expires_at, 
+		created_at, 
+		tags, 
+		is_deleted 
+	FROM urls 
+	WHERE user_id = $1 
+	ORDER BY created_at, short_url
+`
+
+const sqlIterateURLPairs = `
+	SELECT 
+		user_id, 
+		short_url, 
+		original_url, 
+		options, 
+		clicks_left, 
+		active_from, 
+		expires_at, 
+		created_at, 
+		tags, 
+		is_deleted 
+	FROM urls 
+	ORDER BY short_url
+`
+
+const sqlGetTombstones = `
+	SELECT 
+		user_id, 
+		short_url 
+	FROM urls 
+	WHERE is_deleted 
+	ORDER BY short_url
+`
+
+const sqlRestoreURLPair = `
+	INSERT INTO urls 
+	(user_id, short_url, original_url, options, clicks_left, active_from, expires_at, created_at, tags, is_deleted) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) 
+	ON CONFLICT DO NOTHING
+`
+
+const sqlLockURLOptions = `
+	SELECT options 
+	FROM urls 
+	WHERE user_id = $1 AND short_url = $2 
+	FOR UPDATE
+`
+
+const sqlUpdateURLOptions = `
+	UPDATE urls 
+	SET options = $3 
+	WHERE user_id = $1 AND short_url = $2
+`
+
+const sqlSetClickLimit = `
+	UPDATE urls 
+	SET clicks_left = $3 
+	WHERE user_id = $1 AND short_url = $2
+`
+
+const sqlConsumeClick = `
+	UPDATE urls 
+	SET 
+		clicks_left = clicks_left - 1, 
+		is_deleted = COALESCE(clicks_left <= 1, FALSE) 
+	WHERE short_url = $1 
+		AND NOT is_deleted 
+		AND (clicks_left IS NULL OR clicks_left > 0) 
+	RETURNING clicks_left
+`
+
+const sqlAddVariantHit = `
+	INSERT INTO variant_hits 
+	(short_url, variant, hits) 
+	VALUES ($1, $2, 1) 
+	ON CONFLICT (short_url, variant) 
+	DO UPDATE SET hits = variant_hits.hits + 1
+`
+
+const sqlGetVariantHits = `
+	SELECT 
+		variant, 
+		hits 
+	FROM variant_hits 
+	WHERE short_url = $1
+`
+
 const sqlDeleteRequestedURLs = `
 	UPDATE urls 
 	SET is_deleted = TRUE 
@@ -36,3 +163,15 @@ SELECT
     (SELECT COUNT(*) FROM users) AS total_users;
 
 `
+
+const sqlTryAdvisoryLock = `
+	SELECT pg_try_advisory_lock($1)
+`
+
+const sqlAdvisoryUnlock = `
+	SELECT pg_advisory_unlock($1)
+`
+
+const sqlGetSchemaVersion = `
+	SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied
+`
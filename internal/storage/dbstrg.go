@@ -3,8 +3,10 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -34,7 +36,12 @@ func (s *DatabaseStorage) AddURLPair(ctx context.Context, pair *models.URLPair)
 		}
 	}()
 
-	_, err = tx.ExecContext(ctx, sqlAddURLPair, pair.UID, pair.Short, pair.Orig)
+	opts, err := encodeOptions(pair.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlAddURLPair, pair.UID, pair.Short, pair.Orig, opts, pair.ActiveFrom, pair.ExpiresAt, encodeTime(pair.CreatedAt))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
@@ -48,23 +55,109 @@ func (s *DatabaseStorage) AddURLPair(ctx context.Context, pair *models.URLPair)
 
 // GetURLPairByShort retrieves a URL pair by its short URL.
 func (s *DatabaseStorage) GetURLPairByShort(ctx context.Context, short models.ShortURL) (*models.URLPair, error) {
+	pair, isDeleted, err := s.queryURLPair(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDeleted {
+		return nil, newErrDeletedURL(errDeletedURL)
+	}
+
+	return pair, nil
+}
+
+// LookupURLPair retrieves a URL pair by its short URL including deleted ones.
+//
+// Reports whether the pair was deleted.
+func (s *DatabaseStorage) LookupURLPair(ctx context.Context, short models.ShortURL) (*models.URLPair, bool, error) {
+	pair, isDeleted, err := s.queryURLPair(ctx, short)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, newErrNotExist(errNotExist)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pair, isDeleted, nil
+}
+
+func (s *DatabaseStorage) queryURLPair(ctx context.Context, short models.ShortURL) (*models.URLPair, bool, error) {
 	row := s.db.QueryRowContext(ctx, sqlGetURLPairByShort, short)
 
 	var pair = models.URLPair{
 		Short: short,
 	}
 	var isDeleted bool
+	var opts []byte
+	var clicksLeft sql.NullInt64
+	var activeFrom, expiresAt, createdAt sql.NullTime
 
-	err := row.Scan(&pair.UID, &pair.Orig, &isDeleted)
+	err := row.Scan(&pair.UID, &pair.Orig, &opts, &clicksLeft, &activeFrom, &expiresAt, &createdAt, &isDeleted)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = decodePairColumns(&pair, opts, clicksLeft, activeFrom, expiresAt, createdAt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &pair, isDeleted, nil
+}
+
+// LookupURLPairBatch retrieves URL pairs by their short URLs including deleted ones
+// with a single query.
+//
+// Unknown short URLs are absent from the result. Status of the found pairs
+// is either active or deleted, schedule is not taken into account.
+func (s *DatabaseStorage) LookupURLPairBatch(ctx context.Context, shorts []models.ShortURL) (infos map[models.ShortURL]models.URLInfo, err error) {
+	keys := make([]string, len(shorts))
+	for i, short := range shorts {
+		keys[i] = string(short)
+	}
+
+	rows, err := s.db.QueryContext(ctx, sqlLookupURLPairBatch, keys)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
 
-	if isDeleted {
-		return nil, newErrDeletedURL(errDeletedURL)
+	infos = make(map[models.ShortURL]models.URLInfo, len(shorts))
+	for rows.Next() {
+		var info models.URLInfo
+		var isDeleted bool
+		var opts []byte
+		var clicksLeft sql.NullInt64
+		var activeFrom, expiresAt, createdAt sql.NullTime
+
+		err = rows.Scan(&info.Short, &info.UID, &info.Orig, &opts, &clicksLeft, &activeFrom, &expiresAt, &createdAt, &isDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		err = decodePairColumns(&info.URLPair, opts, clicksLeft, activeFrom, expiresAt, createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Status = models.StatusActive
+		if isDeleted {
+			info.Status = models.StatusDeleted
+		}
+		infos[info.Short] = info
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
 	}
 
-	return &pair, nil
+	return infos, nil
 }
 
 // AddBatchURLPairs stores multiple URL pairs in a single transaction.
@@ -80,7 +173,12 @@ func (s *DatabaseStorage) AddBatchURLPairs(ctx context.Context, pairs []models.U
 	}()
 
 	for _, pair := range pairs {
-		_, err := tx.ExecContext(ctx, sqlAddURLPair, pair.UID, pair.Short, pair.Orig)
+		opts, err := encodeOptions(pair.Options)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, sqlAddURLPair, pair.UID, pair.Short, pair.Orig, opts, pair.ActiveFrom, pair.ExpiresAt, encodeTime(pair.CreatedAt))
 		if err != nil {
 			return err
 		}
@@ -89,6 +187,48 @@ func (s *DatabaseStorage) AddBatchURLPairs(ctx context.Context, pairs []models.U
 	return tx.Commit()
 }
 
+// ImportURLPairs stores multiple URL pairs in a single transaction
+// skipping the ones which collide with existing links.
+//
+// Reports for each pair whether it was skipped as a conflict.
+func (s *DatabaseStorage) ImportURLPairs(ctx context.Context, pairs []models.URLPair) (conflicts []bool, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	conflicts = make([]bool, len(pairs))
+	for i, pair := range pairs {
+		tags, err := encodeTags(pair.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := tx.ExecContext(ctx, sqlImportURLPair, pair.UID, pair.Short, pair.Orig, pair.ExpiresAt, encodeTime(pair.CreatedAt), tags)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		conflicts[i] = n == 0
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
 // GetURLPairBatchByUserID retrieves all active URL pairs for a user.
 func (s *DatabaseStorage) GetURLPairBatchByUserID(ctx context.Context, uid models.UserID) (pairs []models.URLPair, err error) {
 	rows, err := s.db.QueryContext(ctx, sqlGetURLPairBatchByUserID, uid)
@@ -103,8 +243,22 @@ func (s *DatabaseStorage) GetURLPairBatchByUserID(ctx context.Context, uid model
 
 	for rows.Next() {
 		var pair models.URLPair
+		var opts, tags []byte
+		var activeFrom, expiresAt, createdAt sql.NullTime
+
+		err = rows.Scan(&pair.UID, &pair.Short, &pair.Orig, &opts, &activeFrom, &expiresAt, &createdAt, &tags)
+		if err != nil {
+			return nil, err
+		}
+		pair.Schedule = decodeSchedule(activeFrom, expiresAt)
+		pair.CreatedAt = createdAt.Time
+
+		pair.Options, err = decodeOptions(opts)
+		if err != nil {
+			return nil, err
+		}
 
-		err = rows.Scan(&pair.UID, &pair.Short, &pair.Orig)
+		pair.Tags, err = decodeTags(tags)
 		if err != nil {
 			return nil, err
 		}
@@ -124,6 +278,295 @@ func (s *DatabaseStorage) GetURLPairBatchByUserID(ctx context.Context, uid model
 	return pairs, nil
 }
 
+// IterateURLPairsByUserID calls fn for every URL pair of the user including
+// deleted ones, oldest first. Rows are read from the database as fn
+// consumes them. Iteration stops at the first error returned by fn.
+//
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *DatabaseStorage) IterateURLPairsByUserID(ctx context.Context, uid models.UserID, fn func(models.URLInfo) error) error {
+	return iterateURLInfos(ctx, s.db, fn, sqlIterateURLPairsByUserID, uid)
+}
+
+// IterateURLPairs calls fn for every URL pair of all users including
+// deleted ones, ordered by short URL. Rows are read from the database
+// as fn consumes them. Iteration stops at the first error returned by fn.
+//
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *DatabaseStorage) IterateURLPairs(ctx context.Context, fn func(models.URLInfo) error) error {
+	return iterateURLInfos(ctx, s.db, fn, sqlIterateURLPairs)
+}
+
+// SnapshotURLPairs calls pairFn for every URL pair and then tombstoneFn for
+// every deleted one. Both are read in a single read-only repeatable read
+// transaction, so they reflect the same point in time.
+func (s *DatabaseStorage) SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) (err error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	err = iterateURLInfos(ctx, tx, func(info models.URLInfo) error {
+		return pairFn(info.URLPair)
+	}, sqlIterateURLPairs)
+	if err != nil {
+		return err
+	}
+
+	err = iterateTombstones(ctx, tx, tombstoneFn)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func iterateURLInfos(ctx context.Context, q queryer, fn func(models.URLInfo) error, query string, args ...any) (err error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var info models.URLInfo
+		var isDeleted bool
+		var opts, tags []byte
+		var clicksLeft sql.NullInt64
+		var activeFrom, expiresAt, createdAt sql.NullTime
+
+		err = rows.Scan(&info.UID, &info.Short, &info.Orig, &opts, &clicksLeft, &activeFrom, &expiresAt, &createdAt, &tags, &isDeleted)
+		if err != nil {
+			return err
+		}
+
+		err = decodePairColumns(&info.URLPair, opts, clicksLeft, activeFrom, expiresAt, createdAt)
+		if err != nil {
+			return err
+		}
+		info.Tags, err = decodeTags(tags)
+		if err != nil {
+			return err
+		}
+
+		info.Status = models.StatusActive
+		if isDeleted {
+			info.Status = models.StatusDeleted
+		}
+
+		err = fn(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func iterateTombstones(ctx context.Context, q queryer, fn func(models.DelURLReq) error) (err error) {
+	rows, err := q.QueryContext(ctx, sqlGetTombstones)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	for rows.Next() {
+		var req models.DelURLReq
+		err = rows.Scan(&req.UID, &req.Short)
+		if err != nil {
+			return err
+		}
+
+		err = fn(req)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// RestoreURLPairs stores URL pairs with all their attributes and deletion
+// state in a single transaction skipping the ones which collide with
+// existing links.
+//
+// Reports for each pair whether it was skipped as a conflict.
+func (s *DatabaseStorage) RestoreURLPairs(ctx context.Context, infos []models.URLInfo) (conflicts []bool, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	conflicts = make([]bool, len(infos))
+	for i, info := range infos {
+		opts, err := encodeOptions(info.Options)
+		if err != nil {
+			return nil, err
+		}
+		tags, err := encodeTags(info.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := tx.ExecContext(ctx, sqlRestoreURLPair, info.UID, info.Short, info.Orig, opts, info.ClicksLeft,
+			info.ActiveFrom, info.ExpiresAt, encodeTime(info.CreatedAt), tags, info.Status == models.StatusDeleted)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		conflicts[i] = n == 0
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// UpdateURLOptions replaces settings of the URL pair owned by user
+// with the result of update applied to the stored ones.
+//
+// The row stays locked by the transaction while update runs.
+func (s *DatabaseStorage) UpdateURLOptions(ctx context.Context, uid models.UserID, short models.ShortURL, update func(*models.LinkOptions) *models.LinkOptions) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = fmt.Errorf("%v; rollback failed: %w", err, rollbackErr)
+		}
+	}()
+
+	var stored []byte
+	err = tx.QueryRowContext(ctx, sqlLockURLOptions, uid, short).Scan(&stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		return newErrNotExist(errNotExist)
+	}
+	if err != nil {
+		return err
+	}
+
+	opts, err := decodeOptions(stored)
+	if err != nil {
+		return err
+	}
+	encoded, err := encodeOptions(update(opts))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlUpdateURLOptions, uid, short, encoded)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetClickLimit sets number of redirects left for the URL pair owned by user.
+func (s *DatabaseStorage) SetClickLimit(ctx context.Context, uid models.UserID, short models.ShortURL, limit *int64) error {
+	res, err := s.db.ExecContext(ctx, sqlSetClickLimit, uid, short, limit)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return newErrNotExist(errNotExist)
+	}
+
+	return nil
+}
+
+// ConsumeClick atomically takes one redirect of the limited link.
+//
+// The counter is checked and decremented by a single conditional update,
+// so concurrent redirects never exceed the limit. The link is marked
+// as deleted when the last redirect is taken.
+func (s *DatabaseStorage) ConsumeClick(ctx context.Context, short models.ShortURL) error {
+	var left sql.NullInt64
+	err := s.db.QueryRowContext(ctx, sqlConsumeClick, short).Scan(&left)
+	if errors.Is(err, sql.ErrNoRows) {
+		return newErrDeletedURL(errClicksExhausted)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddVariantHit increments redirect counter of the link variant.
+func (s *DatabaseStorage) AddVariantHit(ctx context.Context, short models.ShortURL, variant string) error {
+	_, err := s.db.ExecContext(ctx, sqlAddVariantHit, short, variant)
+	return err
+}
+
+// GetVariantHits returns redirect counters of all link variants.
+func (s *DatabaseStorage) GetVariantHits(ctx context.Context, short models.ShortURL) (hits map[string]int64, err error) {
+	rows, err := s.db.QueryContext(ctx, sqlGetVariantHits, short)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rowsCloseErr := rows.Close(); rowsCloseErr != nil {
+			err = fmt.Errorf("%v; rows close failed: %w", err, rowsCloseErr)
+		}
+	}()
+
+	hits = make(map[string]int64)
+	for rows.Next() {
+		var variant string
+		var n int64
+
+		err = rows.Scan(&variant, &n)
+		if err != nil {
+			return nil, err
+		}
+
+		hits[variant] = n
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
 // DeleteRequestedURLs performs batch soft deletion of URLs.
 func (s *DatabaseStorage) DeleteRequestedURLs(ctx context.Context, delurls []*models.DelURLReq) (err error) {
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -182,3 +625,84 @@ func (s *DatabaseStorage) Ping(ctx context.Context) error {
 func (s *DatabaseStorage) Close() error {
 	return s.db.Close()
 }
+
+// encodeOptions converts link settings into JSONB column value.
+//
+// Returns nil for absent settings so the column is stored as NULL.
+func encodeOptions(opts *models.LinkOptions) (any, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	return json.Marshal(opts)
+}
+
+// decodeOptions restores link settings from JSONB column value.
+func decodeOptions(data []byte) (*models.LinkOptions, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var opts models.LinkOptions
+	if err := json.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+// encodeTags converts link tags into JSONB column value.
+func encodeTags(tags []string) (any, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(tags)
+}
+
+// decodeTags restores link tags from JSONB column value.
+func decodeTags(data []byte) ([]string, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// decodePairColumns fills URL pair fields stored in nullable columns.
+func decodePairColumns(pair *models.URLPair, opts []byte, clicksLeft sql.NullInt64, activeFrom, expiresAt, createdAt sql.NullTime) error {
+	if clicksLeft.Valid {
+		pair.ClicksLeft = &clicksLeft.Int64
+	}
+	pair.Schedule = decodeSchedule(activeFrom, expiresAt)
+	pair.CreatedAt = createdAt.Time
+
+	var err error
+	pair.Options, err = decodeOptions(opts)
+	return err
+}
+
+// decodeSchedule restores link schedule from nullable timestamp columns.
+func decodeSchedule(activeFrom, expiresAt sql.NullTime) models.Schedule {
+	var sched models.Schedule
+	if activeFrom.Valid {
+		sched.ActiveFrom = &activeFrom.Time
+	}
+	if expiresAt.Valid {
+		sched.ExpiresAt = &expiresAt.Time
+	}
+	return sched
+}
+
+// encodeTime converts time into nullable column value.
+//
+// Returns nil for zero time so the column is stored as NULL.
+func encodeTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
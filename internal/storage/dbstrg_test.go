@@ -2,8 +2,12 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgerrcode"
@@ -34,7 +38,7 @@ func TestDatabaseStorage_AddURLPair(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		err := strg.AddURLPair(context.Background(), &testPair)
@@ -59,7 +63,7 @@ func TestDatabaseStorage_AddURLPair(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig).WillReturnError(pgErr)
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil).WillReturnError(pgErr)
 
 		err := strg.AddURLPair(context.Background(), &testPair)
 		assert.ErrorIs(t, err, errConflict)
@@ -94,7 +98,7 @@ func TestDatabaseStorage_GetURLPairByShort(t *testing.T) {
 	expectedQuery := regexp.QuoteMeta(sqlGetURLPairByShort)
 
 	t.Run("valid test", func(t *testing.T) {
-		rows := mock.NewRows([]string{"user_id", "original_url", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, false)
+		rows := mock.NewRows([]string{"user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, nil, nil, nil, nil, nil, false)
 		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 
 		pair, err := strg.GetURLPairByShort(context.Background(), testPair.Short)
@@ -103,6 +107,30 @@ func TestDatabaseStorage_GetURLPairByShort(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("limited link", func(t *testing.T) {
+		rows := mock.NewRows([]string{"user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, nil, 3, nil, nil, nil, false)
+		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testPair.Short)
+		assert.NoError(t, err)
+		require.NotNil(t, pair.ClicksLeft)
+		assert.Equal(t, int64(3), *pair.ClicksLeft)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("scheduled link", func(t *testing.T) {
+		from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows := mock.NewRows([]string{"user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, nil, nil, from, nil, nil, false)
+		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testPair.Short)
+		assert.NoError(t, err)
+		require.NotNil(t, pair.ActiveFrom)
+		assert.True(t, from.Equal(*pair.ActiveFrom))
+		assert.Nil(t, pair.ExpiresAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("ctx expired", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
@@ -112,7 +140,7 @@ func TestDatabaseStorage_GetURLPairByShort(t *testing.T) {
 	})
 
 	t.Run("deleted url", func(t *testing.T) {
-		rows := mock.NewRows([]string{"user_id", "original_url", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, true)
+		rows := mock.NewRows([]string{"user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}).AddRow(testPair.UID, testPair.Orig, nil, nil, nil, nil, nil, true)
 		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 
 		_, err := strg.GetURLPairByShort(context.Background(), testPair.Short)
@@ -121,6 +149,100 @@ func TestDatabaseStorage_GetURLPairByShort(t *testing.T) {
 	})
 }
 
+func TestDatabaseStorage_LookupURLPair(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetURLPairByShort)
+	columns := []string{"user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("deleted url", func(t *testing.T) {
+		rows := mock.NewRows(columns).AddRow(testPair.UID, testPair.Orig, nil, nil, nil, nil, created, true)
+		mock.ExpectQuery(expectedQuery).WithArgs(testShortURL).WillReturnRows(rows)
+
+		pair, deleted, err := strg.LookupURLPair(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, testOrigURL, pair.Orig)
+		assert.True(t, created.Equal(pair.CreatedAt))
+	})
+
+	t.Run("not exist error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testShortURL).WillReturnRows(mock.NewRows(columns))
+
+		_, _, err := strg.LookupURLPair(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+}
+
+func TestDatabaseStorage_LookupURLPairBatch(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlLookupURLPairBatch)
+	columns := []string{"short_url", "user_id", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "is_deleted"}
+	shorts := []models.ShortURL{testShortURL, testDeletedShort, "not exist"}
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows(columns).
+			AddRow(testShortURL, testUserID, testOrigURL, nil, nil, nil, nil, nil, false).
+			AddRow(testDeletedShort, testUserID, testOrigURL, nil, nil, nil, nil, nil, true)
+		mock.ExpectQuery(expectedQuery).WithArgs([]string{string(testShortURL), string(testDeletedShort), "not exist"}).WillReturnRows(rows)
+
+		deletedPair := testPair
+		deletedPair.Short = testDeletedShort
+
+		infos, err := strg.LookupURLPairBatch(context.Background(), shorts)
+		assert.NoError(t, err)
+		assert.Equal(t, map[models.ShortURL]models.URLInfo{
+			testShortURL:     {URLPair: testPair, Status: models.StatusActive},
+			testDeletedShort: {URLPair: deletedPair, Status: models.StatusDeleted},
+		}, infos)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WillReturnError(errTest)
+
+		_, err := strg.LookupURLPairBatch(context.Background(), shorts)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+// arrayConverter passes slice arguments through to sqlmock as the pgx driver does.
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v any) (driver.Value, error) {
+	if s, ok := v.([]string); ok {
+		return s, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
 func TestDatabaseStorage_AddBatchURLPairs(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -145,7 +267,7 @@ func TestDatabaseStorage_AddBatchURLPairs(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		err := strg.AddBatchURLPairs(context.Background(), pairs)
@@ -155,7 +277,7 @@ func TestDatabaseStorage_AddBatchURLPairs(t *testing.T) {
 
 	t.Run("some error", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig).WillReturnError(errTest)
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil).WillReturnError(errTest)
 
 		err := strg.AddBatchURLPairs(context.Background(), pairs)
 		assert.Error(t, err)
@@ -181,6 +303,64 @@ func TestDatabaseStorage_AddBatchURLPairs(t *testing.T) {
 	})
 }
 
+func TestDatabaseStorage_ImportURLPairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlImportURLPair)
+
+	tagged := testPair
+	tagged.Short = "custom"
+	tagged.Tags = []string{"promo", "2025"}
+
+	pairs := []models.URLPair{
+		testPair,
+		tagged,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(expectedQuery).WithArgs(tagged.UID, tagged.Short, tagged.Orig, nil, nil, []byte(`["promo","2025"]`)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		conflicts, err := strg.ImportURLPairs(context.Background(), pairs)
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, conflicts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).WithArgs(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		_, err := strg.ImportURLPairs(context.Background(), pairs)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("tx begin error", func(t *testing.T) {
+		mock.ExpectBegin().WillReturnError(errTest)
+
+		_, err := strg.ImportURLPairs(context.Background(), pairs)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestDatabaseStorage_GetURLPairBatchByUserID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -204,7 +384,7 @@ func TestDatabaseStorage_GetURLPairBatchByUserID(t *testing.T) {
 	}
 
 	t.Run("valid test", func(t *testing.T) {
-		rows := mock.NewRows([]string{"user_id", "short_url", "original_url"}).AddRow(testPair.UID, testPair.Short, testPair.Orig)
+		rows := mock.NewRows([]string{"user_id", "short_url", "original_url", "options", "active_from", "expires_at", "created_at", "tags"}).AddRow(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil, nil)
 		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 
 		pairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
@@ -213,6 +393,17 @@ func TestDatabaseStorage_GetURLPairBatchByUserID(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("tagged pair", func(t *testing.T) {
+		rows := mock.NewRows([]string{"user_id", "short_url", "original_url", "options", "active_from", "expires_at", "created_at", "tags"}).AddRow(testPair.UID, testPair.Short, testPair.Orig, nil, nil, nil, nil, []byte(`["promo"]`))
+		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
+
+		pairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
+		assert.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Equal(t, []string{"promo"}, pairs[0].Tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("ctx expired", func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
@@ -230,7 +421,7 @@ func TestDatabaseStorage_GetURLPairBatchByUserID(t *testing.T) {
 	})
 
 	t.Run("valid test", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WillReturnRows(sqlmock.NewRows([]string{"user_id", "short_url", "original_url"}))
+		mock.ExpectQuery(expectedQuery).WillReturnRows(sqlmock.NewRows([]string{"user_id", "short_url", "original_url", "options", "active_from", "expires_at", "created_at", "tags"}))
 
 		_, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
 		assert.ErrorIs(t, err, errNotExist)
@@ -238,6 +429,364 @@ func TestDatabaseStorage_GetURLPairBatchByUserID(t *testing.T) {
 	})
 }
 
+func TestDatabaseStorage_IterateURLPairsByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlIterateURLPairsByUserID)
+	columns := []string{"user_id", "short_url", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "tags", "is_deleted"}
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	deletedPair.Tags = []string{"promo"}
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows(columns).
+			AddRow(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false).
+			AddRow(testUserID, testDeletedShort, testOrigURL, nil, nil, nil, nil, nil, []byte(`["promo"]`), true)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnRows(rows)
+
+		var infos []models.URLInfo
+		err := strg.IterateURLPairsByUserID(context.Background(), testUserID, func(info models.URLInfo) error {
+			infos = append(infos, info)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLInfo{
+			{URLPair: testPair, Status: models.StatusActive},
+			{URLPair: deletedPair, Status: models.StatusDeleted},
+		}, infos)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		rows := mock.NewRows(columns).
+			AddRow(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false).
+			AddRow(testUserID, testDeletedShort, testOrigURL, nil, nil, nil, nil, nil, nil, true)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnRows(rows)
+
+		var calls int
+		err := strg.IterateURLPairsByUserID(context.Background(), testUserID, func(models.URLInfo) error {
+			calls++
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, 1, calls)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnError(errTest)
+
+		err := strg.IterateURLPairsByUserID(context.Background(), testUserID, func(models.URLInfo) error { return nil })
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_IterateURLPairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlIterateURLPairs)
+	columns := []string{"user_id", "short_url", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "tags", "is_deleted"}
+
+	deletedPair := testPair
+	deletedPair.UID = testOtherUserID
+	deletedPair.Short = testDeletedShort
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows(columns).
+			AddRow(testOtherUserID, testDeletedShort, testOrigURL, nil, nil, nil, nil, nil, nil, true).
+			AddRow(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false)
+		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
+
+		var infos []models.URLInfo
+		err := strg.IterateURLPairs(context.Background(), func(info models.URLInfo) error {
+			infos = append(infos, info)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLInfo{
+			{URLPair: deletedPair, Status: models.StatusDeleted},
+			{URLPair: testPair, Status: models.StatusActive},
+		}, infos)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WillReturnError(errTest)
+
+		err := strg.IterateURLPairs(context.Background(), func(models.URLInfo) error { return nil })
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_SnapshotURLPairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	pairsQuery := regexp.QuoteMeta(sqlIterateURLPairs)
+	tombstonesQuery := regexp.QuoteMeta(sqlGetTombstones)
+	columns := []string{"user_id", "short_url", "original_url", "options", "clicks_left", "active_from", "expires_at", "created_at", "tags", "is_deleted"}
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(pairsQuery).WillReturnRows(mock.NewRows(columns).
+			AddRow(testUserID, testDeletedShort, testOrigURL, nil, nil, nil, nil, nil, nil, true).
+			AddRow(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false))
+		mock.ExpectQuery(tombstonesQuery).WillReturnRows(mock.NewRows([]string{"user_id", "short_url"}).
+			AddRow(testUserID, testDeletedShort))
+		mock.ExpectCommit()
+
+		var pairs []models.URLPair
+		var tombstones []models.DelURLReq
+		err := strg.SnapshotURLPairs(context.Background(),
+			func(pair models.URLPair) error {
+				pairs = append(pairs, pair)
+				return nil
+			},
+			func(req models.DelURLReq) error {
+				tombstones = append(tombstones, req)
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.URLPair{deletedPair, testPair}, pairs)
+		assert.Equal(t, []models.DelURLReq{testDelReq}, tombstones)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(pairsQuery).WillReturnRows(mock.NewRows(columns).
+			AddRow(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false))
+		mock.ExpectRollback()
+
+		err := strg.SnapshotURLPairs(context.Background(),
+			func(models.URLPair) error { return errTest },
+			func(models.DelURLReq) error { return nil })
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("tx begin error", func(t *testing.T) {
+		mock.ExpectBegin().WillReturnError(errTest)
+
+		err := strg.SnapshotURLPairs(context.Background(),
+			func(models.URLPair) error { return nil },
+			func(models.DelURLReq) error { return nil })
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_RestoreURLPairs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlRestoreURLPair)
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	deletedPair.Tags = []string{"promo"}
+
+	infos := []models.URLInfo{
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: deletedPair, Status: models.StatusDeleted},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testShortURL, testOrigURL, nil, nil, nil, nil, nil, nil, false).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(expectedQuery).
+			WithArgs(testUserID, testDeletedShort, testOrigURL, nil, nil, nil, nil, nil, []byte(`["promo"]`), true).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		conflicts, err := strg.RestoreURLPairs(context.Background(), infos)
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, conflicts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedQuery).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		_, err := strg.RestoreURLPairs(context.Background(), infos)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_UpdateURLOptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	lockQuery := regexp.QuoteMeta(sqlLockURLOptions)
+	updateQuery := regexp.QuoteMeta(sqlUpdateURLOptions)
+
+	stored := &models.LinkOptions{
+		PasswordHash: "hash",
+	}
+	storedEncoded, err := json.Marshal(stored)
+	require.NoError(t, err)
+
+	opts := &models.LinkOptions{
+		Passthrough:  &models.Passthrough{Query: true},
+		PasswordHash: "hash",
+	}
+	encoded, err := json.Marshal(opts)
+	require.NoError(t, err)
+
+	merge := func(got *models.LinkOptions) *models.LinkOptions {
+		assert.Equal(t, stored, got)
+		return opts
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(testUserID, testShortURL).WillReturnRows(sqlmock.NewRows([]string{"options"}).AddRow(storedEncoded))
+		mock.ExpectExec(updateQuery).WithArgs(testUserID, testShortURL, encoded).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, merge)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not exist error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(testUserID, testShortURL).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, merge)
+		assert.ErrorIs(t, err, errNotExist)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(testUserID, testShortURL).WillReturnRows(sqlmock.NewRows([]string{"options"}).AddRow(storedEncoded))
+		mock.ExpectExec(updateQuery).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, merge)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_VariantHits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	t.Run("add hit", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddVariantHit)).WithArgs(testShortURL, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.AddVariantHit(context.Background(), testShortURL, "a")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get hits", func(t *testing.T) {
+		rows := mock.NewRows([]string{"variant", "hits"}).AddRow("a", 2).AddRow("b", 1)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetVariantHits)).WithArgs(testShortURL).WillReturnRows(rows)
+
+		hits, err := strg.GetVariantHits(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"a": 2, "b": 1}, hits)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetVariantHits)).WillReturnError(errTest)
+
+		_, err := strg.GetVariantHits(context.Background(), testShortURL)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestDatabaseStorage_DeleteRequestedURLs(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -375,3 +924,94 @@ func TestDatabaseStorage_GetStats(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestDatabaseStorage_SetClickLimit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlSetClickLimit)
+
+	limit := int64(1)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testShortURL, limit).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("remove limit", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testShortURL, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.SetClickLimit(context.Background(), testUserID, testShortURL, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not exist error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testShortURL, limit).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		assert.ErrorIs(t, err, errNotExist)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatabaseStorage_ConsumeClick(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		mock.ExpectClose()
+
+		err = db.Close()
+		require.NoError(t, err)
+
+		err = mock.ExpectationsWereMet()
+		require.NoError(t, err)
+	}()
+
+	strg := NewDatabaseStorage(db)
+
+	expectedQuery := regexp.QuoteMeta(sqlConsumeClick)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"clicks_left"}).AddRow(0)
+		mock.ExpectQuery(expectedQuery).WithArgs(testShortURL).WillReturnRows(rows)
+
+		err := strg.ConsumeClick(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("clicks exhausted", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testShortURL).WillReturnRows(mock.NewRows([]string{"clicks_left"}))
+
+		err := strg.ConsumeClick(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errClicksExhausted)
+		var e interface{ IsErrDeletedURL() bool }
+		assert.ErrorAs(t, err, &e)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testShortURL).WillReturnError(errTest)
+
+		err := strg.ConsumeClick(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
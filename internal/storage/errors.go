@@ -8,6 +8,8 @@ var (
 	errConflict   = errors.New("shortened URL already exists")
 	errNotExist   = errors.New("shortened URL does not exist")
 	errDeletedURL = errors.New("URL removed")
+
+	errClicksExhausted = errors.New("URL click limit reached")
 )
 
 // conflict represents an error when a resource already exists.
@@ -1,9 +1,30 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/rycln/shorturl/internal/models"
 	service "github.com/rycln/shorturl/internal/services"
 )
 
+// Transferer moves the whole content of a storage between implementations.
+//
+// Used for migration between storage types and for backups.
+type Transferer interface {
+	// IterateURLPairs calls fn for every URL pair of all users including deleted ones.
+	IterateURLPairs(context.Context, func(models.URLInfo) error) error
+
+	// RestoreURLPairs stores URL pairs with their deletion state skipping
+	// the ones which collide with existing links.
+	RestoreURLPairs(context.Context, []models.URLInfo) ([]bool, error)
+
+	// SnapshotURLPairs calls pairFn for every stored URL pair and then
+	// tombstoneFn for every deletion mark as of a single point in time.
+	SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) error
+}
+
 // Storage is an interface returned by a factory to provide concrete storage implementation.
 //
 // It composes embedded interfaces from service layer and adds an additional Close() method
@@ -15,6 +36,7 @@ type Storage interface {
 	service.PingStorage
 	service.BatchDeleterStorage
 	service.StatsStorage
+	Transferer
 	Close() error
 }
 
@@ -23,7 +45,7 @@ type Storage interface {
 // Supported storage types:
 //   - "db":     database-backed storage (requires configured DB connection)
 //   - "file":   persistent file-based storage
-//   - default:	 application memoory
+//   - default:	 application memoory, loaded from snapshot file if it is configured and exists
 //
 // The factory handles all initialization logic and returns a ready-to-use Storage
 // instance that implements all service interfaces
@@ -38,6 +60,14 @@ func Factory(cfg *StorageConfig) (Storage, error) {
 	case "file":
 		return NewFileStorage(cfg.filePath)
 	default:
-		return NewAppMemStorage(), nil
+		strg := NewAppMemStorage()
+		if cfg.memSnapshotPath == "" {
+			return strg, nil
+		}
+		err := strg.LoadSnapshot(cfg.memSnapshotPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return strg, nil
 	}
 }
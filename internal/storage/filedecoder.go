@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -67,6 +68,43 @@ func (s *FileStorage) getPairByShort(ctx context.Context, short models.ShortURL)
 	}
 }
 
+func (s *FileStorage) getPairsByShorts(ctx context.Context, wanted map[models.ShortURL]struct{}) (pairs map[models.ShortURL]models.URLPair, err error) {
+	s.strgMu.Lock()
+	defer s.strgMu.Unlock()
+
+	fd, err := newFileDecoder(s.strgFileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if decCloseErr := fd.close(); decCloseErr != nil {
+			err = fmt.Errorf("%v; decoder close failed: %w", err, decCloseErr)
+		}
+	}()
+
+	pairs = make(map[models.ShortURL]models.URLPair)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		var pair models.URLPair
+		err = fd.Decode(&pair)
+		if err == io.EOF {
+			return pairs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := wanted[pair.Short]; ok {
+			pairs[pair.Short] = pair
+		}
+	}
+}
+
 func (s *FileStorage) getAllUserPairs(ctx context.Context, uid models.UserID) (userpairs []models.URLPair, err error) {
 	s.strgMu.Lock()
 	defer s.strgMu.Unlock()
@@ -106,6 +144,100 @@ func (s *FileStorage) getAllUserPairs(ctx context.Context, uid models.UserID) (u
 	}
 }
 
+// iteratePairs calls fn for every stored pair in storage order.
+//
+// The storage file is read up to its size at the moment of the call without
+// holding the lock, so fn may take its time. The file is only appended to or
+// atomically replaced, thus the opened snapshot stays consistent.
+func (s *FileStorage) iteratePairs(ctx context.Context, fn func(models.URLPair) error) (err error) {
+	file, size, err := s.openStrgSnapshot()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if fileCloseErr := file.Close(); fileCloseErr != nil {
+			err = fmt.Errorf("%v; file close failed: %w", err, fileCloseErr)
+		}
+	}()
+
+	dec := json.NewDecoder(io.LimitReader(file, size))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var pair models.URLPair
+		err = dec.Decode(&pair)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fn(pair)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// openStrgSnapshot opens the storage file and returns its current size.
+func (s *FileStorage) openStrgSnapshot() (*os.File, int64, error) {
+	s.strgMu.Lock()
+	defer s.strgMu.Unlock()
+
+	file, err := os.Open(s.strgFileName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, errors.Join(err, file.Close())
+	}
+
+	return file, info.Size(), nil
+}
+
+// decodeFile calls fn for every record of the named file.
+// The caller must hold the lock of the file.
+func decodeFile[T any](ctx context.Context, fileName string, fn func(T) error) (err error) {
+	fd, err := newFileDecoder(fileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if decCloseErr := fd.close(); decCloseErr != nil {
+			err = fmt.Errorf("%v; decoder close failed: %w", err, decCloseErr)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var rec T
+		err = fd.Decode(&rec)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fn(rec)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (s *FileStorage) shortIsDeleted(ctx context.Context, short models.ShortURL) (isDeleted bool, err error) {
 	s.delMu.Lock()
 	defer s.delMu.Unlock()
@@ -142,6 +274,45 @@ func (s *FileStorage) shortIsDeleted(ctx context.Context, short models.ShortURL)
 	}
 }
 
+// deletedShorts collects deleted short URLs among wanted ones.
+// Nil wanted collects all deleted short URLs.
+func (s *FileStorage) deletedShorts(ctx context.Context, wanted map[models.ShortURL]struct{}) (deleted map[models.ShortURL]struct{}, err error) {
+	s.delMu.Lock()
+	defer s.delMu.Unlock()
+
+	fd, err := newFileDecoder(s.delFileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if decCloseErr := fd.close(); decCloseErr != nil {
+			err = fmt.Errorf("%v; decoder close failed: %w", err, decCloseErr)
+		}
+	}()
+
+	deleted = make(map[models.ShortURL]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		var req models.DelURLReq
+		err = fd.Decode(&req)
+		if err == io.EOF {
+			return deleted, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := wanted[req.Short]; ok || wanted == nil {
+			deleted[req.Short] = struct{}{}
+		}
+	}
+}
+
 func (s *FileStorage) getStats(ctx context.Context) (stats *models.Stats, err error) {
 	s.strgMu.Lock()
 	defer s.strgMu.Unlock()
@@ -185,3 +356,44 @@ func (s *FileStorage) getStats(ctx context.Context) (stats *models.Stats, err er
 		stats.URLs++
 	}
 }
+
+func (s *FileStorage) countVariantHits(ctx context.Context, short models.ShortURL) (hits map[string]int64, err error) {
+	s.hitsMu.Lock()
+	defer s.hitsMu.Unlock()
+
+	hits = make(map[string]int64)
+
+	fd, err := newFileDecoder(s.hitsFileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return hits, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if decCloseErr := fd.close(); decCloseErr != nil {
+			err = fmt.Errorf("%v; decoder close failed: %w", err, decCloseErr)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		hit := &variantHit{}
+		err = fd.Decode(hit)
+		if err == io.EOF {
+			return hits, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hit.Short == short {
+			hits[hit.Variant]++
+		}
+	}
+}
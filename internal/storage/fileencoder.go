@@ -8,6 +8,12 @@ import (
 	"github.com/rycln/shorturl/internal/models"
 )
 
+// variantHit is a record of a single redirect to multi-destination link variant.
+type variantHit struct {
+	Short   models.ShortURL `json:"short_url"`
+	Variant string          `json:"variant"`
+}
+
 type fileEncoder struct {
 	*json.Encoder
 	file *os.File
@@ -62,3 +68,20 @@ func (s *FileStorage) writeIntoDelFile(delReq *models.DelURLReq) (err error) {
 
 	return enc.Encode(delReq)
 }
+
+func (s *FileStorage) writeIntoHitsFile(hit *variantHit) (err error) {
+	s.hitsMu.Lock()
+	defer s.hitsMu.Unlock()
+
+	enc, err := newFileEncoder(s.hitsFileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if encCloseErr := enc.close(); encCloseErr != nil {
+			err = fmt.Errorf("%v; encoder close failed: %w", err, encCloseErr)
+		}
+	}()
+
+	return enc.Encode(hit)
+}
@@ -14,20 +14,24 @@ import (
 type FileStorage struct {
 	strgFileName string
 	delFileName  string
+	hitsFileName string
 	strgMu       sync.Mutex
 	delMu        sync.Mutex
+	hitsMu       sync.Mutex
 }
 
 // NewFileStorage creates a new FileStorage instance.
+//
+// Existing storage files are kept, missing ones are created.
 func NewFileStorage(fileName string) (*FileStorage, error) {
-	_, err := os.Create(fileName)
+	err := touchFile(fileName)
 	if err != nil {
 		return nil, err
 	}
 
 	delFileName := fileName + "_deleted"
 
-	_, err = os.Create(delFileName)
+	err = touchFile(delFileName)
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +39,19 @@ func NewFileStorage(fileName string) (*FileStorage, error) {
 	return &FileStorage{
 		strgFileName: fileName,
 		delFileName:  delFileName,
+		hitsFileName: fileName + "_hits",
 	}, nil
 }
 
+// touchFile creates the file if it does not exist.
+func touchFile(name string) error {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
 // AddURLPair stores a new URL pair in the file storage.
 func (s *FileStorage) AddURLPair(ctx context.Context, pair *models.URLPair) error {
 	_, err := s.getPairByShort(ctx, pair.Short)
@@ -71,6 +85,59 @@ func (s *FileStorage) GetURLPairByShort(ctx context.Context, short models.ShortU
 	return pair, nil
 }
 
+// LookupURLPair retrieves a URL pair by its short URL including deleted ones.
+//
+// Reports whether the pair was deleted.
+func (s *FileStorage) LookupURLPair(ctx context.Context, short models.ShortURL) (*models.URLPair, bool, error) {
+	pair, err := s.getPairByShort(ctx, short)
+	if errors.Is(err, errNotExist) {
+		return nil, false, newErrNotExist(errNotExist)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	deleted, err := s.shortIsDeleted(ctx, short)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pair, deleted, nil
+}
+
+// LookupURLPairBatch retrieves URL pairs by their short URLs including deleted ones
+// reading each storage file once.
+//
+// Unknown short URLs are absent from the result. Status of the found pairs
+// is either active or deleted, schedule is not taken into account.
+func (s *FileStorage) LookupURLPairBatch(ctx context.Context, shorts []models.ShortURL) (map[models.ShortURL]models.URLInfo, error) {
+	wanted := make(map[models.ShortURL]struct{}, len(shorts))
+	for _, short := range shorts {
+		wanted[short] = struct{}{}
+	}
+
+	pairs, err := s.getPairsByShorts(ctx, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.deletedShorts(ctx, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make(map[models.ShortURL]models.URLInfo, len(pairs))
+	for short, pair := range pairs {
+		info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+		if _, ok := deleted[short]; ok {
+			info.Status = models.StatusDeleted
+		}
+		infos[short] = info
+	}
+
+	return infos, nil
+}
+
 // AddBatchURLPairs stores multiple URL pairs in a single file operation.
 func (s *FileStorage) AddBatchURLPairs(ctx context.Context, pairs []models.URLPair) error {
 	for _, pair := range pairs {
@@ -88,11 +155,239 @@ func (s *FileStorage) AddBatchURLPairs(ctx context.Context, pairs []models.URLPa
 	return nil
 }
 
+// ImportURLPairs stores multiple URL pairs skipping the ones whose
+// short URL is already taken.
+//
+// Existing short URLs are looked up in a single pass over the file.
+// Reports for each pair whether it was skipped as a conflict.
+func (s *FileStorage) ImportURLPairs(ctx context.Context, pairs []models.URLPair) ([]bool, error) {
+	wanted := make(map[models.ShortURL]struct{}, len(pairs))
+	for _, pair := range pairs {
+		wanted[pair.Short] = struct{}{}
+	}
+
+	existing, err := s.getPairsByShorts(ctx, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[models.ShortURL]struct{}, len(existing)+len(pairs))
+	for short := range existing {
+		taken[short] = struct{}{}
+	}
+
+	conflicts := make([]bool, len(pairs))
+	for i, pair := range pairs {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if _, ok := taken[pair.Short]; ok {
+			conflicts[i] = true
+			continue
+		}
+
+		err := s.writeIntoStrgFile(&pair)
+		if err != nil {
+			return nil, err
+		}
+		taken[pair.Short] = struct{}{}
+	}
+
+	return conflicts, nil
+}
+
 // GetURLPairBatchByUserID retrieves all URL pairs created by a specific user.
 func (s *FileStorage) GetURLPairBatchByUserID(ctx context.Context, uid models.UserID) ([]models.URLPair, error) {
 	return s.getAllUserPairs(ctx, uid)
 }
 
+// IterateURLPairsByUserID calls fn for every URL pair of the user including
+// deleted ones in storage order. Pairs are read from the file as fn
+// consumes them. Iteration stops at the first error returned by fn.
+//
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *FileStorage) IterateURLPairsByUserID(ctx context.Context, uid models.UserID, fn func(models.URLInfo) error) error {
+	deleted, err := s.deletedShorts(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.iteratePairs(ctx, func(pair models.URLPair) error {
+		if pair.UID != uid {
+			return nil
+		}
+		info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+		if _, ok := deleted[pair.Short]; ok {
+			info.Status = models.StatusDeleted
+		}
+		return fn(info)
+	})
+}
+
+// IterateURLPairs calls fn for every URL pair of all users including
+// deleted ones in storage order. Pairs are read from the file as fn
+// consumes them. Iteration stops at the first error returned by fn.
+//
+// Status of the pairs is either active or deleted, schedule is not taken into account.
+func (s *FileStorage) IterateURLPairs(ctx context.Context, fn func(models.URLInfo) error) error {
+	deleted, err := s.deletedShorts(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.iteratePairs(ctx, func(pair models.URLPair) error {
+		info := models.URLInfo{URLPair: pair, Status: models.StatusActive}
+		if _, ok := deleted[pair.Short]; ok {
+			info.Status = models.StatusDeleted
+		}
+		return fn(info)
+	})
+}
+
+// SnapshotURLPairs calls pairFn for every URL pair in storage order and then
+// tombstoneFn for every deletion mark.
+//
+// Storage and deletion files are locked for the whole snapshot, so it
+// reflects a single point in time. Writers wait until the callbacks finish.
+func (s *FileStorage) SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) error {
+	s.strgMu.Lock()
+	defer s.strgMu.Unlock()
+	s.delMu.Lock()
+	defer s.delMu.Unlock()
+
+	err := decodeFile(ctx, s.strgFileName, pairFn)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[models.ShortURL]struct{})
+	return decodeFile(ctx, s.delFileName, func(req models.DelURLReq) error {
+		if _, ok := seen[req.Short]; ok {
+			return nil
+		}
+		seen[req.Short] = struct{}{}
+		return tombstoneFn(req)
+	})
+}
+
+// RestoreURLPairs stores URL pairs with all their attributes and deletion
+// state skipping the ones which collide with existing links.
+//
+// Existing short URLs are looked up in a single pass over the file.
+// Reports for each pair whether it was skipped as a conflict.
+func (s *FileStorage) RestoreURLPairs(ctx context.Context, infos []models.URLInfo) ([]bool, error) {
+	pairs := make([]models.URLPair, len(infos))
+	for i, info := range infos {
+		pairs[i] = info.URLPair
+	}
+
+	conflicts, err := s.ImportURLPairs(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, info := range infos {
+		if conflicts[i] || info.Status != models.StatusDeleted {
+			continue
+		}
+
+		err := s.writeIntoDelFile(&models.DelURLReq{UID: info.UID, Short: info.Short})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return conflicts, nil
+}
+
+// UpdateURLOptions replaces settings of the URL pair owned by user
+// with the result of update applied to the stored ones.
+//
+// The storage file stays locked while update runs.
+func (s *FileStorage) UpdateURLOptions(ctx context.Context, uid models.UserID, short models.ShortURL, update func(*models.LinkOptions) *models.LinkOptions) error {
+	return s.updateUserPair(ctx, uid, short, func(pair *models.URLPair) {
+		pair.Options = update(pair.Options)
+	})
+}
+
+// SetClickLimit sets number of redirects left for the URL pair owned by user.
+func (s *FileStorage) SetClickLimit(ctx context.Context, uid models.UserID, short models.ShortURL, limit *int64) error {
+	return s.updateUserPair(ctx, uid, short, func(pair *models.URLPair) {
+		pair.ClicksLeft = limit
+	})
+}
+
+// ConsumeClick atomically takes one redirect of the limited link.
+//
+// The counter is checked and decremented while the storage file is locked.
+// The link is marked as deleted when the last redirect is taken.
+func (s *FileStorage) ConsumeClick(ctx context.Context, short models.ShortURL) error {
+	deleted, err := s.shortIsDeleted(ctx, short)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return newErrDeletedURL(errDeletedURL)
+	}
+
+	var last *models.DelURLReq
+	err = s.updatePair(ctx, short,
+		func(*models.URLPair) bool {
+			return true
+		},
+		func(pair *models.URLPair) error {
+			if pair.ClicksLeft == nil {
+				return nil
+			}
+			if *pair.ClicksLeft <= 0 {
+				return newErrDeletedURL(errClicksExhausted)
+			}
+
+			left := *pair.ClicksLeft - 1
+			pair.ClicksLeft = &left
+			if left == 0 {
+				last = &models.DelURLReq{
+					UID:   pair.UID,
+					Short: pair.Short,
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	if last != nil {
+		return s.writeIntoDelFile(last)
+	}
+
+	return nil
+}
+
+// AddVariantHit increments redirect counter of the link variant.
+//
+// Hits are appended into a separate file which is created on first use.
+func (s *FileStorage) AddVariantHit(ctx context.Context, short models.ShortURL, variant string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return s.writeIntoHitsFile(&variantHit{
+		Short:   short,
+		Variant: variant,
+	})
+}
+
+// GetVariantHits returns redirect counters of all link variants.
+func (s *FileStorage) GetVariantHits(ctx context.Context, short models.ShortURL) (map[string]int64, error) {
+	return s.countVariantHits(ctx, short)
+}
+
 // DeleteRequestedURLs marks URLs as deleted in a batch operation.
 // Implements soft deletion - URLs remain in storage but are marked as deleted.
 func (s *FileStorage) DeleteRequestedURLs(ctx context.Context, delurls []*models.DelURLReq) error {
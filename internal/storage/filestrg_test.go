@@ -5,13 +5,41 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rycln/shorturl/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestNewFileStorage(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "storage.json")
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+
+	strg, err := NewFileStorage(name)
+	require.NoError(t, err)
+	err = strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, deletedPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	// Reopening keeps links and deletion marks written before.
+	strg, err = NewFileStorage(name)
+	require.NoError(t, err)
+
+	pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+	require.NoError(t, err)
+	assert.Equal(t, testPair, *pair)
+
+	_, deleted, err := strg.LookupURLPair(context.Background(), testDeletedShort)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+}
+
 func TestFileStorage_AddURLPair(t *testing.T) {
 	strg, err := NewFileStorage(testFileName)
 	require.NoError(t, err)
@@ -562,6 +590,440 @@ func BenchmarkFileStorage_GetURLPairBatchByUserID(b *testing.B) {
 	})
 }
 
+func TestFileStorage_UpdateURLOptions(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	err = strg.AddURLPair(context.Background(), &testPair)
+	require.NoError(t, err)
+
+	opts := &models.LinkOptions{
+		Passthrough: &models.Passthrough{Path: true},
+	}
+
+	set := func(*models.LinkOptions) *models.LinkOptions {
+		return opts
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, set)
+		assert.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		assert.Equal(t, opts, pair.Options)
+	})
+
+	t.Run("update sees stored settings", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testUserID, testShortURL, func(stored *models.LinkOptions) *models.LinkOptions {
+			assert.Equal(t, opts, stored)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		assert.Nil(t, pair.Options)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		err := strg.UpdateURLOptions(context.Background(), testOtherUserID, testShortURL, set)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := strg.UpdateURLOptions(ctx, testUserID, testShortURL, set)
+		assert.Error(t, err)
+	})
+}
+
+func TestFileStorage_ClickLimit(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	err = strg.AddURLPair(context.Background(), &testPair)
+	require.NoError(t, err)
+
+	limit := int64(5)
+
+	t.Run("other user", func(t *testing.T) {
+		err := strg.SetClickLimit(context.Background(), testOtherUserID, testShortURL, &limit)
+		assert.ErrorIs(t, err, errNotExist)
+	})
+
+	t.Run("unlimited link", func(t *testing.T) {
+		err := strg.ConsumeClick(context.Background(), testShortURL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrent burst", func(t *testing.T) {
+		err := strg.SetClickLimit(context.Background(), testUserID, testShortURL, &limit)
+		require.NoError(t, err)
+
+		pair, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+		require.NoError(t, err)
+		require.NotNil(t, pair.ClicksLeft)
+		assert.Equal(t, limit, *pair.ClicksLeft)
+
+		assert.Equal(t, limit, consumeClicksConcurrently(strg.ConsumeClick, 20))
+
+		_, err = strg.GetURLPairByShort(context.Background(), testShortURL)
+		assert.ErrorIs(t, err, errDeletedURL)
+	})
+}
+
+func TestFileStorage_Schedule(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	pair := testPair
+	pair.Schedule = models.Schedule{ActiveFrom: &from, ExpiresAt: &to}
+
+	err = strg.AddURLPair(context.Background(), &pair)
+	require.NoError(t, err)
+
+	got, err := strg.GetURLPairByShort(context.Background(), testShortURL)
+	require.NoError(t, err)
+	require.NotNil(t, got.ActiveFrom)
+	require.NotNil(t, got.ExpiresAt)
+	assert.True(t, from.Equal(*got.ActiveFrom))
+	assert.True(t, to.Equal(*got.ExpiresAt))
+}
+
+func TestFileStorage_LookupURLPair(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	err = strg.AddURLPair(context.Background(), &testPair)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		pair, deleted, err := strg.LookupURLPair(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Equal(t, testPair, *pair)
+	})
+
+	t.Run("deleted url", func(t *testing.T) {
+		err := strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{{UID: testUserID, Short: testShortURL}})
+		require.NoError(t, err)
+
+		pair, deleted, err := strg.LookupURLPair(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, testPair, *pair)
+	})
+
+	t.Run("not exist error", func(t *testing.T) {
+		_, _, err := strg.LookupURLPair(context.Background(), models.ShortURL("not exist"))
+		assert.ErrorIs(t, err, errNotExist)
+		e, ok := err.(interface{ IsErrNotExist() bool })
+		require.True(t, ok)
+		assert.True(t, e.IsErrNotExist())
+	})
+}
+
+func TestFileStorage_LookupURLPairBatch(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	deletedPair.Orig = "https://deleted.example/"
+
+	err = strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, deletedPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	infos, err := strg.LookupURLPairBatch(context.Background(), []models.ShortURL{testShortURL, testDeletedShort, "not exist"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[models.ShortURL]models.URLInfo{
+		testShortURL:     {URLPair: testPair, Status: models.StatusActive},
+		testDeletedShort: {URLPair: deletedPair, Status: models.StatusDeleted},
+	}, infos)
+}
+
+func TestFileStorage_ImportURLPairs(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+
+	err = strg.AddURLPair(context.Background(), &testPair)
+	require.NoError(t, err)
+
+	custom := testPair
+	custom.Short = "custom"
+	custom.Tags = []string{"promo"}
+
+	conflicts, err := strg.ImportURLPairs(context.Background(), []models.URLPair{testPair, custom, custom})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, conflicts)
+
+	pairs, err := strg.GetURLPairBatchByUserID(context.Background(), testUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLPair{testPair, custom}, pairs)
+}
+
+func TestFileStorage_IterateURLPairsByUserID(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := testPair
+	otherPair.UID = testOtherUserID
+	otherPair.Short = "other"
+
+	err = strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, otherPair, deletedPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	var infos []models.URLInfo
+	err = strg.IterateURLPairsByUserID(context.Background(), testUserID, func(info models.URLInfo) error {
+		// the storage stays writable while the export is in progress
+		return strg.AddURLPair(context.Background(), &models.URLPair{UID: testUserID, Short: "new" + info.Short, Orig: testOrigURL})
+	})
+	require.NoError(t, err)
+
+	err = strg.IterateURLPairsByUserID(context.Background(), testUserID, func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	assert.NoError(t, err)
+	require.Len(t, infos, 4)
+	assert.Equal(t, models.URLInfo{URLPair: testPair, Status: models.StatusActive}, infos[0])
+	assert.Equal(t, models.URLInfo{URLPair: deletedPair, Status: models.StatusDeleted}, infos[1])
+	assert.Equal(t, models.ShortURL("new"+testShortURL), infos[2].Short)
+}
+
+func TestFileStorage_IterateURLPairs(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := testPair
+	otherPair.UID = testOtherUserID
+	otherPair.Short = "other"
+
+	err = strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, otherPair, deletedPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	var infos []models.URLInfo
+	err = strg.IterateURLPairs(context.Background(), func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.URLInfo{
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: otherPair, Status: models.StatusActive},
+		{URLPair: deletedPair, Status: models.StatusDeleted},
+	}, infos)
+
+	t.Run("callback error", func(t *testing.T) {
+		err := strg.IterateURLPairs(context.Background(), func(models.URLInfo) error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestFileStorage_SnapshotURLPairs(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+
+	err = strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, deletedPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq, &testDelReq})
+	require.NoError(t, err)
+
+	var pairs []models.URLPair
+	var tombstones []models.DelURLReq
+	added := make(chan error)
+	err = strg.SnapshotURLPairs(context.Background(),
+		func(pair models.URLPair) error {
+			if len(pairs) == 0 {
+				go func() {
+					added <- strg.AddURLPair(context.Background(), &models.URLPair{UID: testUserID, Short: "new", Orig: testOrigURL})
+				}()
+			}
+			pairs = append(pairs, pair)
+			return nil
+		},
+		func(req models.DelURLReq) error {
+			tombstones = append(tombstones, req)
+			return nil
+		})
+	assert.NoError(t, err)
+	// the pair added concurrently waits for the snapshot to finish
+	assert.Equal(t, []models.URLPair{testPair, deletedPair}, pairs)
+	assert.Equal(t, []models.DelURLReq{testDelReq}, tombstones)
+	assert.NoError(t, <-added)
+
+	t.Run("callback error", func(t *testing.T) {
+		err := strg.SnapshotURLPairs(context.Background(),
+			func(models.URLPair) error { return errTest },
+			func(models.DelURLReq) error { return nil })
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestFileStorage_RestoreURLPairs(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	err = strg.AddURLPair(context.Background(), &testPair)
+	require.NoError(t, err)
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	otherPair := testPair
+	otherPair.UID = testOtherUserID
+	otherPair.Short = "other"
+
+	conflicts, err := strg.RestoreURLPairs(context.Background(), []models.URLInfo{
+		{URLPair: testPair, Status: models.StatusActive},
+		{URLPair: deletedPair, Status: models.StatusDeleted},
+		{URLPair: otherPair, Status: models.StatusActive},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, conflicts)
+
+	infos, err := strg.LookupURLPairBatch(context.Background(), []models.ShortURL{testDeletedShort, "other"})
+	require.NoError(t, err)
+	assert.Equal(t, models.URLInfo{URLPair: deletedPair, Status: models.StatusDeleted}, infos[testDeletedShort])
+	assert.Equal(t, models.URLInfo{URLPair: otherPair, Status: models.StatusActive}, infos["other"])
+}
+
+func TestFileStorage_VariantHits(t *testing.T) {
+	strg, err := NewFileStorage(testFileName)
+	require.NoError(t, err)
+	defer func() {
+		err = os.Remove(strg.strgFileName)
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = os.Remove(strg.delFileName)
+		require.NoError(t, err)
+	}()
+
+	t.Run("no hits file", func(t *testing.T) {
+		hits, err := strg.GetVariantHits(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("valid test", func(t *testing.T) {
+		defer func() {
+			err = os.Remove(strg.hitsFileName)
+			require.NoError(t, err)
+		}()
+
+		for _, variant := range []string{"a", "b", "a"} {
+			err := strg.AddVariantHit(context.Background(), testShortURL, variant)
+			require.NoError(t, err)
+		}
+		err := strg.AddVariantHit(context.Background(), "other", "a")
+		require.NoError(t, err)
+
+		hits, err := strg.GetVariantHits(context.Background(), testShortURL)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"a": 2, "b": 1}, hits)
+	})
+
+	t.Run("ctx expired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := strg.AddVariantHit(ctx, testShortURL, "a")
+		assert.Error(t, err)
+	})
+}
+
 func TestFileStorage_DeleteRequestedURLs(t *testing.T) {
 	strg, err := NewFileStorage(testFileName)
 	require.NoError(t, err)
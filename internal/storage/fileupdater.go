@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// updateUserPair rewrites storage file applying update to the pair owned by user.
+func (s *FileStorage) updateUserPair(ctx context.Context, uid models.UserID, short models.ShortURL, update func(*models.URLPair)) error {
+	return s.updatePair(ctx, short,
+		func(pair *models.URLPair) bool {
+			return pair.UID == uid
+		},
+		func(pair *models.URLPair) error {
+			update(pair)
+			return nil
+		})
+}
+
+// updatePair rewrites storage file applying update to the pair with short URL
+// accepted by match. If update fails the storage file is left untouched.
+//
+// The new content is written into a temporary file which then atomically
+// replaces the storage file.
+func (s *FileStorage) updatePair(ctx context.Context, short models.ShortURL, match func(*models.URLPair) bool, update func(*models.URLPair) error) (err error) {
+	s.strgMu.Lock()
+	defer s.strgMu.Unlock()
+
+	fd, err := newFileDecoder(s.strgFileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if decCloseErr := fd.close(); decCloseErr != nil {
+			err = fmt.Errorf("%v; decoder close failed: %w", err, decCloseErr)
+		}
+	}()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.strgFileName), filepath.Base(s.strgFileName)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if tmpCloseErr := tmp.Close(); tmpCloseErr != nil && !errors.Is(tmpCloseErr, os.ErrClosed) {
+			err = fmt.Errorf("%v; temp file close failed: %w", err, tmpCloseErr)
+		}
+		if removeErr := os.Remove(tmp.Name()); removeErr != nil {
+			err = fmt.Errorf("%v; temp file remove failed: %w", err, removeErr)
+		}
+	}()
+
+	enc := json.NewEncoder(tmp)
+
+	var found bool
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		pair := &models.URLPair{}
+		err = fd.Decode(pair)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if !found && pair.Short == short && match(pair) {
+			err = update(pair)
+			if err != nil {
+				return err
+			}
+			found = true
+		}
+
+		err = enc.Encode(pair)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return newErrNotExist(errNotExist)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.strgFileName)
+}
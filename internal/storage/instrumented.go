@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/rycln/shorturl/internal/logger"
+	"github.com/rycln/shorturl/internal/metrics"
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Backend names labelling storage metrics.
+const (
+	backendDB     = "db"
+	backendFile   = "file"
+	backendMemory = "memory"
+)
+
+// instrumentedStorage records metrics and traces of the wrapped storage operations.
+type instrumentedStorage struct {
+	strg    Storage
+	backend string
+}
+
+// Instrument wraps the storage to record latency and errors of its operations
+// labelled by backend and to trace every operation. Connection pool statistics
+// are recorded for database storage.
+//
+// Optional capabilities of the wrapped storage, like snapshots, are not available
+// through the wrapper, so they should be looked up before wrapping.
+func Instrument(strg Storage) Storage {
+	backend := backendMemory
+	switch s := strg.(type) {
+	case *DatabaseStorage:
+		backend = backendDB
+		err := metrics.Registry.Register(collectors.NewDBStatsCollector(s.db, "shorturl"))
+		if err != nil {
+			logger.Log.Info("Cannot register database pool metrics", zap.Error(err))
+		}
+	case *FileStorage:
+		backend = backendFile
+	}
+
+	return &instrumentedStorage{
+		strg:    strg,
+		backend: backend,
+	}
+}
+
+// operation is a storage operation being recorded.
+type operation struct {
+	name    string
+	backend string
+	start   time.Time
+	span    trace.Span
+	log     *zap.Logger
+}
+
+// begin starts recording the operation, the returned context carries its span.
+// Failures are logged by the request-scoped logger of ctx.
+func (s *instrumentedStorage) begin(ctx context.Context, name string) (context.Context, *operation) {
+	ctx, span := tracing.Start(ctx, "storage."+name, attribute.String("storage.backend", s.backend))
+	return ctx, &operation{
+		name:    name,
+		backend: s.backend,
+		start:   time.Now(),
+		span:    span,
+		log:     logger.FromContext(ctx),
+	}
+}
+
+// end records the operation finished with err.
+//
+// Errors reporting a missing, deleted or conflicting link are results
+// of the operation rather than storage failures.
+func (op *operation) end(err error) {
+	defer op.span.End()
+
+	metrics.StorageDuration.WithLabelValues(op.backend, op.name).Observe(time.Since(op.start).Seconds())
+
+	if err == nil ||
+		errors.Is(err, errNotExist) ||
+		errors.Is(err, errDeletedURL) ||
+		errors.Is(err, errConflict) ||
+		errors.Is(err, errClicksExhausted) ||
+		errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	metrics.StorageErrors.WithLabelValues(op.backend, op.name).Inc()
+	tracing.Fail(op.span, err)
+	op.log.Debug("Storage operation failed",
+		zap.String("backend", op.backend),
+		zap.String("operation", op.name),
+		zap.Error(err),
+	)
+}
+
+func (s *instrumentedStorage) AddURLPair(ctx context.Context, pair *models.URLPair) error {
+	ctx, op := s.begin(ctx, "add_url_pair")
+	err := s.strg.AddURLPair(ctx, pair)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) GetURLPairByShort(ctx context.Context, short models.ShortURL) (*models.URLPair, error) {
+	ctx, op := s.begin(ctx, "get_url_pair")
+	pair, err := s.strg.GetURLPairByShort(ctx, short)
+	op.end(err)
+	return pair, err
+}
+
+func (s *instrumentedStorage) LookupURLPair(ctx context.Context, short models.ShortURL) (*models.URLPair, bool, error) {
+	ctx, op := s.begin(ctx, "lookup_url_pair")
+	pair, deleted, err := s.strg.LookupURLPair(ctx, short)
+	op.end(err)
+	return pair, deleted, err
+}
+
+func (s *instrumentedStorage) LookupURLPairBatch(ctx context.Context, shorts []models.ShortURL) (map[models.ShortURL]models.URLInfo, error) {
+	ctx, op := s.begin(ctx, "lookup_url_pair_batch")
+	infos, err := s.strg.LookupURLPairBatch(ctx, shorts)
+	op.end(err)
+	return infos, err
+}
+
+func (s *instrumentedStorage) UpdateURLOptions(ctx context.Context, uid models.UserID, short models.ShortURL, update func(*models.LinkOptions) *models.LinkOptions) error {
+	ctx, op := s.begin(ctx, "update_url_options")
+	err := s.strg.UpdateURLOptions(ctx, uid, short, update)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) AddVariantHit(ctx context.Context, short models.ShortURL, variant string) error {
+	ctx, op := s.begin(ctx, "add_variant_hit")
+	err := s.strg.AddVariantHit(ctx, short, variant)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) GetVariantHits(ctx context.Context, short models.ShortURL) (map[string]int64, error) {
+	ctx, op := s.begin(ctx, "get_variant_hits")
+	hits, err := s.strg.GetVariantHits(ctx, short)
+	op.end(err)
+	return hits, err
+}
+
+func (s *instrumentedStorage) SetClickLimit(ctx context.Context, uid models.UserID, short models.ShortURL, limit *int64) error {
+	ctx, op := s.begin(ctx, "set_click_limit")
+	err := s.strg.SetClickLimit(ctx, uid, short, limit)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) ConsumeClick(ctx context.Context, short models.ShortURL) error {
+	ctx, op := s.begin(ctx, "consume_click")
+	err := s.strg.ConsumeClick(ctx, short)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) AddBatchURLPairs(ctx context.Context, pairs []models.URLPair) error {
+	ctx, op := s.begin(ctx, "add_url_pair_batch")
+	err := s.strg.AddBatchURLPairs(ctx, pairs)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) GetURLPairBatchByUserID(ctx context.Context, uid models.UserID) ([]models.URLPair, error) {
+	ctx, op := s.begin(ctx, "get_user_url_pairs")
+	pairs, err := s.strg.GetURLPairBatchByUserID(ctx, uid)
+	op.end(err)
+	return pairs, err
+}
+
+func (s *instrumentedStorage) ImportURLPairs(ctx context.Context, pairs []models.URLPair) ([]bool, error) {
+	ctx, op := s.begin(ctx, "import_url_pairs")
+	conflicts, err := s.strg.ImportURLPairs(ctx, pairs)
+	op.end(err)
+	return conflicts, err
+}
+
+func (s *instrumentedStorage) IterateURLPairsByUserID(ctx context.Context, uid models.UserID, fn func(models.URLInfo) error) error {
+	ctx, op := s.begin(ctx, "iterate_user_url_pairs")
+	err := s.strg.IterateURLPairsByUserID(ctx, uid, fn)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) DeleteRequestedURLs(ctx context.Context, delurls []*models.DelURLReq) error {
+	ctx, op := s.begin(ctx, "delete_urls")
+	err := s.strg.DeleteRequestedURLs(ctx, delurls)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) GetStats(ctx context.Context) (*models.Stats, error) {
+	ctx, op := s.begin(ctx, "get_stats")
+	stats, err := s.strg.GetStats(ctx)
+	op.end(err)
+	return stats, err
+}
+
+func (s *instrumentedStorage) Ping(ctx context.Context) error {
+	ctx, op := s.begin(ctx, "ping")
+	err := s.strg.Ping(ctx)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) IterateURLPairs(ctx context.Context, fn func(models.URLInfo) error) error {
+	ctx, op := s.begin(ctx, "iterate_url_pairs")
+	err := s.strg.IterateURLPairs(ctx, fn)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) RestoreURLPairs(ctx context.Context, infos []models.URLInfo) ([]bool, error) {
+	ctx, op := s.begin(ctx, "restore_url_pairs")
+	conflicts, err := s.strg.RestoreURLPairs(ctx, infos)
+	op.end(err)
+	return conflicts, err
+}
+
+func (s *instrumentedStorage) SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) error {
+	ctx, op := s.begin(ctx, "snapshot_url_pairs")
+	err := s.strg.SnapshotURLPairs(ctx, pairFn, tombstoneFn)
+	op.end(err)
+	return err
+}
+
+func (s *instrumentedStorage) Close() error {
+	return s.strg.Close()
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rycln/shorturl/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrument(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+
+	t.Run("expected errors", func(t *testing.T) {
+		exp.Reset()
+		strg := Instrument(NewAppMemStorage())
+
+		errCounter := metrics.StorageErrors.WithLabelValues(backendMemory, "get_url_pair")
+		errsBefore := testutil.ToFloat64(errCounter)
+
+		// A missing link is a result rather than a storage failure.
+		_, err := strg.GetURLPairByShort(context.Background(), testPair.Short)
+		assert.Error(t, err)
+
+		assert.Equal(t, errsBefore, testutil.ToFloat64(errCounter))
+
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "storage.get_url_pair", spans[0].Name)
+		assert.Contains(t, spans[0].Attributes, attribute.String("storage.backend", backendMemory))
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		exp.Reset()
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+
+		strg := Instrument(NewDatabaseStorage(db))
+
+		errCounter := metrics.StorageErrors.WithLabelValues(backendDB, "ping")
+		before := testutil.ToFloat64(errCounter)
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+		err = strg.Ping(context.Background())
+		assert.Error(t, err)
+
+		assert.Equal(t, before+1, testutil.ToFloat64(errCounter))
+
+		spans := exp.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "storage.ping", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+		mock.ExpectClose()
+		require.NoError(t, strg.Close())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
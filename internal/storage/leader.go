@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"sync"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"go.uber.org/zap"
+)
+
+// LeaderElector elects a single instance to run singleton background jobs.
+type LeaderElector interface {
+	// IsLeader reports whether the instance holds leadership
+	// trying to acquire it first if it does not.
+	IsLeader(context.Context) (bool, error)
+	// Resign gives leadership up, so another instance may take it over.
+	Resign() error
+}
+
+// NewLeaderElector creates a leader elector for instances sharing the storage.
+//
+// Instances sharing a database elect a leader with a PostgreSQL advisory lock
+// named by the given name. Memory and file storages are not shared, so the only
+// instance is always the leader.
+func NewLeaderElector(strg Storage, name string) LeaderElector {
+	if s, ok := strg.(*DatabaseStorage); ok {
+		return newDBLeaderElector(s.db, name)
+	}
+	return localLeaderElector{}
+}
+
+// localLeaderElector is a leader elector of a single instance.
+type localLeaderElector struct{}
+
+// IsLeader always reports leadership.
+func (localLeaderElector) IsLeader(context.Context) (bool, error) {
+	return true, nil
+}
+
+// Resign does nothing.
+func (localLeaderElector) Resign() error {
+	return nil
+}
+
+// DBLeaderElector elects a leader with a session-level PostgreSQL advisory lock.
+//
+// The leader keeps the lock on a dedicated connection. When the leader dies
+// its session ends and the lock is released, so the next instance asking
+// for leadership takes it over. The leader which lost its connection
+// steps down.
+type DBLeaderElector struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// newDBLeaderElector creates a leader elector locking a key derived from the name.
+func newDBLeaderElector(db *sql.DB, name string) *DBLeaderElector {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+
+	return &DBLeaderElector{
+		db:  db,
+		key: int64(h.Sum64()),
+	}
+}
+
+// IsLeader reports whether the instance holds the lock trying to acquire it
+// if it does not.
+func (e *DBLeaderElector) IsLeader(ctx context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		err := e.conn.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+		// The session is gone and the lock with it.
+		discardConn(e.conn)
+		e.conn = nil
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, sqlTryAdvisoryLock, e.key).Scan(&locked)
+	if err != nil || !locked {
+		if connCloseErr := conn.Close(); connCloseErr != nil {
+			logger.FromContext(ctx).Info("Cannot close leader election connection", zap.Error(connCloseErr))
+		}
+		return false, err
+	}
+
+	e.conn = conn
+	return true, nil
+}
+
+// Resign releases the lock if the instance holds it.
+func (e *DBLeaderElector) Resign() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	var unlocked bool
+	err := e.conn.QueryRowContext(context.Background(), sqlAdvisoryUnlock, e.key).Scan(&unlocked)
+	if err != nil {
+		// Ending the session releases the lock anyway.
+		discardConn(e.conn)
+		e.conn = nil
+		return err
+	}
+
+	err = e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// discardConn closes the underlying connection instead of returning it
+// to the pool, so the database session ends and releases its locks.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLeaderElector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() {
+		mock.ExpectClose()
+		require.NoError(t, db.Close())
+	}()
+
+	assert.IsType(t, &DBLeaderElector{}, NewLeaderElector(NewDatabaseStorage(db), "test"))
+
+	// Storages of a single instance are always led by it.
+	e := NewLeaderElector(NewAppMemStorage(), "test")
+	leading, err := e.IsLeader(context.Background())
+	require.NoError(t, err)
+	assert.True(t, leading)
+	assert.NoError(t, e.Resign())
+}
+
+func TestDBLeaderElector(t *testing.T) {
+	lockQuery := regexp.QuoteMeta(sqlTryAdvisoryLock)
+	unlockQuery := regexp.QuoteMeta(sqlAdvisoryUnlock)
+
+	setup := func(t *testing.T) (*DBLeaderElector, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			mock.ExpectClose()
+			require.NoError(t, db.Close())
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+
+		return newDBLeaderElector(db, "test"), mock
+	}
+
+	t.Run("follower", func(t *testing.T) {
+		e, mock := setup(t)
+
+		mock.ExpectQuery(lockQuery).WithArgs(e.key).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+		leading, err := e.IsLeader(context.Background())
+		require.NoError(t, err)
+		assert.False(t, leading)
+
+		// A follower has nothing to give up.
+		assert.NoError(t, e.Resign())
+	})
+
+	t.Run("leader keeps leadership", func(t *testing.T) {
+		e, mock := setup(t)
+
+		mock.ExpectQuery(lockQuery).WithArgs(e.key).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+		mock.ExpectPing()
+		mock.ExpectQuery(unlockQuery).WithArgs(e.key).WillReturnRows(sqlmock.NewRows([]string{"unlocked"}).AddRow(true))
+
+		for i := 0; i < 2; i++ {
+			leading, err := e.IsLeader(context.Background())
+			require.NoError(t, err)
+			assert.True(t, leading)
+		}
+
+		assert.NoError(t, e.Resign())
+	})
+
+	t.Run("connection lost", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		e := newDBLeaderElector(db, "test")
+
+		mock.ExpectQuery(lockQuery).WithArgs(e.key).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+		mock.ExpectPing().WillReturnError(errors.New("connection reset"))
+		// The broken connection is closed rather than returned to the pool.
+		mock.ExpectClose()
+
+		leading, err := e.IsLeader(context.Background())
+		require.NoError(t, err)
+		assert.True(t, leading)
+
+		// The mock database cannot reconnect, so leadership is not regained.
+		leading, err = e.IsLeader(context.Background())
+		assert.Error(t, err)
+		assert.False(t, leading)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		e, mock := setup(t)
+
+		mock.ExpectQuery(lockQuery).WithArgs(e.key).WillReturnError(errors.New("test error"))
+
+		leading, err := e.IsLeader(context.Background())
+		assert.Error(t, err)
+		assert.False(t, leading)
+	})
+}
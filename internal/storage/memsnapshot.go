@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// memSnapshotVersion is the version of the memory snapshot format.
+// It is bumped whenever the format changes incompatibly.
+const memSnapshotVersion = 1
+
+// errSnapshotVersion is returned when a snapshot of unknown format is loaded.
+var errSnapshotVersion = errors.New("unsupported snapshot version")
+
+// memSnapshot is the on-disk representation of AppMemStorage content.
+type memSnapshot struct {
+	Version int               `json:"version"`
+	Pairs   []models.URLPair  `json:"pairs"`
+	Deleted []models.ShortURL `json:"deleted"`
+}
+
+// SaveSnapshot writes URL pairs and deletion marks into the named file.
+//
+// The file is replaced atomically, so a crash never leaves a partial snapshot.
+// Variant hit counters are not saved.
+func (s *AppMemStorage) SaveSnapshot(name string) error {
+	snap := memSnapshot{
+		Version: memSnapshotVersion,
+		Pairs:   []models.URLPair{},
+		Deleted: []models.ShortURL{},
+	}
+	s.rlockLinks()
+	for i := range s.links {
+		ls := &s.links[i]
+		for _, pair := range ls.pairs {
+			snap.Pairs = append(snap.Pairs, pair)
+		}
+		for short := range ls.deleted {
+			snap.Deleted = append(snap.Deleted, short)
+		}
+	}
+	s.runlockLinks()
+
+	return writeFileAtomic(name, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(&snap)
+	})
+}
+
+// LoadSnapshot replaces storage content with the snapshot from the named file.
+//
+// Returns an error wrapping os.ErrNotExist if there is no such file.
+func (s *AppMemStorage) LoadSnapshot(name string) (err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if fileCloseErr := file.Close(); fileCloseErr != nil {
+			err = fmt.Errorf("%v; file close failed: %w", err, fileCloseErr)
+		}
+	}()
+
+	var snap memSnapshot
+	err = json.NewDecoder(file).Decode(&snap)
+	if err != nil {
+		return err
+	}
+	if snap.Version != memSnapshotVersion {
+		return fmt.Errorf("%w: %d", errSnapshotVersion, snap.Version)
+	}
+
+	loaded := NewAppMemStorage()
+	for _, pair := range snap.Pairs {
+		loaded.put(loaded.linkShard(pair.Short), pair)
+	}
+	for _, short := range snap.Deleted {
+		loaded.linkShard(short).deleted[short] = struct{}{}
+	}
+
+	for i := range s.links {
+		s.links[i].mu.Lock()
+	}
+	for i := range s.users {
+		s.users[i].mu.Lock()
+	}
+	for i := range s.links {
+		s.links[i].pairs = loaded.links[i].pairs
+		s.links[i].deleted = loaded.links[i].deleted
+	}
+	for i := range s.users {
+		s.users[i].users = loaded.users[i].users
+		s.users[i].mu.Unlock()
+	}
+	for i := range s.links {
+		s.links[i].mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppMemStorage_Snapshot(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "snapshot.json")
+
+	deletedPair := testPair
+	deletedPair.Short = testDeletedShort
+	deletedPair.Tags = []string{"promo"}
+	otherPair := models.URLPair{UID: testOtherUserID, Short: "other", Orig: testOrigURL}
+
+	strg := NewAppMemStorage()
+	err := strg.AddBatchURLPairs(context.Background(), []models.URLPair{testPair, deletedPair, otherPair})
+	require.NoError(t, err)
+	err = strg.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{&testDelReq})
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		err := strg.SaveSnapshot(name)
+		require.NoError(t, err)
+
+		restored := NewAppMemStorage()
+		err = restored.LoadSnapshot(name)
+		require.NoError(t, err)
+		assert.Equal(t, dumpAppMemStorage(t, strg), dumpAppMemStorage(t, restored))
+
+		tmps, err := filepath.Glob(name + ".*.tmp")
+		require.NoError(t, err)
+		assert.Empty(t, tmps)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		err := os.WriteFile(name, []byte(`{"version":99}`), 0666)
+		require.NoError(t, err)
+
+		restored := NewAppMemStorage()
+		err = restored.LoadSnapshot(name)
+		assert.ErrorIs(t, err, errSnapshotVersion)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		err := NewAppMemStorage().LoadSnapshot(filepath.Join(t.TempDir(), "missing"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
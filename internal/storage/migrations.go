@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+	schema "github.com/rycln/shorturl/internal/db"
+)
+
+var errMigrationsPending = errors.New("database migrations are not applied")
+
+// CheckMigrations verifies that the database schema is up to date
+// with the migrations embedded into the binary.
+//
+// Migrations are applied by the migrator before the service starts,
+// the check only reads the version recorded by it.
+func (s *DatabaseStorage) CheckMigrations(ctx context.Context) error {
+	want, err := latestMigration(schema.MigrationsFS)
+	if err != nil {
+		return err
+	}
+
+	var got int64
+	err = s.db.QueryRowContext(ctx, sqlGetSchemaVersion).Scan(&got)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errMigrationsPending, err)
+	}
+	if got < want {
+		return fmt.Errorf("%w: schema version %d, want %d", errMigrationsPending, got, want)
+	}
+
+	return nil
+}
+
+// latestMigration returns the version of the last migration in fsys.
+func latestMigration(fsys fs.FS) (int64, error) {
+	names, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, name := range names {
+		version, err := goose.NumericComponent(name)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
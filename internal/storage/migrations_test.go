@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	schema "github.com/rycln/shorturl/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseStorage_CheckMigrations(t *testing.T) {
+	query := regexp.QuoteMeta(sqlGetSchemaVersion)
+
+	latest, err := latestMigration(schema.MigrationsFS)
+	require.NoError(t, err)
+	require.Positive(t, latest)
+
+	setup := func(t *testing.T) (*DatabaseStorage, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			mock.ExpectClose()
+			require.NoError(t, db.Close())
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+
+		return NewDatabaseStorage(db), mock
+	}
+
+	t.Run("up to date", func(t *testing.T) {
+		strg, mock := setup(t)
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest))
+
+		assert.NoError(t, strg.CheckMigrations(context.Background()))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		strg, mock := setup(t)
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(latest - 1))
+
+		assert.ErrorIs(t, strg.CheckMigrations(context.Background()), errMigrationsPending)
+	})
+
+	t.Run("not migrated", func(t *testing.T) {
+		strg, mock := setup(t)
+		mock.ExpectQuery(query).WillReturnError(errTest)
+
+		assert.ErrorIs(t, strg.CheckMigrations(context.Background()), errMigrationsPending)
+	})
+}
@@ -0,0 +1,112 @@
+// Package tracing provides OpenTelemetry tracing of the application.
+//
+// Spans are created with the global tracer provider, so they are dropped
+// until TraceInit configures an exporter.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Supported trace exporters.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+const (
+	tracerName  = "github.com/rycln/shorturl"
+	serviceName = "shorturl"
+)
+
+var errUnknownExporter = errors.New("unknown trace exporter")
+
+// provider is nil unless spans are exported.
+var provider *sdktrace.TracerProvider
+
+// TraceInit configures W3C trace context propagation and the global
+// tracer provider exporting spans with the given exporter.
+//
+// The OTLP exporter is configured by standard OTEL_EXPORTER_OTLP_* variables,
+// the service name can be overridden by OTEL_SERVICE_NAME.
+func TraceInit(exporter string) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Log.Info("Tracing error", zap.Error(err))
+	}))
+
+	var (
+		exp sdktrace.SpanExporter
+		err error
+	)
+	switch exporter {
+	case ExporterNone, "":
+		return nil
+	case ExporterStdout:
+		exp, err = stdouttrace.New()
+	case ExporterOTLP:
+		exp, err = otlptracegrpc.New(context.Background())
+	default:
+		return fmt.Errorf("%w: %s", errUnknownExporter, exporter)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return err
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return nil
+}
+
+// Shutdown exports buffered spans and stops the tracer provider.
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// Start creates a span as a child of the span in ctx.
+//
+// The span is created by the current global tracer provider.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Fail marks the span as failed with err.
+func Fail(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
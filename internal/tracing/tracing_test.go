@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceInit(t *testing.T) {
+	defer func() {
+		provider = nil
+	}()
+
+	t.Run("none", func(t *testing.T) {
+		require.NoError(t, TraceInit(ExporterNone))
+		assert.Nil(t, provider)
+		assert.NoError(t, Shutdown(context.Background()))
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		require.NoError(t, TraceInit(ExporterStdout))
+		assert.NotNil(t, provider)
+		assert.NoError(t, Shutdown(context.Background()))
+	})
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		err := TraceInit("jaeger")
+		assert.ErrorIs(t, err, errUnknownExporter)
+	})
+}
+
+func TestStart(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child", attribute.String("key", "value"))
+	Fail(child, errors.New("test error"))
+	child.End()
+	parent.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String("key", "value"))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Len(t, spans[0].Events, 1)
+}
@@ -0,0 +1,252 @@
+package transfer
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// backupVersion is the version of the backup archive format.
+// It is bumped whenever the format changes incompatibly.
+const backupVersion = 1
+
+// Backup archive entries.
+const (
+	backupManifestName   = "manifest.json"
+	backupPairsName      = "pairs.jsonl"
+	backupTombstonesName = "tombstones.jsonl"
+)
+
+var (
+	errBackupVersion   = errors.New("unsupported backup version")
+	errBackupCorrupted = errors.New("backup is corrupted")
+)
+
+// snapshotter provides a point-in-time view of a storage.
+type snapshotter interface {
+	// SnapshotURLPairs calls pairFn for every stored URL pair and then
+	// tombstoneFn for every deletion mark as of a single point in time.
+	SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, tombstoneFn func(models.DelURLReq) error) error
+}
+
+// Backuper writes consistent backups of a storage.
+//
+// A backup is a zip archive of JSON lines files with URL pairs and
+// deletion marks (tombstones) followed by a manifest with format version
+// and record counts.
+type Backuper struct {
+	src snapshotter
+	now func() time.Time
+}
+
+// NewBackuper creates a new Backuper instance.
+func NewBackuper(src snapshotter) *Backuper {
+	return &Backuper{
+		src: src,
+		now: time.Now,
+	}
+}
+
+// WriteBackup streams a backup of the storage into w.
+//
+// Nothing is buffered, so w receives data while the storage is being read.
+// On error the archive written so far is incomplete and must be discarded.
+func (b *Backuper) WriteBackup(ctx context.Context, w io.Writer) (*models.BackupManifest, error) {
+	manifest := &models.BackupManifest{
+		Version:   backupVersion,
+		CreatedAt: b.now().UTC(),
+	}
+
+	zw := zip.NewWriter(w)
+
+	entry, err := b.createEntry(zw, backupPairsName, manifest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(entry)
+
+	var tombstones bool
+	err = b.src.SnapshotURLPairs(ctx,
+		func(pair models.URLPair) error {
+			if tombstones {
+				return errors.New("URL pair after tombstones")
+			}
+			manifest.Pairs++
+			return enc.Encode(pair)
+		},
+		func(req models.DelURLReq) error {
+			if !tombstones {
+				tombstones = true
+				entry, err := b.createEntry(zw, backupTombstonesName, manifest.CreatedAt)
+				if err != nil {
+					return err
+				}
+				enc = json.NewEncoder(entry)
+			}
+			manifest.Tombstones++
+			return enc.Encode(req)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if !tombstones {
+		_, err = b.createEntry(zw, backupTombstonesName, manifest.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err = b.createEntry(zw, backupManifestName, manifest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	err = json.NewEncoder(entry).Encode(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func (b *Backuper) createEntry(zw *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	return zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+}
+
+// Backup is an opened backup archive.
+//
+// It serves as a migration source, so a backup is restored into any
+// storage with a Migrator.
+type Backup struct {
+	zr         *zip.Reader
+	manifest   models.BackupManifest
+	tombstones map[models.ShortURL]struct{}
+}
+
+// OpenBackup reads the manifest and the tombstones of a backup archive.
+func OpenBackup(r io.ReaderAt, size int64) (*Backup, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBackupCorrupted, err)
+	}
+
+	b := &Backup{
+		zr:         zr,
+		tombstones: make(map[models.ShortURL]struct{}),
+	}
+
+	err = b.decodeEntry(backupManifestName, func(dec *json.Decoder) error {
+		err := dec.Decode(&b.manifest)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errBackupCorrupted, backupManifestName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b.manifest.Version != backupVersion {
+		return nil, fmt.Errorf("%w: %d", errBackupVersion, b.manifest.Version)
+	}
+
+	var count int64
+	err = b.decodeEntry(backupTombstonesName, func(dec *json.Decoder) error {
+		for {
+			var req models.DelURLReq
+			err := dec.Decode(&req)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", errBackupCorrupted, backupTombstonesName, err)
+			}
+			count++
+			b.tombstones[req.Short] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count != b.manifest.Tombstones {
+		return nil, fmt.Errorf("%w: %d of %d tombstones", errBackupCorrupted, count, b.manifest.Tombstones)
+	}
+
+	return b, nil
+}
+
+// Manifest returns the manifest of the backup.
+func (b *Backup) Manifest() models.BackupManifest {
+	return b.manifest
+}
+
+// IterateURLPairs calls fn for every URL pair of the backup in archive order.
+// Pairs with a tombstone are reported deleted.
+func (b *Backup) IterateURLPairs(ctx context.Context, fn func(models.URLInfo) error) error {
+	var count int64
+	err := b.decodeEntry(backupPairsName, func(dec *json.Decoder) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			info := models.URLInfo{Status: models.StatusActive}
+			err := dec.Decode(&info.URLPair)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", errBackupCorrupted, backupPairsName, err)
+			}
+			count++
+
+			if _, ok := b.tombstones[info.Short]; ok {
+				info.Status = models.StatusDeleted
+			}
+
+			err = fn(info)
+			if err != nil {
+				return err
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if count != b.manifest.Pairs {
+		return fmt.Errorf("%w: %d of %d pairs", errBackupCorrupted, count, b.manifest.Pairs)
+	}
+
+	return nil
+}
+
+// decodeEntry calls decode with a decoder of the named archive entry.
+func (b *Backup) decodeEntry(name string, decode func(*json.Decoder) error) (err error) {
+	entry, err := b.zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errBackupCorrupted, err)
+	}
+	defer func() {
+		if entryCloseErr := entry.Close(); entryCloseErr != nil {
+			err = fmt.Errorf("%v; entry close failed: %w", err, entryCloseErr)
+		}
+	}()
+
+	return decode(json.NewDecoder(entry))
+}
@@ -0,0 +1,194 @@
+package transfer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingSnapshotter fails after the first pair.
+type failingSnapshotter struct{}
+
+func (failingSnapshotter) SnapshotURLPairs(ctx context.Context, pairFn func(models.URLPair) error, _ func(models.DelURLReq) error) error {
+	err := pairFn(models.URLPair{UID: "1", Short: "a", Orig: testOrigURL})
+	if err != nil {
+		return err
+	}
+	return errTest
+}
+
+func writeTestBackup(t *testing.T, src snapshotter) ([]byte, *models.BackupManifest) {
+	t.Helper()
+
+	b := NewBackuper(src)
+	b.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	var buf bytes.Buffer
+	manifest, err := b.WriteBackup(context.Background(), &buf)
+	require.NoError(t, err)
+
+	return buf.Bytes(), manifest
+}
+
+// writeTestArchive builds an archive from raw entries.
+func writeTestArchive(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestBackup(t *testing.T) {
+	src := newTestSource(t)
+
+	t.Run("round trip", func(t *testing.T) {
+		data, manifest := writeTestBackup(t, src)
+		assert.Equal(t, &models.BackupManifest{
+			Version:    backupVersion,
+			CreatedAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Pairs:      5,
+			Tombstones: 1,
+		}, manifest)
+
+		backup, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, *manifest, backup.Manifest())
+
+		dst := storage.NewAppMemStorage()
+		report, err := NewMigrator(backup, dst).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Written: 5}, report)
+		assert.Equal(t, collect(t, src), collect(t, dst))
+	})
+
+	t.Run("file storage", func(t *testing.T) {
+		strg, err := storage.NewFileStorage(filepath.Join(t.TempDir(), "storage"))
+		require.NoError(t, err)
+		_, err = NewMigrator(src, strg).Run(context.Background())
+		require.NoError(t, err)
+
+		data, manifest := writeTestBackup(t, strg)
+		assert.Equal(t, int64(5), manifest.Pairs)
+		assert.Equal(t, int64(1), manifest.Tombstones)
+
+		backup, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, collect(t, src), collect(t, backup))
+	})
+
+	t.Run("empty storage", func(t *testing.T) {
+		data, manifest := writeTestBackup(t, storage.NewAppMemStorage())
+		assert.Zero(t, manifest.Pairs)
+
+		backup, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Empty(t, collect(t, backup))
+	})
+
+	t.Run("snapshot error", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := NewBackuper(failingSnapshotter{}).WriteBackup(context.Background(), &buf)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestOpenBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries map[string]string
+		wantErr error
+	}{
+		{
+			name: "unknown version",
+			entries: map[string]string{
+				backupManifestName:   `{"version":99}`,
+				backupPairsName:      ``,
+				backupTombstonesName: ``,
+			},
+			wantErr: errBackupVersion,
+		},
+		{
+			name: "no manifest",
+			entries: map[string]string{
+				backupPairsName:      ``,
+				backupTombstonesName: ``,
+			},
+			wantErr: errBackupCorrupted,
+		},
+		{
+			name: "missing tombstones",
+			entries: map[string]string{
+				backupManifestName:   `{"version":1,"tombstones":2}`,
+				backupPairsName:      ``,
+				backupTombstonesName: `{"user_id":"1","short_url":"a"}`,
+			},
+			wantErr: errBackupCorrupted,
+		},
+		{
+			name: "broken tombstones",
+			entries: map[string]string{
+				backupManifestName:   `{"version":1,"tombstones":1}`,
+				backupPairsName:      ``,
+				backupTombstonesName: `{"user_id":`,
+			},
+			wantErr: errBackupCorrupted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := writeTestArchive(t, test.entries)
+			_, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+
+	t.Run("not an archive", func(t *testing.T) {
+		data := []byte("not an archive")
+		_, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		assert.ErrorIs(t, err, errBackupCorrupted)
+	})
+
+	t.Run("missing pairs", func(t *testing.T) {
+		data := writeTestArchive(t, map[string]string{
+			backupManifestName:   `{"version":1,"pairs":2}`,
+			backupPairsName:      `{"user_id":"1","short_url":"a","original_url":"https://practicum.yandex.ru/"}`,
+			backupTombstonesName: ``,
+		})
+		backup, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		err = backup.IterateURLPairs(context.Background(), func(models.URLInfo) error { return nil })
+		assert.ErrorIs(t, err, errBackupCorrupted)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		data := writeTestArchive(t, map[string]string{
+			backupManifestName:   `{"version":1,"pairs":1}`,
+			backupPairsName:      `{"user_id":"1","short_url":"a","original_url":"https://practicum.yandex.ru/"}`,
+			backupTombstonesName: ``,
+		})
+		backup, err := OpenBackup(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+
+		err = backup.IterateURLPairs(context.Background(), func(models.URLInfo) error { return errTest })
+		assert.ErrorIs(t, err, errTest)
+		assert.NotErrorIs(t, err, errBackupCorrupted)
+	})
+}
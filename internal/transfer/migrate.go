@@ -0,0 +1,311 @@
+// Package transfer moves URL shortener data between storage backends
+// and backup archives.
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+const defaultBatchSize = 500
+
+var errCheckpointMismatch = errors.New("checkpoint belongs to another migration")
+
+// source provides all stored URL pairs.
+type source interface {
+	// IterateURLPairs calls fn for every URL pair of all users including
+	// deleted ones in a stable order.
+	IterateURLPairs(context.Context, func(models.URLInfo) error) error
+}
+
+// destination accepts URL pairs restored from a source.
+type destination interface {
+	// RestoreURLPairs stores URL pairs with their deletion state skipping
+	// the ones which collide with existing links.
+	RestoreURLPairs(context.Context, []models.URLInfo) ([]bool, error)
+
+	// LookupURLPairBatch retrieves URL pairs by short URLs including deleted ones.
+	LookupURLPairBatch(context.Context, []models.ShortURL) (map[models.ShortURL]models.URLInfo, error)
+}
+
+// Report summarizes a migration run.
+//
+// Source counters cover the whole source, the rest only the records
+// processed by this run.
+type Report struct {
+	// Pairs, Deleted and Owners count URL pairs, deleted ones among them
+	// and distinct users in the source.
+	Pairs   int64 `json:"pairs"`
+	Deleted int64 `json:"deleted"`
+	Owners  int64 `json:"owners"`
+
+	// Resumed counts records skipped as processed by a previous run.
+	Resumed int64 `json:"resumed"`
+
+	// Written counts records stored in the destination,
+	// or that would be stored in dry-run mode.
+	Written int64 `json:"written"`
+
+	// Existing counts records already present in the destination unchanged.
+	Existing int64 `json:"existing"`
+
+	// Conflicts counts records whose short URL is taken in the destination
+	// by a different link.
+	Conflicts int64 `json:"conflicts"`
+
+	// Mismatched counts written records which failed verification.
+	Mismatched int64 `json:"mismatched"`
+}
+
+// OK reports whether every processed record is present in the destination.
+func (r *Report) OK() bool {
+	return r.Conflicts == 0 && r.Mismatched == 0
+}
+
+// checkpoint is the persisted progress of a migration.
+type checkpoint struct {
+	Key       string `json:"key"`
+	Processed int64  `json:"processed"`
+}
+
+// Migrator copies URL pairs, their owners and deletion marks
+// from one storage to another.
+//
+// Records are written in batches and read back from the destination to be
+// verified. Records already present in the destination are skipped, so an
+// interrupted migration can be safely restarted. A checkpoint file
+// additionally lets a restarted migration skip the verified prefix of the source.
+type Migrator struct {
+	src        source
+	dst        destination
+	batchSize  int
+	dryRun     bool
+	checkpoint string
+	key        string
+}
+
+type migratorOption func(*Migrator)
+
+// WithBatchSize sets the number of records written at once.
+func WithBatchSize(size int) migratorOption {
+	return func(m *Migrator) {
+		if size > 0 {
+			m.batchSize = size
+		}
+	}
+}
+
+// WithDryRun makes the migrator only report what would be written.
+func WithDryRun(dryRun bool) migratorOption {
+	return func(m *Migrator) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithCheckpoint enables progress tracking in the named file.
+//
+// The key identifies the migration, a checkpoint with another key is rejected.
+func WithCheckpoint(name, key string) migratorOption {
+	return func(m *Migrator) {
+		m.checkpoint = name
+		m.key = key
+	}
+}
+
+// NewMigrator creates a new Migrator instance.
+func NewMigrator(src source, dst destination, opts ...migratorOption) *Migrator {
+	m := &Migrator{
+		src:       src,
+		dst:       dst,
+		batchSize: defaultBatchSize,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Run performs the migration.
+//
+// The checkpoint file is removed once the whole source is processed.
+// Conflicting and mismatched records do not stop the migration,
+// check Report.OK.
+func (m *Migrator) Run(ctx context.Context) (*Report, error) {
+	skip, err := m.loadCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	owners := make(map[models.UserID]struct{})
+	batch := make([]models.URLInfo, 0, m.batchSize)
+
+	var seen int64
+	err = m.src.IterateURLPairs(ctx, func(info models.URLInfo) error {
+		seen++
+		report.Pairs++
+		if info.Status == models.StatusDeleted {
+			report.Deleted++
+		}
+		owners[info.UID] = struct{}{}
+
+		if seen <= skip {
+			report.Resumed++
+			return nil
+		}
+
+		batch = append(batch, info)
+		if len(batch) < m.batchSize {
+			return nil
+		}
+
+		err := m.flush(ctx, batch, report, seen)
+		batch = batch[:0]
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batch) > 0 {
+		err = m.flush(ctx, batch, report, seen)
+		if err != nil {
+			return nil, err
+		}
+	}
+	report.Owners = int64(len(owners))
+
+	if seen < skip {
+		return nil, fmt.Errorf("%w: source has %d records, %d processed", errCheckpointMismatch, seen, skip)
+	}
+
+	err = m.removeCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// flush writes the batch into the destination, verifies it and saves
+// the progress. processed is the number of source records up to the
+// end of the batch.
+func (m *Migrator) flush(ctx context.Context, batch []models.URLInfo, report *Report, processed int64) error {
+	conflicts := make([]bool, len(batch))
+	if !m.dryRun {
+		var err error
+		conflicts, err = m.dst.RestoreURLPairs(ctx, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	shorts := make([]models.ShortURL, len(batch))
+	for i, info := range batch {
+		shorts[i] = info.Short
+	}
+
+	found, err := m.dst.LookupURLPairBatch(ctx, shorts)
+	if err != nil {
+		return err
+	}
+
+	for i, info := range batch {
+		got, ok := found[info.Short]
+
+		switch {
+		case m.dryRun && !ok:
+			report.Written++
+		case m.dryRun || conflicts[i]:
+			if ok && sameLink(info, got) {
+				report.Existing++
+			} else {
+				report.Conflicts++
+			}
+		case ok && sameLink(info, got):
+			report.Written++
+		default:
+			report.Mismatched++
+		}
+	}
+
+	if m.dryRun {
+		return nil
+	}
+
+	return m.saveCheckpoint(processed)
+}
+
+// sameLink reports whether two records describe the same link of the same
+// owner in the same deletion state.
+func sameLink(want, got models.URLInfo) bool {
+	return want.UID == got.UID &&
+		want.Orig == got.Orig &&
+		(want.Status == models.StatusDeleted) == (got.Status == models.StatusDeleted)
+}
+
+// loadCheckpoint returns the number of source records processed by previous runs.
+func (m *Migrator) loadCheckpoint() (int64, error) {
+	if m.checkpoint == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(m.checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var cp checkpoint
+	err = json.Unmarshal(data, &cp)
+	if err != nil {
+		return 0, err
+	}
+	if cp.Key != m.key {
+		return 0, fmt.Errorf("%w: %q", errCheckpointMismatch, cp.Key)
+	}
+
+	return cp.Processed, nil
+}
+
+// saveCheckpoint atomically replaces the checkpoint file.
+func (m *Migrator) saveCheckpoint(processed int64) error {
+	if m.checkpoint == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint{Key: m.key, Processed: processed})
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(filepath.Dir(m.checkpoint), "."+filepath.Base(m.checkpoint)+".tmp")
+	err = os.WriteFile(tmp, data, 0666)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, m.checkpoint)
+}
+
+func (m *Migrator) removeCheckpoint() error {
+	if m.checkpoint == "" || m.dryRun {
+		return nil
+	}
+
+	err := os.Remove(m.checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
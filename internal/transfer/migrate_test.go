@@ -0,0 +1,147 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/rycln/shorturl/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOrigURL models.OrigURL = "https://practicum.yandex.ru/"
+
+var errTest = errors.New("test error")
+
+// failingDestination fails all writes after the first n batches.
+type failingDestination struct {
+	destination
+	n int
+}
+
+func (d *failingDestination) RestoreURLPairs(ctx context.Context, infos []models.URLInfo) ([]bool, error) {
+	if d.n == 0 {
+		return nil, errTest
+	}
+	d.n--
+	return d.destination.RestoreURLPairs(ctx, infos)
+}
+
+func newTestSource(t *testing.T) *storage.AppMemStorage {
+	t.Helper()
+
+	src := storage.NewAppMemStorage()
+	err := src.AddBatchURLPairs(context.Background(), []models.URLPair{
+		{UID: "1", Short: "a", Orig: testOrigURL},
+		{UID: "1", Short: "b", Orig: testOrigURL, Tags: []string{"promo"}},
+		{UID: "2", Short: "c", Orig: testOrigURL},
+		{UID: "2", Short: "d", Orig: testOrigURL},
+		{UID: "3", Short: "e", Orig: testOrigURL},
+	})
+	require.NoError(t, err)
+
+	err = src.DeleteRequestedURLs(context.Background(), []*models.DelURLReq{{UID: "2", Short: "d"}})
+	require.NoError(t, err)
+
+	return src
+}
+
+func collect(t *testing.T, strg source) []models.URLInfo {
+	t.Helper()
+
+	var infos []models.URLInfo
+	err := strg.IterateURLPairs(context.Background(), func(info models.URLInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return infos
+}
+
+func TestMigrator_Run(t *testing.T) {
+	src := newTestSource(t)
+
+	t.Run("valid test", func(t *testing.T) {
+		dst := storage.NewAppMemStorage()
+
+		report, err := NewMigrator(src, dst, WithBatchSize(2)).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Written: 5}, report)
+		assert.True(t, report.OK())
+		assert.Equal(t, collect(t, src), collect(t, dst))
+	})
+
+	t.Run("file destination", func(t *testing.T) {
+		dst, err := storage.NewFileStorage(filepath.Join(t.TempDir(), "storage"))
+		require.NoError(t, err)
+
+		report, err := NewMigrator(src, dst).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Written: 5}, report)
+
+		back := storage.NewAppMemStorage()
+		_, err = NewMigrator(dst, back).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, collect(t, src), collect(t, back))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		dst := storage.NewAppMemStorage()
+		err := dst.AddBatchURLPairs(context.Background(), []models.URLPair{
+			{UID: "1", Short: "a", Orig: testOrigURL},
+			{UID: "9", Short: "b", Orig: testOrigURL},
+		})
+		require.NoError(t, err)
+
+		report, err := NewMigrator(src, dst, WithDryRun(true)).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Written: 3, Existing: 1, Conflicts: 1}, report)
+		assert.False(t, report.OK())
+		assert.Len(t, collect(t, dst), 2)
+	})
+
+	t.Run("rerun skips existing", func(t *testing.T) {
+		dst := storage.NewAppMemStorage()
+
+		_, err := NewMigrator(src, dst).Run(context.Background())
+		require.NoError(t, err)
+
+		report, err := NewMigrator(src, dst).Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Existing: 5}, report)
+	})
+
+	t.Run("destination error", func(t *testing.T) {
+		_, err := NewMigrator(src, &failingDestination{destination: storage.NewAppMemStorage()}).Run(context.Background())
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestMigrator_Resume(t *testing.T) {
+	src := newTestSource(t)
+	dst := storage.NewAppMemStorage()
+	cp := filepath.Join(t.TempDir(), "checkpoint")
+
+	_, err := NewMigrator(src, &failingDestination{destination: dst, n: 1},
+		WithBatchSize(2), WithCheckpoint(cp, "mem->mem")).Run(context.Background())
+	require.ErrorIs(t, err, errTest)
+	assert.FileExists(t, cp)
+
+	t.Run("another migration", func(t *testing.T) {
+		_, err := NewMigrator(src, dst, WithCheckpoint(cp, "file->db")).Run(context.Background())
+		assert.ErrorIs(t, err, errCheckpointMismatch)
+	})
+
+	report, err := NewMigrator(src, dst, WithBatchSize(2), WithCheckpoint(cp, "mem->mem")).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Pairs: 5, Deleted: 1, Owners: 3, Resumed: 2, Written: 3}, report)
+	assert.Equal(t, collect(t, src), collect(t, dst))
+
+	_, err = os.Stat(cp)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,309 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/rycln/shorturl/internal/models"
+)
+
+// deletionLogCompactSize is the size of applied records after which the log
+// is rewritten with pending requests only.
+const deletionLogCompactSize = 1 << 20
+
+// errDeletionLogCorrupted is returned when the log contains a damaged record
+// other than a torn last line.
+var errDeletionLogCorrupted = errors.New("deletion log is corrupted")
+
+// deletionLogRecord is a line of the deletion log. It holds either
+// a queued deletion request or number of the oldest requests applied.
+type deletionLogRecord struct {
+	UID    models.UserID   `json:"uid,omitempty"`
+	Short  models.ShortURL `json:"short,omitempty"`
+	Commit int             `json:"commit,omitempty"`
+}
+
+// deletionLogEntry is a pending request of the log and size of its record.
+type deletionLogEntry struct {
+	req  *models.DelURLReq
+	size int64
+}
+
+// DeletionLog is a write-ahead log of URL deletion requests.
+//
+// Requests are appended to the log and synced to disk before they are
+// acknowledged. Once a batch is deleted from storage, a commit record
+// drops the oldest requests from the log. Requests left uncommitted by
+// a crash are replayed when the log is opened again. Deletion is
+// idempotent, so replaying a request twice is harmless.
+//
+// When applied records take more than compactSize bytes, the log is
+// rewritten with pending requests only, so it stays bounded even if
+// the queue never drains.
+type DeletionLog struct {
+	mu          sync.Mutex
+	name        string
+	file        *os.File
+	size        int64
+	committed   int64
+	compactSize int64
+	pending     []deletionLogEntry
+	replay      []*models.DelURLReq
+}
+
+// OpenDeletionLog opens the named log creating it if necessary.
+//
+// Uncommitted requests are kept for Pending. A torn last line left
+// by a crash is cut off.
+func OpenDeletionLog(name string) (*DeletionLog, error) {
+	pending, size, err := readDeletionLog(name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Truncate(size)
+	if err != nil {
+		if fileCloseErr := file.Close(); fileCloseErr != nil {
+			err = fmt.Errorf("%v; file close failed: %w", err, fileCloseErr)
+		}
+		return nil, err
+	}
+
+	l := &DeletionLog{
+		name:        name,
+		file:        file,
+		size:        size,
+		committed:   size,
+		compactSize: deletionLogCompactSize,
+		pending:     pending,
+	}
+	for _, entry := range pending {
+		l.replay = append(l.replay, entry.req)
+		l.committed -= entry.size
+	}
+
+	return l, nil
+}
+
+// Pending returns requests which were not committed before the log was opened.
+func (l *DeletionLog) Pending() []*models.DelURLReq {
+	return l.replay
+}
+
+// Append writes requests to the end of the log and syncs it to disk.
+//
+// On failure the log is truncated back, so no part of the requests remains.
+func (l *DeletionLog) Append(reqs []*models.DelURLReq) error {
+	var buf bytes.Buffer
+	entries := make([]deletionLogEntry, len(reqs))
+	for i, req := range reqs {
+		start := buf.Len()
+		err := encodeDeletionRequests(&buf, reqs[i:i+1])
+		if err != nil {
+			return err
+		}
+		entries[i] = deletionLogEntry{req: req, size: int64(buf.Len() - start)}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := l.file.Write(buf.Bytes())
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		if truncErr := l.file.Truncate(l.size); truncErr != nil {
+			err = fmt.Errorf("%v; log truncation failed: %w", err, truncErr)
+		}
+		return err
+	}
+
+	l.size += int64(buf.Len())
+	l.pending = append(l.pending, entries...)
+
+	return nil
+}
+
+// Commit marks n oldest requests as applied.
+//
+// The log is emptied once all its requests are applied
+// and compacted once applied records grow past compactSize.
+func (l *DeletionLog) Commit(n int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n > len(l.pending) {
+		return fmt.Errorf("commit of %d requests exceeds %d pending", n, len(l.pending))
+	}
+	for _, entry := range l.pending[:n] {
+		l.committed += entry.size
+	}
+	l.pending = l.pending[n:]
+
+	if len(l.pending) == 0 {
+		err := l.file.Truncate(0)
+		if err != nil {
+			return err
+		}
+		l.pending = nil
+		l.size = 0
+		l.committed = 0
+		return nil
+	}
+
+	line, err := json.Marshal(deletionLogRecord{Commit: n})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = l.file.Write(line)
+	if err != nil {
+		return err
+	}
+	l.size += int64(len(line))
+	l.committed += int64(len(line))
+
+	if l.committed < l.compactSize {
+		return nil
+	}
+	return l.compact()
+}
+
+// compact rewrites the log with pending requests only.
+//
+// The requests are written into a temporary file which is synced and then
+// renamed over the log, so a crash leaves either the old or the new log.
+// If compaction fails the old log stays in use.
+func (l *DeletionLog) compact() (err error) {
+	var buf bytes.Buffer
+	reqs := make([]*models.DelURLReq, len(l.pending))
+	for i, entry := range l.pending {
+		reqs[i] = entry.req
+	}
+	err = encodeDeletionRequests(&buf, reqs)
+	if err != nil {
+		return err
+	}
+
+	// The file is opened for appending before the rename,
+	// so the log never writes into a file which is already replaced.
+	tmpName := l.name + ".tmp"
+	tmp, err := os.OpenFile(tmpName, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if tmpCloseErr := tmp.Close(); tmpCloseErr != nil {
+			err = fmt.Errorf("%v; temp file close failed: %w", err, tmpCloseErr)
+		}
+		if removeErr := os.Remove(tmpName); removeErr != nil {
+			err = fmt.Errorf("%v; temp file remove failed: %w", err, removeErr)
+		}
+	}()
+
+	_, err = tmp.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpName, l.name)
+	if err != nil {
+		return err
+	}
+
+	old := l.file
+	l.file = tmp
+	l.size = int64(buf.Len())
+	l.committed = 0
+	// Copy releases entries of the applied requests.
+	l.pending = append([]deletionLogEntry(nil), l.pending...)
+
+	if oldCloseErr := old.Close(); oldCloseErr != nil {
+		return fmt.Errorf("compacted log close failed: %w", oldCloseErr)
+	}
+	return nil
+}
+
+// Close closes the log file.
+func (l *DeletionLog) Close() error {
+	return l.file.Close()
+}
+
+// readDeletionLog returns uncommitted requests of the named log
+// and size of its complete lines. Missing file is treated as an empty log.
+func readDeletionLog(name string) (reqs []deletionLogEntry, size int64, err error) {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if fileCloseErr := file.Close(); fileCloseErr != nil {
+			err = fmt.Errorf("%v; file close failed: %w", err, fileCloseErr)
+		}
+	}()
+
+	r := bufio.NewReader(file)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A line without newline is a write torn by a crash,
+			// it has never been acknowledged.
+			return reqs, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var rec deletionLogRecord
+		err = json.Unmarshal(line, &rec)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", errDeletionLogCorrupted, err)
+		}
+		size += int64(len(line))
+
+		if rec.Commit == 0 {
+			reqs = append(reqs, deletionLogEntry{
+				req:  &models.DelURLReq{UID: rec.UID, Short: rec.Short},
+				size: int64(len(line)),
+			})
+			continue
+		}
+		if rec.Commit > len(reqs) {
+			return nil, 0, fmt.Errorf("%w: commit of %d requests exceeds %d pending", errDeletionLogCorrupted, rec.Commit, len(reqs))
+		}
+		reqs = reqs[rec.Commit:]
+	}
+}
+
+func encodeDeletionRequests(w io.Writer, reqs []*models.DelURLReq) error {
+	enc := json.NewEncoder(w)
+	for _, req := range reqs {
+		err := enc.Encode(deletionLogRecord{UID: req.UID, Short: req.Short})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rycln/shorturl/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionLog(t *testing.T) {
+	t.Run("replay uncommitted", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Empty(t, log.Pending())
+
+		err = log.Append(testDelReqs[:2])
+		require.NoError(t, err)
+		err = log.Append(testDelReqs[2:])
+		require.NoError(t, err)
+		err = log.Commit(1)
+		require.NoError(t, err)
+		require.NoError(t, log.Close())
+
+		log, err = OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Equal(t, testDelReqs[1:], log.Pending())
+
+		// Replayed requests are committed like appended ones.
+		err = log.Commit(2)
+		require.NoError(t, err)
+		require.NoError(t, log.Close())
+
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.Zero(t, info.Size())
+	})
+
+	t.Run("torn last line", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+		content := `{"uid":"1","short":"url1"}` + "\n" + `{"uid":"1","sho`
+		err := os.WriteFile(name, []byte(content), 0666)
+		require.NoError(t, err)
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Equal(t, testDelReqs[:1], log.Pending())
+
+		// The torn line is cut off before new requests are appended.
+		err = log.Append([]*models.DelURLReq{testDelReqs[1]})
+		require.NoError(t, err)
+		require.NoError(t, log.Close())
+
+		log, err = OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Equal(t, testDelReqs[:2], log.Pending())
+		require.NoError(t, log.Close())
+	})
+
+	t.Run("compaction", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		log.compactSize = 1024
+
+		// The queue never drains: every commit leaves the last request pending.
+		err = log.Append(testDelReqs[:1])
+		require.NoError(t, err)
+		for i := 0; i < 1000; i++ {
+			req := &models.DelURLReq{UID: "1", Short: models.ShortURL(fmt.Sprintf("url%d", i))}
+			err = log.Append([]*models.DelURLReq{req})
+			require.NoError(t, err)
+			err = log.Commit(1)
+			require.NoError(t, err)
+
+			info, err := os.Stat(name)
+			require.NoError(t, err)
+			require.Less(t, info.Size(), int64(2048))
+		}
+		err = log.Append(testDelReqs[1:2])
+		require.NoError(t, err)
+		require.NoError(t, log.Close())
+
+		_, err = os.Stat(name + ".tmp")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		log, err = OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Equal(t, []*models.DelURLReq{{UID: "1", Short: "url999"}, testDelReqs[1]}, log.Pending())
+		require.NoError(t, log.Close())
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+		content := "not json\n" + `{"uid":"1","short":"url1"}` + "\n"
+		err := os.WriteFile(name, []byte(content), 0666)
+		require.NoError(t, err)
+
+		_, err = OpenDeletionLog(name)
+		assert.ErrorIs(t, err, errDeletionLogCorrupted)
+	})
+
+	t.Run("excessive commit", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, log.Close())
+		}()
+
+		err = log.Append(testDelReqs[:1])
+		require.NoError(t, err)
+		err = log.Commit(2)
+		assert.Error(t, err)
+	})
+}
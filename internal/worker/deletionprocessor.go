@@ -1,8 +1,8 @@
-// Package worker implements a background URL deletion processor for the URL shortener service.
 package worker
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/rycln/shorturl/internal/logger"
@@ -12,128 +12,323 @@ import (
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+// Default deletion queue limits.
+const (
+	defaultQueueCapacity   = 10000
+	defaultMaxBatchSize    = 500
+	defaultRetryBackoff    = time.Second
+	defaultMaxRetryBackoff = time.Minute
+)
+
 type batchDeleteServicer interface {
 	DeleteURLsBatch(context.Context, []*models.DelURLReq) error
 }
 
-// DeletionProcessor is a background worker that processes URL deletions in batches.
+type deletionLog interface {
+	Pending() []*models.DelURLReq
+	Append([]*models.DelURLReq) error
+	Commit(int) error
+}
+
+// DeletionProcessor processes URL deletions in batches as a scheduler job.
+//
+// Requests are kept in a single bounded queue. The queue is flushed to storage
+// every period, as soon as it holds a full batch and on shutdown. A failed batch
+// stays at the head of the queue and is retried with exponential backoff.
+// When the queue is full new requests are rejected, so callers can push back
+// on clients.
 //
-// The processor collects deletion requests in memory and flushes them to storage
-// either when batch size limit is reached or on timer expiration.
+// With a deletion log requests survive crashes and restarts: they are acknowledged
+// only after being written to the log and committed there after being deleted
+// from storage.
 type DeletionProcessor struct {
-	ctx                context.Context
-	cancel             context.CancelFunc
 	batchDeleteService batchDeleteServicer
-	delChans           chan chan *models.DelURLReq
+	log                deletionLog
+
+	capacity        int
+	maxBatchSize    int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+
+	mu       sync.Mutex
+	job      *Job
+	queue    []*models.DelURLReq
+	reserved int
+	closed   bool
+	inFlight int
+
+	// appendMu orders deletion log appends with publishing to the queue,
+	// so that log commits of deleted batches match the queue head.
+	appendMu sync.Mutex
+	// appends tracks requests with reserved room which are not queued yet.
+	appends sync.WaitGroup
+
+	deleted       int64
+	rejected      int64
+	failedBatches int64
+}
+
+type deletionProcessorOption func(*DeletionProcessor)
+
+// WithDeletionLog makes deletion requests durable.
+//
+// Requests left in the log by the previous run are queued first
+// even if they exceed the queue capacity.
+func WithDeletionLog(log deletionLog) deletionProcessorOption {
+	return func(p *DeletionProcessor) {
+		p.log = log
+		p.queue = append(p.queue, log.Pending()...)
+	}
+}
+
+// WithQueueCapacity limits number of queued deletion requests.
+func WithQueueCapacity(capacity int) deletionProcessorOption {
+	return func(p *DeletionProcessor) {
+		p.capacity = capacity
+	}
+}
+
+// WithMaxBatchSize limits number of requests deleted at once.
+// Queue holding that many requests is flushed immediately.
+func WithMaxBatchSize(size int) deletionProcessorOption {
+	return func(p *DeletionProcessor) {
+		p.maxBatchSize = size
+	}
+}
+
+// WithRetryBackoff sets delays before retrying a failed batch.
+// The delay starts at initial and doubles after each failure up to max.
+func WithRetryBackoff(initial, max time.Duration) deletionProcessorOption {
+	return func(p *DeletionProcessor) {
+		p.retryBackoff = initial
+		p.maxRetryBackoff = max
+	}
 }
 
 // NewDeletionProcessor creates new processor instance.
-func NewDeletionProcessor(batchDeleteService batchDeleteServicer) *DeletionProcessor {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &DeletionProcessor{
-		ctx:                ctx,
-		cancel:             cancel,
+func NewDeletionProcessor(batchDeleteService batchDeleteServicer, opts ...deletionProcessorOption) *DeletionProcessor {
+	p := &DeletionProcessor{
 		batchDeleteService: batchDeleteService,
-		delChans:           make(chan chan *models.DelURLReq, 10),
+		capacity:           defaultQueueCapacity,
+		maxBatchSize:       defaultMaxBatchSize,
+		retryBackoff:       defaultRetryBackoff,
+		maxRetryBackoff:    defaultMaxRetryBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(p)
 	}
-}
 
-// Shutdown stops the processor.
-func (p *DeletionProcessor) Shutdown() {
-	p.cancel()
-	close(p.delChans)
+	return p
 }
 
-// Run starts the background processing loop.
+// Schedule registers the processor as a job of the scheduler flushing
+// the queue every period. Each batch deletion is limited by timeout.
 //
-// The processor will handle requests until Shutdown() is called.
-func (p *DeletionProcessor) Run(period time.Duration, timeout time.Duration) chan struct{} {
-	doneCh := make(chan struct{})
-
-	go func() {
-		defer close(doneCh)
-
-		inChan := fanIn(p.ctx, p.delChans)
-
-		tick := time.NewTicker(period)
-
-		var delBatch []*models.DelURLReq
-
-		for {
-			select {
-			case <-p.ctx.Done():
-				return
-			case durl := <-inChan:
-				delBatch = append(delBatch, durl)
-			case <-tick.C:
-				if len(delBatch) == 0 {
-					continue
-				}
-
-				ctx, cancel := context.WithTimeout(p.ctx, timeout)
-				err := p.batchDeleteService.DeleteURLsBatch(ctx, delBatch)
-				if err != nil {
-					logger.Log.Info("Cannot delete batch", zap.Error(err))
-					cancel()
-					continue
-				}
-				cancel()
-				delBatch = nil
-			}
+// On scheduler shutdown new requests are rejected with ErrProcessorClosed
+// and queued ones are flushed.
+func (p *DeletionProcessor) Schedule(s *Scheduler, period time.Duration, timeout time.Duration) {
+	job := s.Every("deletion", period, p.flush,
+		WithJobTimeout(timeout),
+		WithJobBackoff(p.retryBackoff, p.maxRetryBackoff),
+		WithJobFinalRun(p.drain),
+	)
+
+	p.mu.Lock()
+	p.job = job
+	p.mu.Unlock()
+}
+
+// Check reports whether the deletion job keeps flushing the queue,
+// see Job.Check. It fails if the processor is not scheduled.
+func (p *DeletionProcessor) Check(context.Context) error {
+	p.mu.Lock()
+	job := p.job
+	p.mu.Unlock()
+
+	if job == nil {
+		return errNotScheduled
+	}
+	return job.Check()
+}
+
+// flush deletes a batch from the head of the queue and triggers
+// the next run if more requests are queued.
+func (p *DeletionProcessor) flush(ctx context.Context) error {
+	_, err := p.deleteBatch(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	more := len(p.queue) > 0
+	job := p.job
+	p.mu.Unlock()
+
+	if more && job != nil {
+		job.Trigger()
+	}
+
+	return nil
+}
+
+// drain closes the queue and deletes all queued requests without retries.
+// Requests left are kept by the deletion log if there is one.
+func (p *DeletionProcessor) drain(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	// requests accepted before closing are being logged, wait for them
+	p.appends.Wait()
+
+	for {
+		n, err := p.deleteBatch(ctx)
+		if err != nil {
+			p.mu.Lock()
+			left := len(p.queue)
+			p.mu.Unlock()
+			logger.Log.Info("Cannot flush deletion queue", zap.Int("left", left), zap.Error(err))
+			return err
 		}
-	}()
+		if n == 0 {
+			return nil
+		}
+	}
+}
+
+// deleteBatch deletes the head of the queue from storage and returns
+// number of deleted requests. Failed batch stays in the queue.
+func (p *DeletionProcessor) deleteBatch(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	n := min(len(p.queue), p.maxBatchSize)
+	batch := p.queue[:n:n]
+	p.inFlight = n
+	p.mu.Unlock()
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	err := p.batchDeleteService.DeleteURLsBatch(ctx, batch)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inFlight = 0
+	if err != nil {
+		p.failedBatches++
+		return 0, err
+	}
+
+	p.queue = p.queue[n:]
+	if len(p.queue) == 0 {
+		p.queue = nil
+	}
+	p.deleted += int64(n)
+
+	if p.log != nil {
+		err = p.log.Commit(n)
+		if err != nil {
+			logger.Log.Info("Cannot commit deletion log", zap.Error(err))
+		}
+	}
 
-	return doneCh
+	return n, nil
 }
 
 // AddURLsIntoDeletionQueue enqueues URLs for deletion.
 //
-// Non-blocking method for use in HTTP handlers.
-func (p *DeletionProcessor) AddURLsIntoDeletionQueue(uid models.UserID, shorts []models.ShortURL) {
-	delCh := make(chan *models.DelURLReq)
-
-	go func() {
-		defer close(delCh)
-		for _, short := range shorts {
-			select {
-			case <-p.ctx.Done():
-				return
-			default:
-				delCh <- &models.DelURLReq{
-					UID:   uid,
-					Short: short,
-				}
-			}
-		}
-	}()
-
-	p.delChans <- delCh
-}
-
-func fanIn(ctx context.Context, inChans <-chan chan *models.DelURLReq) chan *models.DelURLReq {
-	outChan := make(chan *models.DelURLReq)
-
-	go func() {
-		defer close(outChan)
-
-		for ch := range inChans {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				go func(ch <-chan *models.DelURLReq) {
-					for durl := range ch {
-						select {
-						case <-ctx.Done():
-							return
-						default:
-							outChan <- durl
-						}
-					}
-				}(ch)
-			}
-		}
-	}()
+// Returns after the requests are written to the deletion log if there is one,
+// so the caller may acknowledge them. When the queue has no room for the
+// requests, returns an error reporting when to retry.
+func (p *DeletionProcessor) AddURLsIntoDeletionQueue(uid models.UserID, shorts []models.ShortURL) error {
+	reqs := make([]*models.DelURLReq, 0, len(shorts))
+	for _, short := range shorts {
+		reqs = append(reqs, &models.DelURLReq{
+			UID:   uid,
+			Short: short,
+		})
+	}
+
+	err := p.reserve(len(reqs))
+	if err != nil {
+		return err
+	}
+	defer p.appends.Done()
+
+	// The log is synced without holding the queue lock, so flushes and
+	// stats are not blocked by disk writes.
+	p.appendMu.Lock()
+	defer p.appendMu.Unlock()
+
+	if p.log != nil {
+		err = p.log.Append(reqs)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reserved -= len(reqs)
+	if err != nil {
+		return err
+	}
+
+	p.queue = append(p.queue, reqs...)
+
+	if len(p.queue) >= p.maxBatchSize && p.job != nil {
+		p.job.Trigger()
+	}
+
+	return nil
+}
+
+// reserve takes room for n requests in the queue.
+func (p *DeletionProcessor) reserve(n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrProcessorClosed
+	}
+	if n > p.capacity {
+		return newErrTooLargeBatch(errTooLargeBatch)
+	}
+	if len(p.queue)+p.reserved+n > p.capacity {
+		p.rejected += int64(n)
+		return newErrQueueFull(errQueueFull, p.retryAfter())
+	}
+	p.reserved += n
+	p.appends.Add(1)
+
+	return nil
+}
+
+// retryAfter estimates when the queue gets room: at the next flush
+// or retry. The caller must hold the lock.
+func (p *DeletionProcessor) retryAfter() time.Duration {
+	if p.job == nil {
+		return 0
+	}
+	return p.job.NextRunIn()
+}
+
+// Stats returns current state of the deletion queue.
+func (p *DeletionProcessor) Stats() models.DeletionQueueStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := models.DeletionQueueStats{
+		Depth:         len(p.queue),
+		Capacity:      p.capacity,
+		InFlight:      p.inFlight,
+		Deleted:       p.deleted,
+		Rejected:      p.rejected,
+		FailedBatches: p.failedBatches,
+	}
+	if p.job != nil {
+		stats.RetryIn = p.job.RetryIn()
+	}
 
-	return outChan
+	return stats
 }
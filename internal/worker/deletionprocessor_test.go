@@ -1,7 +1,9 @@
 package worker
 
 import (
+	"context"
 	"errors"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -9,6 +11,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/shorturl/internal/models"
 	"github.com/rycln/shorturl/internal/worker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -19,7 +23,13 @@ const (
 
 var errTest = errors.New("test error")
 
-func TestDeletionProcessor_Run(t *testing.T) {
+var testDelReqs = []*models.DelURLReq{
+	{UID: testUserID, Short: "url1"},
+	{UID: testUserID, Short: "url2"},
+	{UID: testUserID, Short: "url3"},
+}
+
+func TestDeletionProcessor_Schedule(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -29,26 +39,23 @@ func TestDeletionProcessor_Run(t *testing.T) {
 		var wg sync.WaitGroup
 		wg.Add(1)
 
-		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), gomock.Any()).Return(nil).Times(1).Do(func(_, _ interface{}) {
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1).Do(func(_, _ interface{}) {
 			wg.Done()
 		})
 
 		p := NewDeletionProcessor(mServ)
-		defer p.Shutdown()
 
 		urls := []models.ShortURL{"url1", "url2", "url3"}
 
-		p.Run(testTicker, testTimeout)
-		p.AddURLsIntoDeletionQueue(testUserID, urls)
+		sched := NewScheduler()
+		p.Schedule(sched, testTicker, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, urls)
+		require.NoError(t, err)
 
-		var done = make(chan struct{})
+		wg.Wait()
 
-		go func() {
-			wg.Wait()
-			close(done)
-		}()
-
-		<-done
+		require.NoError(t, sched.Shutdown(context.Background()))
 	})
 
 	t.Run("serv error", func(t *testing.T) {
@@ -57,38 +64,335 @@ func TestDeletionProcessor_Run(t *testing.T) {
 		var wg sync.WaitGroup
 		wg.Add(1)
 
-		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), gomock.Any()).Return(errTest).Times(1).Do(func(_, _ interface{}) {
-			wg.Done()
-		})
+		// The failed batch is retried until it succeeds.
+		gomock.InOrder(
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(errTest).Times(1),
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1).Do(func(_, _ interface{}) {
+				wg.Done()
+			}),
+		)
 
-		p := NewDeletionProcessor(mServ)
-		defer p.Shutdown()
+		p := NewDeletionProcessor(mServ, WithRetryBackoff(testTicker, testTicker))
 
 		urls := []models.ShortURL{"url1", "url2", "url3"}
 
-		p.Run(testTicker, testTimeout)
-		p.AddURLsIntoDeletionQueue(testUserID, urls)
-
-		var done = make(chan struct{})
+		sched := NewScheduler()
+		p.Schedule(sched, testTicker, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, urls)
+		require.NoError(t, err)
 
-		go func() {
-			wg.Wait()
-			close(done)
-		}()
+		wg.Wait()
 
-		<-done
+		require.NoError(t, sched.Shutdown(context.Background()))
 	})
 
 	t.Run("shutdown", func(t *testing.T) {
 		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
 
+		// Queued requests are flushed on shutdown.
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1)
+
 		p := NewDeletionProcessor(mServ)
 
 		urls := []models.ShortURL{"url1", "url2", "url3"}
 
-		p.Run(testTicker, testTimeout)
-		p.AddURLsIntoDeletionQueue(testUserID, urls)
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, urls)
+		require.NoError(t, err)
+
+		require.NoError(t, sched.Shutdown(context.Background()))
+
+		err = p.AddURLsIntoDeletionQueue(testUserID, urls)
+		assert.ErrorIs(t, err, ErrProcessorClosed)
+	})
+
+	t.Run("log error", func(t *testing.T) {
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+		mLog := mocks.NewMockdeletionLog(ctrl)
+
+		mLog.EXPECT().Pending().Return(nil)
+		mLog.EXPECT().Append(testDelReqs).Return(errTest)
+
+		p := NewDeletionProcessor(mServ, WithDeletionLog(mLog))
+
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+		assert.ErrorIs(t, err, errTest)
+
+		// Requests which failed to be logged are not deleted.
+		require.NoError(t, sched.Shutdown(context.Background()))
+	})
+}
+
+func TestDeletionProcessor_SlowLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+	mLog := mocks.NewMockdeletionLog(ctrl)
+
+	appending := make(chan struct{})
+	release := make(chan struct{})
+	mLog.EXPECT().Pending().Return(nil)
+	mLog.EXPECT().Append(testDelReqs).DoAndReturn(func([]*models.DelURLReq) error {
+		close(appending)
+		<-release
+		return nil
+	})
+	mLog.EXPECT().Commit(3).Return(nil)
+	mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1)
+
+	p := NewDeletionProcessor(mServ, WithDeletionLog(mLog), WithQueueCapacity(3))
+
+	sched := NewScheduler()
+	p.Schedule(sched, time.Hour, testTimeout)
+	sched.Start()
+
+	done := make(chan error)
+	go func() {
+		done <- p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+	}()
+	<-appending
+
+	// The queue is not locked while the log syncs, but the room is taken.
+	assert.Zero(t, p.Stats().Depth)
+	err := p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url4"})
+	assert.ErrorIs(t, err, errQueueFull)
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, 3, p.Stats().Depth)
+
+	require.NoError(t, sched.Shutdown(context.Background()))
+}
+
+func TestDeletionProcessor_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewDeletionProcessor(mocks.NewMockbatchDeleteServicer(ctrl))
+	assert.ErrorIs(t, p.Check(context.Background()), errNotScheduled)
+
+	sched := NewScheduler()
+	p.Schedule(sched, time.Hour, testTimeout)
+	assert.ErrorIs(t, p.Check(context.Background()), errJobNotRunning)
+
+	sched.Start()
+	assert.NoError(t, p.Check(context.Background()))
+
+	require.NoError(t, sched.Shutdown(context.Background()))
+	assert.ErrorIs(t, p.Check(context.Background()), errJobNotRunning)
+}
+
+func TestDeletionProcessor_Batching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("full batch flushed immediately", func(t *testing.T) {
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		gomock.InOrder(
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs[:2]).Return(nil).Times(1).Do(func(_, _ interface{}) {
+				wg.Done()
+			}),
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs[2:]).Return(nil).Times(1),
+		)
+
+		p := NewDeletionProcessor(mServ, WithMaxBatchSize(2))
+
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1"})
+		require.NoError(t, err)
+		err = p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url2", "url3"})
+		require.NoError(t, err)
+
+		// The first batch goes without waiting for the ticker,
+		// the rest is flushed on shutdown.
+		wg.Wait()
+		require.NoError(t, sched.Shutdown(context.Background()))
+
+		stats := p.Stats()
+		assert.Equal(t, int64(3), stats.Deleted)
+		assert.Zero(t, stats.Depth)
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+
+		const initial, max = 10 * time.Millisecond, 40 * time.Millisecond
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		var calls []time.Time
+		record := func(_, _ interface{}) {
+			calls = append(calls, time.Now())
+		}
+		gomock.InOrder(
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(errTest).Times(4).Do(record),
+			mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1).Do(func(_, _ interface{}) {
+				wg.Done()
+			}),
+		)
+
+		p := NewDeletionProcessor(mServ, WithMaxBatchSize(3), WithRetryBackoff(initial, max))
+
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+		require.NoError(t, err)
+
+		wg.Wait()
+		require.NoError(t, sched.Shutdown(context.Background()))
+
+		// Each retry waits twice as long as the previous one up to max.
+		require.Len(t, calls, 4)
+		for i, want := range []time.Duration{initial, 2 * initial, max} {
+			assert.GreaterOrEqual(t, calls[i+1].Sub(calls[i]), want)
+		}
+
+		stats := p.Stats()
+		assert.Equal(t, int64(4), stats.FailedBatches)
+		assert.Equal(t, int64(3), stats.Deleted)
+		assert.Zero(t, stats.RetryIn)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1)
+
+		p := NewDeletionProcessor(mServ, WithQueueCapacity(3))
+
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+		err := p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+		require.NoError(t, err)
+
+		err = p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url4"})
+		var e interface {
+			IsErrQueueFull() bool
+			RetryAfter() time.Duration
+		}
+		require.ErrorAs(t, err, &e)
+		assert.True(t, e.IsErrQueueFull())
+		// Room is expected after the next scheduled flush.
+		assert.InDelta(t, time.Hour, e.RetryAfter(), float64(time.Second))
+
+		err = p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3", "url4"})
+		assert.ErrorIs(t, err, errTooLargeBatch)
+		var tooLarge interface{ IsErrTooLarge() bool }
+		require.ErrorAs(t, err, &tooLarge)
+		assert.True(t, tooLarge.IsErrTooLarge())
+
+		assert.Equal(t, models.DeletionQueueStats{
+			Depth:    3,
+			Capacity: 3,
+			Rejected: 1,
+		}, p.Stats())
+
+		require.NoError(t, sched.Shutdown(context.Background()))
+	})
+}
+
+func TestDeletionProcessor_Durability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("killed mid-batch", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+
+		inBatch := make(chan struct{})
+		release := make(chan struct{})
+
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).DoAndReturn(func(context.Context, []*models.DelURLReq) error {
+			close(inBatch)
+			<-release
+			return errTest
+		}).Times(1)
+
+		p := NewDeletionProcessor(mServ, WithDeletionLog(log))
+		sched := NewScheduler()
+		p.Schedule(sched, testTicker, time.Hour)
+		sched.Start()
+
+		err = p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+		require.NoError(t, err)
+
+		// The process dies while the batch is being deleted,
+		// only the log on disk survives.
+		<-inBatch
+		restarted, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Equal(t, testDelReqs, restarted.Pending())
+
+		// The replayed requests are deleted by the restarted worker.
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mRestartedServ := mocks.NewMockbatchDeleteServicer(ctrl)
+		mRestartedServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1).Do(func(_, _ interface{}) {
+			wg.Done()
+		})
+
+		rp := NewDeletionProcessor(mRestartedServ, WithDeletionLog(restarted))
+		rSched := NewScheduler()
+		rp.Schedule(rSched, testTicker, testTimeout)
+		rSched.Start()
+		wg.Wait()
+		require.NoError(t, rSched.Shutdown(context.Background()))
+		require.NoError(t, restarted.Close())
+
+		reopened, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Empty(t, reopened.Pending())
+		require.NoError(t, reopened.Close())
+
+		// Let the killed worker go, its batch has not been committed.
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), gomock.Any()).Return(errTest).AnyTimes()
+		close(release)
+		require.NoError(t, sched.Shutdown(context.Background()))
+		require.NoError(t, log.Close())
+	})
+
+	t.Run("flushed on shutdown", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "deletions.log")
+
+		log, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+
+		mServ := mocks.NewMockbatchDeleteServicer(ctrl)
+		mServ.EXPECT().DeleteURLsBatch(gomock.Any(), testDelReqs).Return(nil).Times(1)
+
+		p := NewDeletionProcessor(mServ, WithDeletionLog(log))
+		sched := NewScheduler()
+		p.Schedule(sched, time.Hour, testTimeout)
+		sched.Start()
+
+		err = p.AddURLsIntoDeletionQueue(testUserID, []models.ShortURL{"url1", "url2", "url3"})
+		require.NoError(t, err)
+
+		require.NoError(t, sched.Shutdown(context.Background()))
+		require.NoError(t, log.Close())
 
-		p.Shutdown()
+		reopened, err := OpenDeletionLog(name)
+		require.NoError(t, err)
+		assert.Empty(t, reopened.Pending())
+		require.NoError(t, reopened.Close())
 	})
 }
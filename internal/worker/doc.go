@@ -0,0 +1,3 @@
+// Package worker implements background jobs of the URL shortener service:
+// a job scheduler, the URL deletion processor and storage snapshots.
+package worker
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProcessorClosed is returned when deletion is requested after Shutdown.
+	ErrProcessorClosed = errors.New("deletion processor is shut down")
+
+	errQueueFull     = errors.New("deletion queue is full")
+	errTooLargeBatch = errors.New("deletion request exceeds queue capacity")
+	errNotScheduled  = errors.New("deletion processor is not scheduled")
+)
+
+// queueFull represents an error when deletion queue has no room for a request.
+type queueFull struct {
+	err        error
+	retryAfter time.Duration
+}
+
+// Error returns the string representation of the error.
+func (err *queueFull) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *queueFull) Unwrap() error {
+	return err.err
+}
+
+// IsErrQueueFull provides type checking capability.
+func (err *queueFull) IsErrQueueFull() bool {
+	return true
+}
+
+// RetryAfter returns time after which the queue is expected to have room.
+func (err *queueFull) RetryAfter() time.Duration {
+	return err.retryAfter
+}
+
+// newErrQueueFull constructs a new queueFull error.
+func newErrQueueFull(err error, retryAfter time.Duration) error {
+	return &queueFull{
+		err:        err,
+		retryAfter: retryAfter,
+	}
+}
+
+// tooLargeBatch represents an error when deletion request exceeds
+// the queue capacity and can never be accepted.
+type tooLargeBatch struct {
+	err error
+}
+
+// Error returns the string representation of the error.
+func (err *tooLargeBatch) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *tooLargeBatch) Unwrap() error {
+	return err.err
+}
+
+// IsErrTooLarge provides type checking capability.
+func (err *tooLargeBatch) IsErrTooLarge() bool {
+	return true
+}
+
+// newErrTooLargeBatch constructs a new tooLargeBatch error.
+func newErrTooLargeBatch(err error) error {
+	return &tooLargeBatch{
+		err: err,
+	}
+}
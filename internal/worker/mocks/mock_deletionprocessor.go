@@ -48,3 +48,68 @@ func (mr *MockbatchDeleteServicerMockRecorder) DeleteURLsBatch(arg0, arg1 interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLsBatch", reflect.TypeOf((*MockbatchDeleteServicer)(nil).DeleteURLsBatch), arg0, arg1)
 }
+
+// MockdeletionLog is a mock of deletionLog interface.
+type MockdeletionLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeletionLogMockRecorder
+}
+
+// MockdeletionLogMockRecorder is the mock recorder for MockdeletionLog.
+type MockdeletionLogMockRecorder struct {
+	mock *MockdeletionLog
+}
+
+// NewMockdeletionLog creates a new mock instance.
+func NewMockdeletionLog(ctrl *gomock.Controller) *MockdeletionLog {
+	mock := &MockdeletionLog{ctrl: ctrl}
+	mock.recorder = &MockdeletionLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeletionLog) EXPECT() *MockdeletionLogMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockdeletionLog) Append(arg0 []*models.DelURLReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockdeletionLogMockRecorder) Append(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockdeletionLog)(nil).Append), arg0)
+}
+
+// Commit mocks base method.
+func (m *MockdeletionLog) Commit(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockdeletionLogMockRecorder) Commit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockdeletionLog)(nil).Commit), arg0)
+}
+
+// Pending mocks base method.
+func (m *MockdeletionLog) Pending() []*models.DelURLReq {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending")
+	ret0, _ := ret[0].([]*models.DelURLReq)
+	return ret0
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockdeletionLogMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockdeletionLog)(nil).Pending))
+}
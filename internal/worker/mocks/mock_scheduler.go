@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockleaderElector is a mock of leaderElector interface.
+type MockleaderElector struct {
+	ctrl     *gomock.Controller
+	recorder *MockleaderElectorMockRecorder
+}
+
+// MockleaderElectorMockRecorder is the mock recorder for MockleaderElector.
+type MockleaderElectorMockRecorder struct {
+	mock *MockleaderElector
+}
+
+// NewMockleaderElector creates a new mock instance.
+func NewMockleaderElector(ctrl *gomock.Controller) *MockleaderElector {
+	mock := &MockleaderElector{ctrl: ctrl}
+	mock.recorder = &MockleaderElectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockleaderElector) EXPECT() *MockleaderElectorMockRecorder {
+	return m.recorder
+}
+
+// IsLeader mocks base method.
+func (m *MockleaderElector) IsLeader(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockleaderElectorMockRecorder) IsLeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockleaderElector)(nil).IsLeader), arg0)
+}
+
+// Resign mocks base method.
+func (m *MockleaderElector) Resign() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resign")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resign indicates an expected call of Resign.
+func (mr *MockleaderElectorMockRecorder) Resign() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resign", reflect.TypeOf((*MockleaderElector)(nil).Resign))
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshotter.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksnapshotSaver is a mock of snapshotSaver interface.
+type MocksnapshotSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MocksnapshotSaverMockRecorder
+}
+
+// MocksnapshotSaverMockRecorder is the mock recorder for MocksnapshotSaver.
+type MocksnapshotSaverMockRecorder struct {
+	mock *MocksnapshotSaver
+}
+
+// NewMocksnapshotSaver creates a new mock instance.
+func NewMocksnapshotSaver(ctrl *gomock.Controller) *MocksnapshotSaver {
+	mock := &MocksnapshotSaver{ctrl: ctrl}
+	mock.recorder = &MocksnapshotSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksnapshotSaver) EXPECT() *MocksnapshotSaverMockRecorder {
+	return m.recorder
+}
+
+// SaveSnapshot mocks base method.
+func (m *MocksnapshotSaver) SaveSnapshot(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MocksnapshotSaverMockRecorder) SaveSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MocksnapshotSaver)(nil).SaveSnapshot), arg0)
+}
@@ -0,0 +1,518 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rycln/shorturl/internal/logger"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+// Default scheduler limits.
+const (
+	defaultJobQueueSize = 100
+	defaultJobWorkers   = 1
+)
+
+var (
+	// ErrSchedulerClosed is returned when a job is enqueued after Shutdown.
+	ErrSchedulerClosed = errors.New("scheduler is shut down")
+
+	errJobQueueFull  = errors.New("job queue is full")
+	errJobPanicked   = errors.New("job panicked")
+	errJobNotRunning = errors.New("job is not running")
+	errJobStalled    = errors.New("job is stalled")
+)
+
+type leaderElector interface {
+	IsLeader(context.Context) (bool, error)
+	Resign() error
+}
+
+// JobFunc performs a single run of a background job.
+//
+// The context is cancelled when the run times out or the scheduler shuts down.
+type JobFunc func(context.Context) error
+
+// Job is a background job registered in a scheduler.
+type Job struct {
+	name       string
+	period     time.Duration
+	timeout    time.Duration
+	backoff    time.Duration
+	maxBackoff time.Duration
+	run        JobFunc
+	final      JobFunc
+	singleton  bool
+	trigger    chan struct{}
+
+	mu       sync.Mutex
+	running  bool
+	failures int
+	tickAt   time.Time
+	retryAt  time.Time
+}
+
+type jobOption func(*Job)
+
+// WithJobTimeout limits duration of every run of the job.
+func WithJobTimeout(timeout time.Duration) jobOption {
+	return func(j *Job) {
+		j.timeout = timeout
+	}
+}
+
+// WithJobBackoff makes a periodic job retry failed runs.
+//
+// The delay starts at initial and doubles after each failure up to max.
+// Scheduled and triggered runs are skipped until the retry.
+func WithJobBackoff(initial, max time.Duration) jobOption {
+	return func(j *Job) {
+		j.backoff = initial
+		j.maxBackoff = max
+	}
+}
+
+// WithJobFinalRun makes a periodic job call fn once on shutdown.
+//
+// The final run is not cancelled by the shutdown, it is limited
+// by the job timeout only.
+func WithJobFinalRun(fn JobFunc) jobOption {
+	return func(j *Job) {
+		j.final = fn
+	}
+}
+
+// WithJobSingleton makes the job run on the elected leader instance only.
+//
+// Runs on other instances are skipped until the leader dies
+// and one of them takes its leadership over.
+//
+// No job of the service is a singleton yet: deletion, snapshot and health
+// jobs work on state of their own instance and must run everywhere. The
+// option is meant for future jobs doing cluster-wide work once, such as
+// purging expired links.
+func WithJobSingleton() jobOption {
+	return func(j *Job) {
+		j.singleton = true
+	}
+}
+
+// Trigger requests a run of the periodic job as soon as possible.
+//
+// Requests made while a run is pending are coalesced.
+func (j *Job) Trigger() {
+	select {
+	case j.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// NextRunIn returns time left until the next scheduled run or retry,
+// zero if there is none.
+func (j *Job) NextRunIn() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	next := j.tickAt
+	if !j.retryAt.IsZero() {
+		next = j.retryAt
+	}
+	return max(time.Until(next), 0)
+}
+
+// RetryIn returns time left until a failed run is retried, zero if none.
+func (j *Job) RetryIn() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return max(time.Until(j.retryAt), 0)
+}
+
+// Check reports whether the periodic job keeps its schedule.
+//
+// It fails if the job is not running, because the scheduler is not started
+// or is shut down, or if a scheduled run is late by more than a period,
+// because the previous run hangs. Failed runs waiting for a retry
+// do not fail the check.
+func (j *Job) Check() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.running {
+		return fmt.Errorf("%w: %s", errJobNotRunning, j.name)
+	}
+	if j.period > 0 {
+		if late := time.Since(j.tickAt); late > j.period+j.timeout {
+			return fmt.Errorf("%w: %s is late by %s", errJobStalled, j.name, late.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+func (j *Job) setRunning(running bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.running = running
+}
+
+// succeeded resets the backoff.
+func (j *Job) succeeded() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.failures = 0
+	j.retryAt = time.Time{}
+}
+
+// failed registers a failed run and returns delay before the retry.
+func (j *Job) failed() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	delay := j.backoff
+	for i := 0; i < j.failures && delay < j.maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, j.maxBackoff)
+
+	j.failures++
+	j.retryAt = time.Now().Add(delay)
+
+	return delay
+}
+
+func (j *Job) ticked() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.tickAt = time.Now().Add(j.period)
+}
+
+// queuedJob is a one-off job waiting for a free worker.
+type queuedJob struct {
+	name    string
+	timeout time.Duration
+	run     JobFunc
+}
+
+// Scheduler runs periodic and queued background jobs.
+//
+// Every run is limited by the job timeout, recovered from panics and logged.
+// Periodic jobs run every period and whenever triggered. Queued jobs are
+// one-off runs executed by a pool of workers in the order they were enqueued.
+// Shutdown cancels running periodic jobs, waits for their final runs and
+// for all accepted queued jobs.
+type Scheduler struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	queue   chan queuedJob
+	workers int
+	leader  leaderElector
+
+	mu      sync.Mutex
+	jobs    []*Job
+	started bool
+	closed  bool
+	leading bool
+}
+
+type schedulerOption func(*Scheduler)
+
+// WithJobQueueSize limits number of queued jobs waiting for a worker.
+func WithJobQueueSize(size int) schedulerOption {
+	return func(s *Scheduler) {
+		s.queue = make(chan queuedJob, size)
+	}
+}
+
+// WithJobWorkers sets number of workers running queued jobs.
+func WithJobWorkers(n int) schedulerOption {
+	return func(s *Scheduler) {
+		s.workers = n
+	}
+}
+
+// WithLeaderElector sets the elector choosing an instance to run singleton jobs.
+// Without it the instance is always the leader.
+func WithLeaderElector(leader leaderElector) schedulerOption {
+	return func(s *Scheduler) {
+		s.leader = leader
+	}
+}
+
+// NewScheduler creates new scheduler instance.
+func NewScheduler(opts ...schedulerOption) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		ctx:     ctx,
+		cancel:  cancel,
+		queue:   make(chan queuedJob, defaultJobQueueSize),
+		workers: defaultJobWorkers,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Every registers a job running fn every period.
+//
+// Non-positive period makes the job run only when triggered. The job
+// starts with the scheduler or immediately if the scheduler is running.
+func (s *Scheduler) Every(name string, period time.Duration, fn JobFunc, opts ...jobOption) *Job {
+	j := &Job{
+		name:    name,
+		period:  period,
+		run:     fn,
+		trigger: make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	if j.singleton && s.leader != nil {
+		j.run = s.leaderOnly(name, j.run)
+		if j.final != nil {
+			j.final = s.leaderOnly(name, j.final)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, j)
+	if s.started && !s.closed {
+		s.startPeriodic(j)
+	}
+
+	return j
+}
+
+// Enqueue submits a one-off job.
+//
+// The job runs even if the scheduler is shut down after it was accepted.
+// Returns an error if the queue is full or the scheduler is shut down.
+func (s *Scheduler) Enqueue(name string, fn JobFunc, opts ...jobOption) error {
+	j := &Job{run: fn}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	if j.singleton && s.leader != nil {
+		j.run = s.leaderOnly(name, j.run)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrSchedulerClosed
+	}
+
+	select {
+	case s.queue <- queuedJob{name: name, timeout: j.timeout, run: j.run}:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errJobQueueFull, name)
+	}
+}
+
+// Start launches registered periodic jobs and queued job workers.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started || s.closed {
+		return
+	}
+	s.started = true
+
+	for _, j := range s.jobs {
+		s.startPeriodic(j)
+	}
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.runWorker()
+	}
+}
+
+// Shutdown stops the scheduler and waits for the jobs to finish
+// or for the context to expire.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		s.resign()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("jobs shutdown timeout: %w", ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+// startPeriodic launches the periodic job. The caller must hold the lock.
+func (s *Scheduler) startPeriodic(j *Job) {
+	if j.period > 0 {
+		j.ticked()
+	}
+	j.setRunning(true)
+
+	s.wg.Add(1)
+	go s.runPeriodic(j)
+}
+
+// leaderOnly wraps fn to run only while the instance is the leader.
+func (s *Scheduler) leaderOnly(name string, fn JobFunc) JobFunc {
+	return func(ctx context.Context) error {
+		leading, err := s.leader.IsLeader(ctx)
+		s.setLeading(leading)
+		if err != nil {
+			return fmt.Errorf("leader election failed: %w", err)
+		}
+		if !leading {
+			logger.Log.Debug("Job skipped, not a leader", zap.String("job", name))
+			return nil
+		}
+		return fn(ctx)
+	}
+}
+
+// setLeading logs leadership changes.
+func (s *Scheduler) setLeading(leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if leading == s.leading {
+		return
+	}
+	s.leading = leading
+
+	if leading {
+		logger.Log.Info("Became leader of singleton jobs")
+	} else {
+		logger.Log.Info("Lost leadership of singleton jobs")
+	}
+}
+
+// resign gives leadership up after all jobs are done,
+// so another instance takes it over without waiting.
+func (s *Scheduler) resign() {
+	if s.leader == nil {
+		return
+	}
+
+	err := s.leader.Resign()
+	if err != nil {
+		logger.Log.Info("Cannot resign leadership", zap.Error(err))
+	}
+}
+
+func (s *Scheduler) runPeriodic(j *Job) {
+	defer s.wg.Done()
+	defer j.setRunning(false)
+
+	var tickCh <-chan time.Time
+	if j.period > 0 {
+		tick := time.NewTicker(j.period)
+		defer tick.Stop()
+		tickCh = tick.C
+	}
+
+	// retryCh is not nil while a failed run waits for its retry.
+	var retryCh <-chan time.Time
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			if j.final != nil {
+				_ = s.runJob(context.Background(), j.name+" final", j.timeout, j.final)
+			}
+			return
+		case <-tickCh:
+			j.ticked()
+		case <-j.trigger:
+		case <-retryCh:
+			retryCh = nil
+		}
+
+		if retryCh != nil {
+			continue
+		}
+
+		err := s.runJob(s.ctx, j.name, j.timeout, j.run)
+		if err != nil && j.backoff > 0 {
+			retryCh = time.After(j.failed())
+			continue
+		}
+		j.succeeded()
+	}
+}
+
+func (s *Scheduler) runWorker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case qj := <-s.queue:
+			_ = s.runJob(context.Background(), qj.name, qj.timeout, qj.run)
+		case <-s.ctx.Done():
+			// Accepted jobs are run before shutdown completes.
+			for {
+				select {
+				case qj := <-s.queue:
+					_ = s.runJob(context.Background(), qj.name, qj.timeout, qj.run)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// runJob makes a single run of the job converting a panic into an error.
+func (s *Scheduler) runJob(parent context.Context, name string, timeout time.Duration, fn JobFunc) (err error) {
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errJobPanicked, r)
+			logger.Log.Error("Job panicked", zap.String("job", name), zap.Any("panic", r), zap.Stack("stack"))
+		}
+
+		if err != nil {
+			logger.Log.Info("Job failed", zap.String("job", name), zap.Duration("took", time.Since(start)), zap.Error(err))
+			return
+		}
+		logger.Log.Debug("Job done", zap.String("job", name), zap.Duration("took", time.Since(start)))
+	}()
+
+	return fn(ctx)
+}
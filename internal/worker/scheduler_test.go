@@ -0,0 +1,333 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/worker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Every(t *testing.T) {
+	t.Run("periodic runs", func(t *testing.T) {
+		s := NewScheduler()
+
+		ran := make(chan struct{}, 3)
+		s.Every("test", testTicker, func(context.Context) error {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		s.Start()
+
+		for i := 0; i < 3; i++ {
+			<-ran
+		}
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		s := NewScheduler()
+
+		ran := make(chan struct{}, 1)
+		job := s.Every("test", 0, func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+		s.Start()
+
+		assert.Zero(t, job.NextRunIn())
+
+		job.Trigger()
+		<-ran
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("registered after start", func(t *testing.T) {
+		s := NewScheduler()
+		s.Start()
+
+		ran := make(chan struct{}, 1)
+		job := s.Every("test", time.Hour, func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+		assert.InDelta(t, time.Hour, job.NextRunIn(), float64(time.Second))
+
+		job.Trigger()
+		<-ran
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		s := NewScheduler()
+
+		errCh := make(chan error, 1)
+		job := s.Every("test", 0, func(ctx context.Context) error {
+			<-ctx.Done()
+			errCh <- ctx.Err()
+			return ctx.Err()
+		}, WithJobTimeout(testTimeout))
+		s.Start()
+
+		job.Trigger()
+		assert.ErrorIs(t, <-errCh, context.DeadlineExceeded)
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		s := NewScheduler()
+
+		var runs atomic.Int32
+		ran := make(chan struct{}, 1)
+		job := s.Every("test", 0, func(context.Context) error {
+			if runs.Add(1) == 1 {
+				panic("test panic")
+			}
+			ran <- struct{}{}
+			return nil
+		})
+		s.Start()
+
+		// The job survives the panic and runs again.
+		job.Trigger()
+		require.Eventually(t, func() bool {
+			return runs.Load() == 1
+		}, time.Second, time.Millisecond)
+		job.Trigger()
+		<-ran
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		s := NewScheduler()
+
+		const initial, max = 10 * time.Millisecond, 40 * time.Millisecond
+
+		var calls []time.Time
+		done := make(chan struct{})
+		job := s.Every("test", time.Hour, func(context.Context) error {
+			calls = append(calls, time.Now())
+			if len(calls) < 5 {
+				return errTest
+			}
+			close(done)
+			return nil
+		}, WithJobBackoff(initial, max))
+		s.Start()
+
+		job.Trigger()
+		<-done
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		// Each retry waits twice as long as the previous one up to max.
+		require.Len(t, calls, 5)
+		for i, want := range []time.Duration{initial, 2 * initial, 4 * initial, max} {
+			assert.GreaterOrEqual(t, calls[i+1].Sub(calls[i]), want)
+		}
+		assert.Zero(t, job.RetryIn())
+	})
+
+	t.Run("final run", func(t *testing.T) {
+		s := NewScheduler()
+
+		var finalErr error
+		s.Every("test", time.Hour, func(context.Context) error {
+			return nil
+		}, WithJobTimeout(time.Hour), WithJobFinalRun(func(ctx context.Context) error {
+			// The final run is not cancelled by the shutdown.
+			finalErr = ctx.Err()
+			return nil
+		}))
+		s.Start()
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		assert.NoError(t, finalErr)
+	})
+}
+
+func TestJob_Check(t *testing.T) {
+	s := NewScheduler()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	job := s.Every("test", testTicker, func(context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+	assert.ErrorIs(t, job.Check(), errJobNotRunning)
+
+	s.Start()
+	assert.NoError(t, job.Check())
+
+	// A hanging run makes the scheduled runs late.
+	<-started
+	require.Eventually(t, func() bool {
+		return errors.Is(job.Check(), errJobStalled)
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	require.NoError(t, s.Shutdown(context.Background()))
+	assert.ErrorIs(t, job.Check(), errJobNotRunning)
+}
+
+func TestScheduler_Singleton(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("failover", func(t *testing.T) {
+		mLeader := mocks.NewMockleaderElector(ctrl)
+
+		elected := make(chan struct{}, 1)
+		elect := func(any) {
+			elected <- struct{}{}
+		}
+
+		// The instance follows until the leader dies and then takes over.
+		gomock.InOrder(
+			mLeader.EXPECT().IsLeader(gomock.Any()).Return(false, nil).Times(2).Do(elect),
+			mLeader.EXPECT().IsLeader(gomock.Any()).Return(true, nil).Times(1).Do(elect),
+		)
+		mLeader.EXPECT().Resign().Return(nil).Times(1)
+
+		s := NewScheduler(WithLeaderElector(mLeader))
+
+		var runs atomic.Int32
+		job := s.Every("test", 0, func(context.Context) error {
+			runs.Add(1)
+			return nil
+		}, WithJobSingleton())
+
+		ran := make(chan struct{}, 1)
+		other := s.Every("other", 0, func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+		s.Start()
+
+		// Jobs which are not singletons run on every instance.
+		other.Trigger()
+		<-ran
+
+		for i := 0; i < 3; i++ {
+			job.Trigger()
+			<-elected
+		}
+
+		// Leadership is given up on shutdown.
+		require.NoError(t, s.Shutdown(context.Background()))
+		assert.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("election error", func(t *testing.T) {
+		mLeader := mocks.NewMockleaderElector(ctrl)
+		mLeader.EXPECT().IsLeader(gomock.Any()).Return(false, errTest).Times(1)
+		mLeader.EXPECT().Resign().Return(nil).Times(1)
+
+		s := NewScheduler(WithLeaderElector(mLeader))
+
+		done := make(chan struct{})
+		err := s.Enqueue("test", func(context.Context) error {
+			return nil
+		}, WithJobSingleton())
+		require.NoError(t, err)
+		err = s.Enqueue("done", func(context.Context) error {
+			close(done)
+			return nil
+		})
+		require.NoError(t, err)
+		s.Start()
+
+		<-done
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+}
+
+func TestScheduler_Enqueue(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		s := NewScheduler()
+		s.Start()
+
+		ran := make(chan struct{}, 1)
+		err := s.Enqueue("test", func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+		require.NoError(t, err)
+
+		<-ran
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	t.Run("drained on shutdown", func(t *testing.T) {
+		s := NewScheduler(WithJobWorkers(2))
+
+		var runs atomic.Int32
+		for i := 0; i < 10; i++ {
+			err := s.Enqueue("test", func(context.Context) error {
+				runs.Add(1)
+				return nil
+			})
+			require.NoError(t, err)
+		}
+		s.Start()
+
+		require.NoError(t, s.Shutdown(context.Background()))
+		assert.Equal(t, int32(10), runs.Load())
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		s := NewScheduler(WithJobQueueSize(1))
+
+		noop := func(context.Context) error {
+			return nil
+		}
+		require.NoError(t, s.Enqueue("test", noop))
+		assert.ErrorIs(t, s.Enqueue("test", noop), errJobQueueFull)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		s := NewScheduler()
+		s.Start()
+		require.NoError(t, s.Shutdown(context.Background()))
+
+		err := s.Enqueue("test", func(context.Context) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrSchedulerClosed)
+	})
+}
+
+func TestScheduler_Shutdown(t *testing.T) {
+	s := NewScheduler()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	err := s.Enqueue("test", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	s.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	err = s.Shutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type snapshotSaver interface {
+	SaveSnapshot(string) error
+}
+
+// Snapshotter saves a storage snapshot into a file as a scheduler job.
+type Snapshotter struct {
+	saver    snapshotSaver
+	fileName string
+}
+
+// NewSnapshotter creates new snapshotter instance.
+func NewSnapshotter(saver snapshotSaver, fileName string) *Snapshotter {
+	return &Snapshotter{
+		saver:    saver,
+		fileName: fileName,
+	}
+}
+
+// Schedule registers the snapshotter as a job of the scheduler
+// saving snapshots every period.
+//
+// Non-positive period disables periodic snapshots. The final snapshot
+// is not saved on shutdown, call Save after the storage stops receiving writes.
+func (s *Snapshotter) Schedule(sched *Scheduler, period time.Duration) {
+	if period <= 0 {
+		return
+	}
+
+	sched.Every("snapshot", period, func(context.Context) error {
+		return s.Save()
+	})
+}
+
+// Save writes a snapshot immediately.
+func (s *Snapshotter) Save() error {
+	return s.saver.SaveSnapshot(s.fileName)
+}
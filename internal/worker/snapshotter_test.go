@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/shorturl/internal/worker/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSnapshotName = "snapshot.json"
+
+func TestSnapshotter_Schedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("valid test", func(t *testing.T) {
+		mSaver := mocks.NewMocksnapshotSaver(ctrl)
+
+		saved := make(chan struct{})
+		mSaver.EXPECT().SaveSnapshot(testSnapshotName).Return(nil).MinTimes(1).Do(func(string) {
+			select {
+			case saved <- struct{}{}:
+			default:
+			}
+		})
+
+		sched := NewScheduler()
+		NewSnapshotter(mSaver, testSnapshotName).Schedule(sched, testTicker)
+		sched.Start()
+
+		<-saved
+		assert.NoError(t, sched.Shutdown(context.Background()))
+	})
+
+	t.Run("saver error", func(t *testing.T) {
+		mSaver := mocks.NewMocksnapshotSaver(ctrl)
+
+		saved := make(chan struct{})
+		mSaver.EXPECT().SaveSnapshot(testSnapshotName).Return(errTest).MinTimes(1).Do(func(string) {
+			select {
+			case saved <- struct{}{}:
+			default:
+			}
+		})
+
+		sched := NewScheduler()
+		NewSnapshotter(mSaver, testSnapshotName).Schedule(sched, testTicker)
+		sched.Start()
+
+		<-saved
+		assert.NoError(t, sched.Shutdown(context.Background()))
+	})
+
+	t.Run("periodic snapshots disabled", func(t *testing.T) {
+		mSaver := mocks.NewMocksnapshotSaver(ctrl)
+
+		sched := NewScheduler()
+		NewSnapshotter(mSaver, testSnapshotName).Schedule(sched, 0)
+		sched.Start()
+
+		assert.NoError(t, sched.Shutdown(context.Background()))
+	})
+}
+
+func TestSnapshotter_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSaver := mocks.NewMocksnapshotSaver(ctrl)
+	mSaver.EXPECT().SaveSnapshot(testSnapshotName).Return(errTest)
+
+	s := NewSnapshotter(mSaver, testSnapshotName)
+	assert.ErrorIs(t, s.Save(), errTest)
+}